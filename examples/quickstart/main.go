@@ -4,12 +4,11 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
-	"os/signal"
 	"runtime"
-	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -145,15 +144,12 @@ func main() {
 
 		// Tracing configuration
 		TracingEndpoint:    os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
-		TracingSampleRate:  1.0,
+		TracingSampleRate:  bootstrap.SampleRate(1.0),
 		TracingPropagators: []string{"tracecontext", "baggage"},
 	}, deps, nil) // No hooks needed for production use
 
 	if err != nil {
-		logger.ErrorWith("Failed to create service", domainlog.Fields{
-			"error": err.Error(),
-		})
-		os.Exit(1)
+		logger.WithError(err).Fatal("Failed to create service")
 	}
 
 	// Get router and add routes
@@ -169,37 +165,16 @@ func main() {
 	// Print API documentation using logger
 	printAPIDoc(logger)
 
-	// Start service
-	errChan := make(chan error, 1)
-	go func() {
-		if err := svc.Start(); err != nil {
-			errChan <- fmt.Errorf("failed to start service: %w", err)
-		}
-	}()
-
-	// Wait for shutdown signal
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-	select {
-	case err := <-errChan:
-		logger.ErrorWith("Service error", domainlog.Fields{
-			"error": err.Error(),
-		})
-		os.Exit(1)
-	case sig := <-sigChan:
-		logger.InfoWith("Received shutdown signal", domainlog.Fields{
-			"signal": sig.String(),
-		})
+	// Run service until SIGINT/SIGTERM, then shut down gracefully
+	if err := svc.Run(context.Background()); err != nil {
+		logger.WithError(err).Fatal("Service error")
 	}
+}
 
-	// Graceful shutdown
-	if err := svc.Shutdown(context.Background()); err != nil {
-		logger.ErrorWith("Shutdown error", domainlog.Fields{
-			"error": err.Error(),
-		})
-		os.Exit(1)
-	}
+// todoDecodeOpts limits todo request bodies and rejects unexpected fields
+var todoDecodeOpts = httpadapter.DecodeOpts{
+	MaxBytes:              1 << 16,
+	DisallowUnknownFields: true,
 }
 
 // In-memory store for demo purposes
@@ -228,11 +203,9 @@ func handleCreateTodo(svc *bootstrap.Service, probe *applicationProbe) http.Hand
 		logger := svc.Logger()
 
 		var todo Todo
-		if err := json.NewDecoder(r.Body).Decode(&todo); err != nil {
-			logger.ErrorWith("Invalid request body", domainlog.Fields{
-				"error": err.Error(),
-			})
-			respondError(w, http.StatusBadRequest, "Invalid request body")
+		if err := httpadapter.DecodeJSON(r, &todo, todoDecodeOpts); err != nil {
+			logger.WithError(err).Error("Invalid request body")
+			respondDecodeError(w, err)
 			return
 		}
 
@@ -377,12 +350,12 @@ func handleUpdateTodo(svc *bootstrap.Service) http.HandlerFunc {
 		}
 
 		var updates Todo
-		if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		if err := httpadapter.DecodeJSON(r, &updates, todoDecodeOpts); err != nil {
 			logger.ErrorWith("Invalid request body", domainlog.Fields{
 				"error": err.Error(),
 				"id":    id,
 			})
-			respondError(w, http.StatusBadRequest, "Invalid request body")
+			respondDecodeError(w, err)
 			return
 		}
 
@@ -443,3 +416,13 @@ func respondError(w http.ResponseWriter, status int, message string) {
 	// Best effort to write the error response
 	json.NewEncoder(w).Encode(response)
 }
+
+// respondDecodeError maps a body decoding failure to a 400 or 413 response
+func respondDecodeError(w http.ResponseWriter, err error) {
+	var derr *httpadapter.DecodeError
+	if errors.As(err, &derr) {
+		respondError(w, derr.StatusCode(), derr.Error())
+		return
+	}
+	respondError(w, http.StatusBadRequest, "Invalid request body")
+}
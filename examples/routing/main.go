@@ -36,6 +36,8 @@ func printExampleRequests() {
 	fmt.Println(`curl  -X POST -H "Content-Type: application/json" -d '{"name":"John Doe","email":"john@example.com"}' http://localhost:8080/api/v1/users`)
 	fmt.Println("\n# Get user profile (requires auth)")
 	fmt.Println(`curl  -H "Authorization: Bearer token123" http://localhost:8080/api/v1/user/profile`)
+	fmt.Println("\n# Stream server time as Server-Sent Events")
+	fmt.Println("curl  -N http://localhost:8080/api/v1/events")
 
 	fmt.Println("\n=== Kubernetes Probe Endpoints (excluded from tracing & logging) ===")
 	fmt.Println("# Liveness probe")
@@ -47,9 +49,9 @@ func printExampleRequests() {
 
 	fmt.Println("\n=== Logging Configuration Endpoint (if supported) ===")
 	fmt.Println("# Get current log level")
-	fmt.Println("curl  http://localhost:8080/internal/logging/config")
+	fmt.Println("curl  http://localhost:8080/internal/logging")
 	fmt.Println("\n# Update log level")
-	fmt.Println(`curl  -X PUT -H "Content-Type: application/json" -d '{"level":"debug"}' http://localhost:8080/internal/logging/config`)
+	fmt.Println(`curl  -X PUT -H "Content-Type: application/json" -d '{"level":"debug"}' http://localhost:8080/internal/logging`)
 
 	fmt.Println("\n=== Observability Endpoints (excluded from tracing & logging) ===")
 	fmt.Println("# Prometheus metrics")
@@ -93,10 +95,9 @@ func main() {
 	logger.Info("Starting example service")
 	router, err := setupRouter()
 	if err != nil {
-		logger.ErrorWith("Failed to setup router", domainlog.Fields{
+		logger.FatalWith("Failed to setup router", domainlog.Fields{
 			"error": err.Error(),
 		})
-		os.Exit(1)
 	}
 
 	// Create server
@@ -114,10 +115,9 @@ func main() {
 			"address": srv.Addr,
 		})
 		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
-			logger.ErrorWith("Server error", domainlog.Fields{
+			logger.FatalWith("Server error", domainlog.Fields{
 				"error": err.Error(),
 			})
-			os.Exit(1)
 		}
 	}()
 
@@ -221,6 +221,7 @@ func setupRouter() (domainhttp.Router, error) {
 		r.Get("/users", handleGetUsers)
 		r.Post("/users", handleCreateUser)
 		r.Get("/users/{id}", handleGetUser)
+		r.Get("/events", handleEvents)
 
 		// Example handler with middleware
 		r.Route("/user", func(r chi.Router) {
@@ -259,6 +260,32 @@ func handleGetUser(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, user)
 }
 
+// handleEvents streams the server time every second until the client
+// disconnects. The stream ends at the router's request timeout.
+func handleEvents(w http.ResponseWriter, r *http.Request) {
+	sse, err := httpadapter.NewSSEWriter(w, r, 15*time.Second)
+	if err != nil {
+		logger.ErrorWith("Failed to start event stream", domainlog.Fields{
+			"error": err.Error(),
+		})
+		return
+	}
+	defer sse.Close()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := sse.Send("time", now.UTC().Format(time.RFC3339)); err != nil {
+				return
+			}
+		case <-sse.Done():
+			return
+		}
+	}
+}
+
 func handleUserProfile(w http.ResponseWriter, r *http.Request) {
 	profile := map[string]interface{}{
 		"name":  "Example User",
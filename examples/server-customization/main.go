@@ -10,9 +10,7 @@ import (
 	"net"
 	"net/http"
 	"os"
-	"os/signal"
 	"path/filepath"
-	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -136,6 +134,8 @@ func main() {
 
 		// Add middleware ordering configuration
 		Router: domainhttp.RouterOptions{
+			// Hardened security headers, with HSTS sent over TLS only
+			SecurityHeaders: &domainhttp.SecurityHeaderOptions{},
 			MiddlewareOrdering: &domainhttp.MiddlewareOrdering{
 				Order: []domainhttp.MiddlewareCategory{
 					domainhttp.SecurityMiddleware, // Security first
@@ -144,17 +144,6 @@ func main() {
 					domainhttp.ObservabilityMiddleware,
 				},
 				CustomMiddleware: map[domainhttp.MiddlewareCategory][]func(http.Handler) http.Handler{
-					domainhttp.SecurityMiddleware: {
-						// Move security headers middleware here
-						func(next http.Handler) http.Handler {
-							return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-								w.Header().Set("X-Content-Type-Options", "nosniff")
-								w.Header().Set("X-Frame-Options", "DENY")
-								w.Header().Set("X-XSS-Protection", "1; mode=block")
-								next.ServeHTTP(w, r)
-							})
-						},
-					},
 					domainhttp.ApplicationMiddleware: {
 						// Move rate limiting middleware here
 						middleware.ThrottleBacklog(10, 50, time.Second*10),
@@ -168,15 +157,14 @@ func main() {
 		ExcludeFromTracing: []string{"/internal/*", "/metrics"},
 
 		TracingEndpoint:    os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
-		TracingSampleRate:  1.0,
+		TracingSampleRate:  bootstrap.SampleRate(1.0),
 		TracingPropagators: []string{"tracecontext", "baggage"},
 	}, deps, nil)
 
 	if err != nil {
-		logger.ErrorWith("Failed to create service", domainlog.Fields{
+		logger.FatalWith("Failed to create service", domainlog.Fields{
 			"error": err.Error(),
 		})
-		os.Exit(1)
 	}
 
 	// Add custom routes
@@ -187,36 +175,11 @@ func main() {
 		r.Get("/tls-debug", handleTLSDebug(svc))
 	})
 
-	// Start service
-	errChan := make(chan error, 1)
-	go func() {
-		if err := svc.Start(); err != nil {
-			errChan <- fmt.Errorf("failed to start service: %w", err)
-		}
-	}()
-
-	// Wait for shutdown signal
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-	select {
-	case err := <-errChan:
-		logger.ErrorWith("Service error", domainlog.Fields{
-			"error": err.Error(),
-		})
-		os.Exit(1)
-	case sig := <-sigChan:
-		logger.InfoWith("Received shutdown signal", domainlog.Fields{
-			"signal": sig.String(),
-		})
-	}
-
-	// Graceful shutdown
-	if err := svc.Shutdown(context.Background()); err != nil {
-		logger.ErrorWith("Shutdown error", domainlog.Fields{
+	// Run service until SIGINT/SIGTERM, then shut down gracefully
+	if err := svc.Run(context.Background()); err != nil {
+		logger.FatalWith("Service error", domainlog.Fields{
 			"error": err.Error(),
 		})
-		os.Exit(1)
 	}
 }
 
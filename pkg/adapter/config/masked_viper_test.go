@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -105,6 +107,29 @@ func TestViperStore_GetMaskedConfig(t *testing.T) {
 	}
 }
 
+func TestViperStore_GetFlattenedConfig(t *testing.T) {
+	f := NewFactory()
+	store, err := f.NewStore()
+	require.NoError(t, err)
+
+	require.NoError(t, store.Set("server", map[string]interface{}{
+		"http": map[string]interface{}{
+			"port": 8080,
+		},
+		"api_key": "abc123",
+	}))
+	require.NoError(t, store.Set("name", "test-app"))
+
+	got, err := store.GetFlattenedConfig(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"server.http.port": 8080,
+		"server.api_key":   "******",
+		"name":             "test-app",
+	}, got)
+}
+
 func TestViperStore_ConfigHandler(t *testing.T) {
 	// Create store with test config
 	f := NewFactory()
@@ -154,6 +179,35 @@ func TestViperStore_ConfigHandler(t *testing.T) {
 		assert.Equal(t, want, got)
 	})
 
+	t.Run("GET with flatten returns dotted keys", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/internal/config?flatten=true", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var got map[string]interface{}
+		err = json.NewDecoder(rec.Body).Decode(&got)
+		require.NoError(t, err)
+
+		want := map[string]interface{}{
+			"database.host":     "localhost",
+			"database.password": "******",
+		}
+
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("GET with invalid flatten returns bad request", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/internal/config?flatten=maybe", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
 	t.Run("POST returns method not allowed", func(t *testing.T) {
 		req := httptest.NewRequest("POST", "/internal/config", nil)
 		rec := httptest.NewRecorder()
@@ -163,3 +217,156 @@ func TestViperStore_ConfigHandler(t *testing.T) {
 		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
 	})
 }
+
+func TestViperStore_ConfigDiff(t *testing.T) {
+	config := `
+server:
+  port: 8080
+  host: localhost
+database:
+  password: from-file
+`
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(configPath, []byte(config), 0644)
+	require.NoError(t, err)
+
+	t.Setenv("DIFF_SERVER_PORT", "9090")
+	t.Setenv("DIFF_DATABASE_PASSWORD", "from-env")
+
+	f := NewFactory()
+	store, err := f.NewStore(
+		domainconfig.WithConfigFile(configPath),
+		domainconfig.WithEnvPrefix("DIFF"),
+	)
+	require.NoError(t, err)
+
+	strategy := &domainconfig.DefaultMaskStrategy{
+		SensitiveKeys: []string{"password"},
+		MaskPattern:   "******",
+	}
+
+	t.Run("reports overridden keys only", func(t *testing.T) {
+		got, err := store.GetConfigDiff(strategy)
+		require.NoError(t, err)
+
+		want := map[string]domainconfig.ConfigDiff{
+			"server.port":       {File: 8080, Effective: "9090"},
+			"database.password": {File: "******", Effective: "******"},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("includes runtime overrides", func(t *testing.T) {
+		require.NoError(t, store.Set("server.host", "0.0.0.0"))
+
+		got, err := store.GetConfigDiff(strategy)
+		require.NoError(t, err)
+
+		assert.Equal(t, domainconfig.ConfigDiff{File: "localhost", Effective: "0.0.0.0"}, got["server.host"])
+	})
+
+	t.Run("GET returns masked diff", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/internal/config/diff", nil)
+		rec := httptest.NewRecorder()
+
+		store.GetDiffHandler(strategy).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		body := rec.Body.String()
+		assert.NotContains(t, body, "from-env")
+
+		var got map[string]domainconfig.ConfigDiff
+		err := json.Unmarshal([]byte(body), &got)
+		require.NoError(t, err)
+
+		assert.Equal(t, "9090", got["server.port"].Effective)
+		assert.Equal(t, "******", got["database.password"].Effective)
+	})
+
+	t.Run("POST returns method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/internal/config/diff", nil)
+		rec := httptest.NewRecorder()
+
+		store.GetDiffHandler(strategy).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
+
+func TestViperStore_ConfigDiffWithoutFile(t *testing.T) {
+	f := NewFactory()
+	store, err := f.NewStore()
+	require.NoError(t, err)
+
+	got, err := store.GetConfigDiff(nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestViperStore_Origin(t *testing.T) {
+	config := `
+server:
+  port: 8080
+  host: localhost
+database:
+  host: db.local
+`
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(configPath, []byte(config), 0644)
+	require.NoError(t, err)
+
+	t.Setenv("ORIGIN_SERVER_HOST", "0.0.0.0")
+
+	f := NewFactory()
+	store, err := f.NewStore(
+		domainconfig.WithConfigFile(configPath),
+		domainconfig.WithEnvPrefix("ORIGIN"),
+		domainconfig.WithDefaults(map[string]interface{}{
+			"server.timeout": "5s",
+		}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, store.Set("database", map[string]interface{}{"host": "override.local"}))
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "server.port", want: domainconfig.OriginFile},
+		{key: "server.host", want: domainconfig.OriginEnv},
+		{key: "server.timeout", want: domainconfig.OriginDefault},
+		{key: "database.host", want: domainconfig.OriginOverride},
+		{key: "Server.Port", want: domainconfig.OriginFile},
+		{key: "missing", want: domainconfig.OriginUnset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.want, store.Origin(tt.key))
+		})
+	}
+
+	handler := store.GetOriginHandler()
+
+	t.Run("GET returns origin", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/internal/config/origin?key=server.host", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"key":"server.host","origin":"env"}`, rec.Body.String())
+	})
+
+	t.Run("GET without key returns bad request", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/internal/config/origin", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
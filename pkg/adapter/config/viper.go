@@ -5,10 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 
 	domainconfig "github.com/damianoneill/go-bootstrap/pkg/domain/config"
@@ -24,7 +30,21 @@ func (s *ViperStore) GetConfigHandler(maskStrategy domainconfig.MaskStrategy) ht
 			return
 		}
 
-		config, err := s.GetMaskedConfig(maskStrategy)
+		// ?flatten=true returns dotted keys, which diff cleanly
+		flatten := false
+		if value := r.URL.Query().Get("flatten"); value != "" {
+			var err error
+			if flatten, err = strconv.ParseBool(value); err != nil {
+				http.Error(w, "invalid flatten parameter", http.StatusBadRequest)
+				return
+			}
+		}
+
+		getConfig := s.GetMaskedConfig
+		if flatten {
+			getConfig = s.GetFlattenedConfig
+		}
+		config, err := getConfig(maskStrategy)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -46,35 +66,191 @@ func (s *ViperStore) GetMaskedConfig(maskStrategy domainconfig.MaskStrategy) (ma
 	allSettings := s.v.AllSettings()
 
 	if maskStrategy == nil {
-		// Use default strategy if none provided
-		maskStrategy = &domainconfig.DefaultMaskStrategy{
-			SensitiveKeys: []string{"password", "secret", "key", "token", "credential"},
-			MaskPattern:   "******",
-		}
+		maskStrategy = defaultMaskStrategy()
 	}
 
 	// Recursively mask sensitive values
-	masked := maskConfigMap("", allSettings, maskStrategy)
+	masked := s.maskConfigMap("", allSettings, maskStrategy, false)
 	return masked, nil
 }
 
-// Apply MaskStrategy to a config map recursively
-func maskConfigMap(prefix string, config map[string]interface{}, strategy domainconfig.MaskStrategy) map[string]interface{} {
+// GetFlattenedConfig returns the masked configuration with nested keys
+// joined by dots, such as server.http.port, at the top level.
+func (s *ViperStore) GetFlattenedConfig(maskStrategy domainconfig.MaskStrategy) (map[string]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if maskStrategy == nil {
+		maskStrategy = defaultMaskStrategy()
+	}
+
+	return s.maskConfigMap("", s.v.AllSettings(), maskStrategy, true), nil
+}
+
+func (s *ViperStore) GetDiffHandler(maskStrategy domainconfig.MaskStrategy) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		diff, err := s.GetConfigDiff(maskStrategy)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(diff); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+}
+
+// GetConfigDiff returns the keys from the config file whose effective value
+// differs, keyed by full config path. Values are masked. If no config file
+// is in use the diff is empty.
+func (s *ViperStore) GetConfigDiff(maskStrategy domainconfig.MaskStrategy) (map[string]domainconfig.ConfigDiff, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if maskStrategy == nil {
+		maskStrategy = defaultMaskStrategy()
+	}
+
+	diff := make(map[string]domainconfig.ConfigDiff)
+
+	configFile := s.v.ConfigFileUsed()
+	if configFile == "" {
+		return diff, nil
+	}
+
+	// Re-read the file into a separate instance so env vars, defaults and
+	// overrides do not apply
+	file := viper.NewWithOptions(viper.KeyDelimiter(s.keyDelimiter))
+	file.SetConfigFile(configFile)
+	if err := file.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	for _, key := range file.AllKeys() {
+		fileValue := file.Get(key)
+		effective := s.v.Get(key)
+
+		// Env values are strings, so compare textual forms to avoid
+		// reporting type-only differences
+		if fmt.Sprint(fileValue) == fmt.Sprint(effective) {
+			continue
+		}
+
+		diff[key] = domainconfig.ConfigDiff{
+			File:      maskStrategy.MaskValue(key, fileValue),
+			Effective: maskStrategy.MaskValue(key, effective),
+		}
+	}
+
+	return diff, nil
+}
+
+func (s *ViperStore) GetOriginHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		key := r.URL.Query().Get("key")
+		if key == "" {
+			http.Error(w, "key parameter required", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]string{
+			"key":    key,
+			"origin": s.Origin(key),
+		}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+}
+
+// Origin reports which source provides the effective value of key,
+// following Viper's precedence: override, env, file, then default.
+// Viper does not expose its layers, so overrides are tracked by Set and
+// env variables are looked up the way AutomaticEnv resolves them.
+func (s *ViperStore) Origin(key string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key = strings.ToLower(key)
+	if !s.v.IsSet(key) {
+		return domainconfig.OriginUnset
+	}
+
+	// A map set at runtime overrides every key beneath it
+	for k := key; ; {
+		if _, ok := s.overrides[k]; ok {
+			return domainconfig.OriginOverride
+		}
+		i := strings.LastIndex(k, s.keyDelimiter)
+		if i < 0 {
+			break
+		}
+		k = k[:i]
+	}
+
+	if s.envPrefix != "" {
+		name := strings.ToUpper(s.envPrefix + "_" + s.envKeyReplacer.Replace(key))
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			return domainconfig.OriginEnv
+		}
+	}
+	if s.v.InConfig(key) {
+		return domainconfig.OriginFile
+	}
+	return domainconfig.OriginDefault
+}
+
+// defaultMaskStrategy returns the strategy used when none is provided
+func defaultMaskStrategy() domainconfig.MaskStrategy {
+	return &domainconfig.DefaultMaskStrategy{
+		SensitiveKeys: []string{"password", "secret", "key", "token", "credential"},
+		MaskPattern:   "******",
+	}
+}
+
+// Apply MaskStrategy to a config map recursively. When flat is set, leaf
+// values are keyed by their full path instead of being nested.
+func (s *ViperStore) maskConfigMap(prefix string, config map[string]interface{}, strategy domainconfig.MaskStrategy, flat bool) map[string]interface{} {
 	result := make(map[string]interface{})
 
 	for k, v := range config {
 		fullKey := k
 		if prefix != "" {
-			fullKey = prefix + "." + k
+			fullKey = prefix + s.keyDelimiter + k
 		}
 
 		switch val := v.(type) {
 		case map[string]interface{}:
 			// Recurse into nested maps
-			result[k] = maskConfigMap(fullKey, val, strategy)
+			nested := s.maskConfigMap(fullKey, val, strategy, flat)
+			if !flat {
+				result[k] = nested
+				continue
+			}
+			for nestedKey, nestedValue := range nested {
+				result[nestedKey] = nestedValue
+			}
 		default:
 			// Mask leaf values that match sensitive patterns
-			result[k] = strategy.MaskValue(fullKey, v)
+			key := k
+			if flat {
+				key = fullKey
+			}
+			result[key] = strategy.MaskValue(fullKey, v)
 		}
 	}
 
@@ -83,8 +259,17 @@ func maskConfigMap(prefix string, config map[string]interface{}, strategy domain
 
 // ViperStore implements the Store interface using Viper
 type ViperStore struct {
-	v  *viper.Viper
-	mu sync.RWMutex
+	v            *viper.Viper
+	mu           sync.RWMutex
+	lenientBools bool
+	expandEnv    bool
+	keepUnsetEnv bool
+	envPrefix    string
+	overrides    map[string]struct{} // Keys changed by Set, for Origin
+
+	envKeyReplacer *strings.Replacer
+	keyDelimiter   string
+	readOnly       bool // Set on snapshots
 }
 
 // Factory creates Viper-backed stores
@@ -102,9 +287,16 @@ func (f *Factory) NewStore(opts ...domainconfig.Option) (domainconfig.MaskedStor
 		}
 	}
 
-	v := viper.New()
+	if options.EnvKeyReplacer == nil {
+		options.EnvKeyReplacer = strings.NewReplacer(".", "_")
+	}
+	if options.KeyDelimiter == "" {
+		options.KeyDelimiter = "."
+	}
+
+	v := viper.NewWithOptions(viper.KeyDelimiter(options.KeyDelimiter))
 	v.SetConfigType("yaml") // Default to YAML
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.SetEnvKeyReplacer(options.EnvKeyReplacer)
 
 	// Apply options
 	if options.ConfigFile != "" {
@@ -120,7 +312,17 @@ func (f *Factory) NewStore(opts ...domainconfig.Option) (domainconfig.MaskedStor
 		}
 	}
 
-	store := &ViperStore{v: v}
+	store := &ViperStore{
+		v:            v,
+		lenientBools: options.LenientBooleans,
+		expandEnv:    options.EnvExpansion,
+		keepUnsetEnv: options.KeepUnsetEnv,
+		envPrefix:    options.EnvPrefix,
+		overrides:    make(map[string]struct{}),
+
+		envKeyReplacer: options.EnvKeyReplacer,
+		keyDelimiter:   options.KeyDelimiter,
+	}
 
 	// Load config if file specified
 	if options.ConfigFile != "" {
@@ -129,20 +331,197 @@ func (f *Factory) NewStore(opts ...domainconfig.Option) (domainconfig.MaskedStor
 		}
 	}
 
+	if err := store.checkDurationBounds(options.DurationBounds); err != nil {
+		return nil, err
+	}
+
+	if options.Schema != nil {
+		if err := store.checkSchema(options.Schema); err != nil {
+			return nil, err
+		}
+	}
+
 	return store, nil
 }
 
+// checkSchema decodes the config into a fresh copy of prototype, failing
+// on decode errors and keys without a matching field, then checks that
+// fields tagged required:"true" are set
+func (s *ViperStore) checkSchema(prototype interface{}) error {
+	t := reflect.TypeOf(prototype)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	errorUnused := func(c *mapstructure.DecoderConfig) { c.ErrorUnused = true }
+	target := reflect.New(t).Interface()
+	if err := s.v.Unmarshal(target, append(s.decoderOptions(), errorUnused)...); err != nil {
+		return fmt.Errorf("config does not match schema: %w", err)
+	}
+
+	var missing []string
+	s.missingRequired(t, "", &missing)
+	if len(missing) > 0 {
+		return fmt.Errorf("config does not match schema: required keys not set: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// missingRequired appends the keys of fields tagged required:"true" within
+// struct type t that are not set, recursing into nested structs
+func (s *ViperStore) missingRequired(t reflect.Type, prefix string, missing *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		// Match mapstructure's naming: the tag name, else the field name
+		name, tagOpts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(tagOpts, "squash") && field.Type.Kind() == reflect.Struct {
+			s.missingRequired(field.Type, prefix, missing)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		key := strings.ToLower(name)
+		if prefix != "" {
+			key = prefix + s.keyDelimiter + key
+		}
+
+		if field.Tag.Get("required") == "true" && !s.v.IsSet(key) {
+			*missing = append(*missing, key)
+		}
+
+		ft := field.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && ft != reflect.TypeOf(time.Time{}) {
+			s.missingRequired(ft, key, missing)
+		}
+	}
+}
+
+// checkDurationBounds verifies each set key parses as a duration within
+// its bounds. Keys are checked in order so errors are deterministic.
+func (s *ViperStore) checkDurationBounds(bounds map[string][2]time.Duration) error {
+	keys := make([]string, 0, len(bounds))
+	for key := range bounds {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !s.v.IsSet(key) {
+			continue
+		}
+		d, err := cast.ToDurationE(s.v.Get(key))
+		if err != nil {
+			return fmt.Errorf("config key %s: %w", key, err)
+		}
+		if b := bounds[key]; d < b[0] || d > b[1] {
+			return fmt.Errorf("config key %s: duration %s outside bounds [%s, %s]", key, d, b[0], b[1])
+		}
+	}
+	return nil
+}
+
 // ReadConfig loads the configuration file
 func (s *ViperStore) ReadConfig() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.readOnly {
+		return fmt.Errorf("reading config: snapshot is read-only")
+	}
+
 	if err := s.v.ReadInConfig(); err != nil {
 		return fmt.Errorf("reading config: %w", err)
 	}
 	return nil
 }
 
+// Snapshot copies the effective settings, including env values and
+// overrides, into a detached Viper instance
+func (s *ViperStore) Snapshot() domainconfig.Store {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.detached(s.v.AllSettings())
+}
+
+// Sub returns a read-only copy of the config under prefix, in the manner
+// of Viper's Sub. The effective settings are walked rather than using
+// viper.Sub, which drops env values and overrides of nested keys. A prefix
+// that is unset or not a map yields an empty store.
+func (s *ViperStore) Sub(prefix string) domainconfig.Store {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	settings := s.v.AllSettings()
+	for _, segment := range strings.Split(strings.ToLower(prefix), s.keyDelimiter) {
+		nested, ok := settings[segment].(map[string]interface{})
+		if !ok {
+			return s.detached(nil)
+		}
+		settings = nested
+	}
+	return s.detached(settings)
+}
+
+// detached returns a read-only store holding settings, with the same
+// parsing behavior as s
+func (s *ViperStore) detached(settings map[string]interface{}) *ViperStore {
+	v := viper.NewWithOptions(viper.KeyDelimiter(s.keyDelimiter))
+	// MergeConfigMap does not fail for an in-memory map
+	_ = v.MergeConfigMap(settings)
+
+	return &ViperStore{
+		v:              v,
+		lenientBools:   s.lenientBools,
+		expandEnv:      s.expandEnv,
+		keepUnsetEnv:   s.keepUnsetEnv,
+		overrides:      make(map[string]struct{}),
+		envKeyReplacer: s.envKeyReplacer,
+		keyDelimiter:   s.keyDelimiter,
+		readOnly:       true,
+	}
+}
+
+func (s *ViperStore) WriteConfig() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.v.ConfigFileUsed() == "" {
+		return fmt.Errorf("writing config: no config file configured")
+	}
+	if err := s.v.WriteConfig(); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
+}
+
+func (s *ViperStore) SafeWriteConfig() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file := s.v.ConfigFileUsed()
+	if file == "" {
+		return fmt.Errorf("writing config: no config file configured")
+	}
+	// Viper's SafeWriteConfig only considers config paths, not an
+	// explicitly set file, so write to the file directly
+	if err := s.v.SafeWriteConfigAs(file); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
+}
+
 // Get methods implement Store interface
 func (s *ViperStore) GetString(key string) (string, bool) {
 	s.mu.RLock()
@@ -151,7 +530,7 @@ func (s *ViperStore) GetString(key string) (string, bool) {
 	if !s.v.IsSet(key) {
 		return "", false
 	}
-	return s.v.GetString(key), true
+	return s.expand(s.v.GetString(key)), true
 }
 
 func (s *ViperStore) GetInt(key string) (int, bool) {
@@ -171,6 +550,13 @@ func (s *ViperStore) GetBool(key string) (bool, bool) {
 	if !s.v.IsSet(key) {
 		return false, false
 	}
+	if s.lenientBools {
+		if str, ok := s.v.Get(key).(string); ok {
+			if b, ok := parseLenientBool(str); ok {
+				return b, true
+			}
+		}
+	}
 	return s.v.GetBool(key), true
 }
 
@@ -204,11 +590,78 @@ func (s *ViperStore) GetStringSlice(key string) ([]string, bool) {
 	return s.v.GetStringSlice(key), true
 }
 
+func (s *ViperStore) GetIntSlice(key string) ([]int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.v.IsSet(key) {
+		return nil, false
+	}
+	return s.v.GetIntSlice(key), true
+}
+
+func (s *ViperStore) GetFloat64Slice(key string) ([]float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.v.IsSet(key) {
+		return nil, false
+	}
+
+	// Viper has no float slice accessor, so convert each element like
+	// GetIntSlice does, yielding an empty slice for unconvertible values
+	items, err := cast.ToSliceE(s.v.Get(key))
+	if err != nil {
+		return []float64{}, true
+	}
+	values := make([]float64, 0, len(items))
+	for _, item := range items {
+		f, err := cast.ToFloat64E(item)
+		if err != nil {
+			return []float64{}, true
+		}
+		values = append(values, f)
+	}
+	return values, true
+}
+
+func (s *ViperStore) GetTime(key string) (time.Time, bool) {
+	return s.GetTimeWithLayout(key, time.RFC3339)
+}
+
+func (s *ViperStore) GetTimeWithLayout(key, layout string) (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.v.IsSet(key) {
+		return time.Time{}, false
+	}
+
+	switch v := s.v.Get(key).(type) {
+	case time.Time:
+		// Already decoded, e.g. set programmatically
+		return v, true
+	case string:
+		t, err := time.Parse(layout, v)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
 func (s *ViperStore) Set(key string, value interface{}) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.readOnly {
+		return fmt.Errorf("setting %s: snapshot is read-only", key)
+	}
+
 	s.v.Set(key, value)
+	s.overrides[strings.ToLower(key)] = struct{}{}
 	return nil
 }
 
@@ -223,12 +676,84 @@ func (s *ViperStore) UnmarshalKey(key string, target interface{}) error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return s.v.UnmarshalKey(key, target)
+	return s.v.UnmarshalKey(key, target, s.decoderOptions()...)
 }
 
 func (s *ViperStore) Unmarshal(target interface{}) error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return s.v.Unmarshal(target)
+	return s.v.Unmarshal(target, s.decoderOptions()...)
+}
+
+// decoderOptions returns the decoder configuration used when unmarshalling
+func (s *ViperStore) decoderOptions() []viper.DecoderConfigOption {
+	if !s.lenientBools && !s.expandEnv {
+		return nil
+	}
+
+	var hooks []mapstructure.DecodeHookFunc
+	if s.expandEnv {
+		// Expand first so references can resolve to durations and lists
+		hooks = append(hooks, s.expandEnvHook)
+	}
+	// Viper's default hooks
+	hooks = append(hooks,
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	)
+	if s.lenientBools {
+		hooks = append(hooks, lenientBoolHook)
+	}
+	return []viper.DecoderConfigOption{
+		viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(hooks...)),
+	}
+}
+
+// expandEnvHook expands environment variable references in string values
+func (s *ViperStore) expandEnvHook(from reflect.Type, _ reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String {
+		return data, nil
+	}
+	return s.expand(data.(string)), nil
+}
+
+// expand replaces environment variable references in value when env
+// expansion is enabled
+func (s *ViperStore) expand(value string) string {
+	if !s.expandEnv {
+		return value
+	}
+	return os.Expand(value, func(name string) string {
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+		if s.keepUnsetEnv {
+			return "${" + name + "}"
+		}
+		return ""
+	})
+}
+
+// lenientBoolHook converts flexible boolean spellings when decoding into bool fields
+func lenientBoolHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Bool {
+		return data, nil
+	}
+	if b, ok := parseLenientBool(data.(string)); ok {
+		return b, nil
+	}
+	return data, nil
+}
+
+// parseLenientBool parses common truthy and falsy spellings
+func parseLenientBool(value string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "t", "true", "on", "yes", "y", "enabled":
+		return true, true
+	case "0", "f", "false", "off", "no", "n", "disabled":
+		return false, true
+	default:
+		return false, false
+	}
 }
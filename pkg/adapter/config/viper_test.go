@@ -2,8 +2,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,6 +30,15 @@ test_float: 3.14
 test_slice:
   - one
   - two
+test_ports:
+  - 8080
+  - 9090
+test_thresholds:
+  - 0.5
+  - 0.9
+  - 1
+test_time: "2024-01-01T00:00:00Z"
+test_date: "2024-01-31"
 `)
 
 	err := os.WriteFile(configPath, content, 0644)
@@ -90,6 +102,50 @@ test_slice:
 				assert.Equal(t, []string{"one", "two"}, val)
 			},
 		},
+		{
+			name: "int slice values",
+			testFunc: func(t *testing.T) {
+				val, ok := store.GetIntSlice("test_ports")
+				assert.True(t, ok)
+				assert.Equal(t, []int{8080, 9090}, val)
+
+				_, ok = store.GetIntSlice("missing")
+				assert.False(t, ok)
+			},
+		},
+		{
+			name: "float slice values",
+			testFunc: func(t *testing.T) {
+				val, ok := store.GetFloat64Slice("test_thresholds")
+				assert.True(t, ok)
+				assert.Equal(t, []float64{0.5, 0.9, 1}, val)
+
+				_, ok = store.GetFloat64Slice("missing")
+				assert.False(t, ok)
+			},
+		},
+		{
+			name: "time values",
+			testFunc: func(t *testing.T) {
+				val, ok := store.GetTime("test_time")
+				assert.True(t, ok)
+				assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), val)
+
+				val, ok = store.GetTimeWithLayout("test_date", time.DateOnly)
+				assert.True(t, ok)
+				assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), val)
+
+				// Values not matching the layout are not found
+				val, ok = store.GetTime("test_date")
+				assert.False(t, ok)
+				assert.True(t, val.IsZero())
+				_, ok = store.GetTime("test_string")
+				assert.False(t, ok)
+
+				_, ok = store.GetTime("missing")
+				assert.False(t, ok)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -112,6 +168,58 @@ func TestFactory_NewStore_WithEnv(t *testing.T) {
 	assert.Equal(t, "from_env", val)
 }
 
+func TestFactory_NewStore_WithEnvKeyReplacer(t *testing.T) {
+	t.Setenv("SVC__SERVER__PORT", "9090")
+
+	f := NewFactory()
+	store, err := f.NewStore(
+		domainconfig.WithEnvPrefix("SVC_"),
+		domainconfig.WithEnvKeyReplacer(strings.NewReplacer(".", "__")),
+	)
+	require.NoError(t, err)
+
+	val, ok := store.GetInt("server.port")
+	assert.True(t, ok)
+	assert.Equal(t, 9090, val)
+	assert.Equal(t, domainconfig.OriginEnv, store.Origin("server.port"))
+}
+
+func TestFactory_NewStore_WithKeyDelimiter(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	content := []byte(`
+hosts:
+  api.example.com:
+    port: 8443
+`)
+	err := os.WriteFile(configPath, content, 0644)
+	require.NoError(t, err)
+
+	t.Setenv("SVC__HOSTS__API.EXAMPLE.COM__TIMEOUT", "5s")
+
+	f := NewFactory()
+	store, err := f.NewStore(
+		domainconfig.WithConfigFile(configPath),
+		domainconfig.WithEnvPrefix("SVC_"),
+		domainconfig.WithKeyDelimiter("::"),
+		domainconfig.WithEnvKeyReplacer(strings.NewReplacer("::", "__")),
+	)
+	require.NoError(t, err)
+
+	// Dots within a segment are part of the key
+	port, ok := store.GetInt("hosts::api.example.com::port")
+	assert.True(t, ok)
+	assert.Equal(t, 8443, port)
+
+	timeout, ok := store.GetDuration("hosts::api.example.com::timeout")
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, timeout)
+
+	flat, err := store.GetFlattenedConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 8443, flat["hosts::api.example.com::port"])
+}
+
 func TestFactory_NewStore_WithDefaults(t *testing.T) {
 	defaults := map[string]interface{}{
 		"default_key":  "default_value",
@@ -174,3 +282,495 @@ app:
 	assert.Equal(t, 8080, appConfig.Port)
 	assert.True(t, appConfig.Features.Enabled)
 }
+
+func TestStore_LenientBooleans(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{name: "on", value: "on", want: true},
+		{name: "yes", value: "yes", want: true},
+		{name: "upper case YES", value: "YES", want: true},
+		{name: "off", value: "off", want: false},
+		{name: "no", value: "no", want: false},
+		{name: "zero", value: "0", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LENIENT_FEATURE_ENABLED", tt.value)
+
+			f := NewFactory()
+			store, err := f.NewStore(
+				domainconfig.WithEnvPrefix("LENIENT"),
+				domainconfig.WithLenientBooleans(true),
+			)
+			require.NoError(t, err)
+
+			val, ok := store.GetBool("feature.enabled")
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, val)
+		})
+	}
+}
+
+func TestStore_LenientBooleans_Unmarshal(t *testing.T) {
+	config := `
+feature:
+  enabled: "yes"
+  beta: "off"
+`
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(configPath, []byte(config), 0644)
+	require.NoError(t, err)
+
+	f := NewFactory()
+	store, err := f.NewStore(
+		domainconfig.WithConfigFile(configPath),
+		domainconfig.WithLenientBooleans(true),
+	)
+	require.NoError(t, err)
+
+	var feature struct {
+		Enabled bool
+		Beta    bool
+	}
+	err = store.UnmarshalKey("feature", &feature)
+	require.NoError(t, err)
+
+	assert.True(t, feature.Enabled)
+	assert.False(t, feature.Beta)
+}
+
+func TestStore_StrictBooleans(t *testing.T) {
+	t.Setenv("STRICT_FEATURE_ENABLED", "on")
+
+	f := NewFactory()
+	store, err := f.NewStore(domainconfig.WithEnvPrefix("STRICT"))
+	require.NoError(t, err)
+
+	val, ok := store.GetBool("feature.enabled")
+	assert.True(t, ok)
+	assert.False(t, val)
+}
+
+func TestStore_EnvExpansion(t *testing.T) {
+	config := `
+storage:
+  path: ${EXPAND_DATA_DIR}/data
+  cache: $EXPAND_UNSET_DIR/cache
+  timeout: ${EXPAND_TIMEOUT}
+`
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(configPath, []byte(config), 0644)
+	require.NoError(t, err)
+
+	t.Setenv("EXPAND_DATA_DIR", "/srv")
+	t.Setenv("EXPAND_TIMEOUT", "5s")
+
+	tests := []struct {
+		name      string
+		options   []domainconfig.Option
+		wantPath  string
+		wantCache string
+	}{
+		{
+			name:      "disabled",
+			wantPath:  "${EXPAND_DATA_DIR}/data",
+			wantCache: "$EXPAND_UNSET_DIR/cache",
+		},
+		{
+			name:      "unset variables expand to empty",
+			options:   []domainconfig.Option{domainconfig.WithEnvExpansion(true)},
+			wantPath:  "/srv/data",
+			wantCache: "/cache",
+		},
+		{
+			name: "unset variables kept",
+			options: []domainconfig.Option{
+				domainconfig.WithEnvExpansion(true),
+				domainconfig.WithKeepUnsetEnv(true),
+			},
+			wantPath:  "/srv/data",
+			wantCache: "${EXPAND_UNSET_DIR}/cache",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFactory()
+			store, err := f.NewStore(append(tt.options, domainconfig.WithConfigFile(configPath))...)
+			require.NoError(t, err)
+
+			path, ok := store.GetString("storage.path")
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantPath, path)
+
+			var storage struct {
+				Path  string
+				Cache string
+			}
+			err = store.UnmarshalKey("storage", &storage)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPath, storage.Path)
+			assert.Equal(t, tt.wantCache, storage.Cache)
+		})
+	}
+
+	t.Run("expanded before decoding", func(t *testing.T) {
+		f := NewFactory()
+		store, err := f.NewStore(
+			domainconfig.WithConfigFile(configPath),
+			domainconfig.WithEnvExpansion(true),
+		)
+		require.NoError(t, err)
+
+		var storage struct {
+			Timeout time.Duration
+		}
+		err = store.UnmarshalKey("storage", &storage)
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, storage.Timeout)
+	})
+}
+
+func TestStore_WriteConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(configPath, []byte("feature:\n  enabled: false\nname: test-app\n"), 0644)
+	require.NoError(t, err)
+
+	f := NewFactory()
+	store, err := f.NewStore(domainconfig.WithConfigFile(configPath))
+	require.NoError(t, err)
+
+	require.NoError(t, store.Set("feature.enabled", true))
+	require.NoError(t, store.WriteConfig())
+
+	// A new store reads the persisted change alongside existing values
+	reloaded, err := f.NewStore(domainconfig.WithConfigFile(configPath))
+	require.NoError(t, err)
+	enabled, ok := reloaded.GetBool("feature.enabled")
+	assert.True(t, ok)
+	assert.True(t, enabled)
+	name, ok := reloaded.GetString("name")
+	assert.True(t, ok)
+	assert.Equal(t, "test-app", name)
+
+	// SafeWriteConfig does not overwrite the existing file
+	err = store.SafeWriteConfig()
+	assert.ErrorContains(t, err, "writing config")
+}
+
+func TestStore_WriteConfigWithoutFile(t *testing.T) {
+	f := NewFactory()
+	store, err := f.NewStore()
+	require.NoError(t, err)
+
+	assert.EqualError(t, store.WriteConfig(), "writing config: no config file configured")
+	assert.EqualError(t, store.SafeWriteConfig(), "writing config: no config file configured")
+}
+
+func TestFactory_NewStore_WithSchema(t *testing.T) {
+	type schema struct {
+		Server struct {
+			HTTP struct {
+				Port    int           `mapstructure:"port" required:"true"`
+				Timeout time.Duration `mapstructure:"timeout"`
+			} `mapstructure:"http"`
+		} `mapstructure:"server"`
+		Name string `required:"true"`
+	}
+
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			config: `
+name: test-app
+server:
+  http:
+    port: 8080
+    timeout: 5s
+`,
+		},
+		{
+			name: "misspelled key",
+			config: `
+name: test-app
+server:
+  http:
+    port: 8080
+  htp:
+    port: 9090
+`,
+			wantErr: "'server' has invalid keys: htp",
+		},
+		{
+			name: "wrong type",
+			config: `
+name: test-app
+server:
+  http:
+    port: eighty
+`,
+			wantErr: "port",
+		},
+		{
+			name: "missing required keys",
+			config: `
+server:
+  http:
+    timeout: 5s
+`,
+			wantErr: "required keys not set: server.http.port, name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			err := os.WriteFile(configPath, []byte(tt.config), 0644)
+			require.NoError(t, err)
+
+			f := NewFactory()
+			_, err = f.NewStore(
+				domainconfig.WithConfigFile(configPath),
+				domainconfig.WithSchema(&schema{}),
+			)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, "config does not match schema")
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestStore_Snapshot(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	writePair := func(n int) {
+		content := fmt.Sprintf("pair:\n  first: %d\n  second: %d\n", n, n)
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+	}
+	writePair(0)
+
+	f := NewFactory()
+	store, err := f.NewStore(domainconfig.WithConfigFile(configPath))
+	require.NoError(t, err)
+	require.NoError(t, store.Set("override", "before"))
+
+	snapshot := store.Snapshot()
+
+	// Later changes and reloads are not reflected
+	require.NoError(t, store.Set("override", "after"))
+	writePair(1)
+	require.NoError(t, store.ReadConfig())
+
+	override, ok := snapshot.GetString("override")
+	assert.True(t, ok)
+	assert.Equal(t, "before", override)
+	first, ok := snapshot.GetInt("pair.first")
+	assert.True(t, ok)
+	assert.Equal(t, 0, first)
+
+	assert.Error(t, snapshot.Set("override", "changed"))
+	assert.Error(t, snapshot.ReadConfig())
+}
+
+func TestStore_SnapshotConsistentDuringReloads(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(configPath, []byte("pair:\n  first: 0\n  second: 0\n"), 0644)
+	require.NoError(t, err)
+
+	f := NewFactory()
+	store, err := f.NewStore(domainconfig.WithConfigFile(configPath))
+	require.NoError(t, err)
+
+	// Reload a new pair of matching values repeatedly while readers check
+	// each snapshot sees both values from the same load
+	done := make(chan struct{})
+	reloaded := make(chan error, 1)
+	go func() {
+		defer close(done)
+		for n := 1; n <= 50; n++ {
+			content := fmt.Sprintf("pair:\n  first: %d\n  second: %d\n", n, n)
+			path := filepath.Join(dir, fmt.Sprintf("config-%d.yaml", n))
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				reloaded <- err
+				return
+			}
+			// Rename so the store never reads a partially written file
+			if err := os.Rename(path, configPath); err != nil {
+				reloaded <- err
+				return
+			}
+			if err := store.ReadConfig(); err != nil {
+				reloaded <- err
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				snapshot := store.Snapshot()
+				first, _ := snapshot.GetInt("pair.first")
+				second, _ := snapshot.GetInt("pair.second")
+				if first != second {
+					t.Errorf("inconsistent snapshot: first=%d second=%d", first, second)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case err := <-reloaded:
+		t.Fatalf("reloading config: %v", err)
+	default:
+	}
+	first, _ := store.GetInt("pair.first")
+	assert.Equal(t, 50, first)
+}
+
+func TestStore_Sub(t *testing.T) {
+	config := `
+database:
+  host: db.local
+  pool:
+    size: 10
+name: test-app
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(configPath, []byte(config), 0644)
+	require.NoError(t, err)
+
+	t.Setenv("SUB_DATABASE_HOST", "db.prod")
+
+	f := NewFactory()
+	store, err := f.NewStore(
+		domainconfig.WithConfigFile(configPath),
+		domainconfig.WithEnvPrefix("SUB"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, store.Set("database.pool.timeout", "5s"))
+
+	database := store.Sub("database")
+
+	// Env values and overrides of nested keys are included
+	host, ok := database.GetString("host")
+	assert.True(t, ok)
+	assert.Equal(t, "db.prod", host)
+	timeout, ok := database.GetDuration("pool.timeout")
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, timeout)
+
+	pool := database.Sub("pool")
+	size, ok := pool.GetInt("size")
+	assert.True(t, ok)
+	assert.Equal(t, 10, size)
+
+	// A nested prefix is equivalent to chained Sub calls
+	size, ok = store.Sub("database.pool").GetInt("size")
+	assert.True(t, ok)
+	assert.Equal(t, 10, size)
+
+	assert.True(t, database.IsSet("pool.size"))
+	assert.False(t, database.IsSet("name"))
+	assert.False(t, database.IsSet("database.host"))
+
+	for _, prefix := range []string{"missing", "name", "database.host"} {
+		empty := store.Sub(prefix)
+		require.NotNil(t, empty, prefix)
+		assert.False(t, empty.IsSet("host"), prefix)
+		_, ok := empty.GetString("host")
+		assert.False(t, ok, prefix)
+	}
+
+	assert.Error(t, database.Set("host", "other"))
+}
+
+func TestFactory_NewStore_DurationBounds(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+server:
+  read_timeout: 1ms
+  write_timeout: 15s
+`), 0644))
+
+	bounds := func(key string) domainconfig.Option {
+		return domainconfig.WithDurationBounds(map[string][2]time.Duration{
+			key: {time.Second, time.Minute},
+		})
+	}
+
+	tests := []struct {
+		name    string
+		opts    []domainconfig.Option
+		wantErr string
+	}{
+		{
+			name: "in bounds",
+			opts: []domainconfig.Option{bounds("server.write_timeout")},
+		},
+		{
+			name:    "below min",
+			opts:    []domainconfig.Option{bounds("server.read_timeout")},
+			wantErr: "config key server.read_timeout: duration 1ms outside bounds [1s, 1m0s]",
+		},
+		{
+			name: "above max from defaults",
+			opts: []domainconfig.Option{
+				bounds("server.idle_timeout"),
+				domainconfig.WithDefaults(map[string]interface{}{"server.idle_timeout": "2h"}),
+			},
+			wantErr: "duration 2h0m0s outside bounds",
+		},
+		{
+			name: "unparsable duration",
+			opts: []domainconfig.Option{
+				bounds("server.idle_timeout"),
+				domainconfig.WithDefaults(map[string]interface{}{"server.idle_timeout": "soon"}),
+			},
+			wantErr: "config key server.idle_timeout",
+		},
+		{
+			name: "unset key is not checked",
+			opts: []domainconfig.Option{bounds("server.missing_timeout")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]domainconfig.Option{domainconfig.WithConfigFile(configPath)}, tt.opts...)
+			store, err := NewFactory().NewStore(opts...)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				assert.NotNil(t, store)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
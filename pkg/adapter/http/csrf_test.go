@@ -0,0 +1,156 @@
+// pkg/adapter/http/csrf_test.go
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	adapterlog "github.com/damianoneill/go-bootstrap/pkg/adapter/logging"
+	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+var testCSRFSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newCSRFRouter(t *testing.T) *Router {
+	t.Helper()
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithCSRF(domainhttp.CSRFOptions{Secret: testCSRFSecret, Secure: true}),
+	)
+	assert.NoError(t, err)
+
+	r := router.(*Router)
+	r.Get("/form", func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(CSRFToken(req.Context())))
+	})
+	r.Post("/form", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return r
+}
+
+func TestRouterCSRFIssuesToken(t *testing.T) {
+	router := newCSRFRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/form", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	cookies := rec.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, "csrf_token", cookies[0].Name)
+		assert.True(t, cookies[0].Secure)
+		assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+		assert.Equal(t, cookies[0].Value, rec.Body.String(), "handlers see the issued token")
+		assert.True(t, validCSRFToken(cookies[0].Value, testCSRFSecret))
+	}
+
+	// A valid cookie is reused rather than reissued
+	req := httptest.NewRequest(http.MethodGet, "/form", nil)
+	req.AddCookie(cookies[0])
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Empty(t, rec.Result().Cookies())
+	assert.Equal(t, cookies[0].Value, rec.Body.String())
+}
+
+func TestRouterCSRFValidation(t *testing.T) {
+	token, err := newCSRFToken(testCSRFSecret)
+	assert.NoError(t, err)
+	forged, err := newCSRFToken([]byte("another-secret-another-secret-xx"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		cookie     string
+		header     string
+		field      string
+		wantStatus int
+	}{
+		{
+			name:       "header token accepted",
+			cookie:     token,
+			header:     token,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "form field token accepted",
+			cookie:     token,
+			field:      token,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "missing token rejected",
+			cookie:     token,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "missing cookie rejected",
+			header:     token,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "mismatched token rejected",
+			cookie:     token,
+			header:     "attacker-token",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "cookie signed with another secret rejected",
+			cookie:     forged,
+			header:     forged,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newCSRFRouter(t)
+
+			form := url.Values{}
+			if tt.field != "" {
+				form.Set("csrf_token", tt.field)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/form", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "csrf_token", Value: tt.cookie})
+			}
+			if tt.header != "" {
+				req.Header.Set("X-CSRF-Token", tt.header)
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestRouterCSRFExemptsBuiltinEndpoints(t *testing.T) {
+	router := newCSRFRouter(t)
+
+	logger, err := adapterlog.NewFactory().NewLogger(domainlog.WithLevel(domainlog.InfoLevel))
+	assert.NoError(t, err)
+	router.Mount("/internal/logging", logger.(domainlog.RuntimeConfigurable).GetConfigHandler())
+
+	// Operators change the level without a CSRF token
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/internal/logging",
+		strings.NewReader(`{"level":"debug"}`)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, domainlog.DebugLevel, logger.GetLevel())
+	assert.Empty(t, rec.Result().Cookies(), "built-in endpoints are not issued tokens")
+
+	// Application routes are still protected
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/form", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Decode error kinds, matched with errors.Is
+var (
+	// ErrBodyTooLarge indicates the body exceeded DecodeOpts.MaxBytes
+	ErrBodyTooLarge = errors.New("request body too large")
+
+	// ErrEmptyBody indicates the body contained no JSON value
+	ErrEmptyBody = errors.New("request body must not be empty")
+
+	// ErrMalformedJSON indicates the body was not valid JSON for the target
+	ErrMalformedJSON = errors.New("malformed JSON")
+
+	// ErrUnknownField indicates the body contained a field the target does not
+	// define while DecodeOpts.DisallowUnknownFields was set
+	ErrUnknownField = errors.New("unknown field")
+)
+
+// DecodeOpts configures DecodeJSON
+type DecodeOpts struct {
+	// MaxBytes limits the size of the body. If zero, no limit is applied.
+	MaxBytes int64
+
+	// DisallowUnknownFields rejects bodies containing fields that do not
+	// map to the target
+	DisallowUnknownFields bool
+}
+
+// DecodeError describes why a request body could not be decoded.
+// It unwraps to one of the Err* kinds and reports the matching HTTP status.
+type DecodeError struct {
+	// Kind is one of ErrBodyTooLarge, ErrEmptyBody, ErrMalformedJSON
+	// or ErrUnknownField
+	Kind error
+
+	// Detail describes the problem in terms safe to return to the client
+	Detail string
+}
+
+func (e *DecodeError) Error() string {
+	if e.Detail == "" {
+		return e.Kind.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.Kind, e.Detail)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Kind
+}
+
+// StatusCode returns 413 for oversize bodies and 400 otherwise
+func (e *DecodeError) StatusCode() int {
+	if errors.Is(e.Kind, ErrBodyTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// DecodeJSON decodes a single JSON value from the request body into v.
+// Failures are returned as *DecodeError so handlers can respond with:
+//
+//	var derr *DecodeError
+//	if errors.As(err, &derr) {
+//	    http.Error(w, derr.Error(), derr.StatusCode())
+//	    return
+//	}
+func DecodeJSON(r *http.Request, v interface{}, opts DecodeOpts) error {
+	body := r.Body
+	if opts.MaxBytes > 0 {
+		body = http.MaxBytesReader(nil, r.Body, opts.MaxBytes)
+	}
+
+	dec := json.NewDecoder(body)
+	if opts.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+
+	if err := dec.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return &DecodeError{Kind: ErrEmptyBody}
+		}
+		return classifyDecodeError(err)
+	}
+
+	// Reject trailing data so the body is exactly one JSON value
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return classifyDecodeError(err)
+		}
+		return &DecodeError{
+			Kind:   ErrMalformedJSON,
+			Detail: "body must contain a single JSON value",
+		}
+	}
+
+	return nil
+}
+
+// classifyDecodeError maps a decoder error to a DecodeError
+func classifyDecodeError(err error) *DecodeError {
+	var (
+		maxErr    *http.MaxBytesError
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+
+	switch {
+	case errors.As(err, &maxErr):
+		return &DecodeError{
+			Kind:   ErrBodyTooLarge,
+			Detail: fmt.Sprintf("limit is %d bytes", maxErr.Limit),
+		}
+	case errors.As(err, &syntaxErr):
+		return &DecodeError{
+			Kind:   ErrMalformedJSON,
+			Detail: fmt.Sprintf("syntax error at offset %d", syntaxErr.Offset),
+		}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &DecodeError{
+			Kind:   ErrMalformedJSON,
+			Detail: "unexpected end of body",
+		}
+	case errors.As(err, &typeErr):
+		return &DecodeError{
+			Kind:   ErrMalformedJSON,
+			Detail: fmt.Sprintf("invalid value for field %q", typeErr.Field),
+		}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no typed error for unknown fields
+		return &DecodeError{
+			Kind:   ErrUnknownField,
+			Detail: strings.TrimPrefix(err.Error(), "json: unknown field "),
+		}
+	default:
+		return &DecodeError{
+			Kind:   ErrMalformedJSON,
+			Detail: err.Error(),
+		}
+	}
+}
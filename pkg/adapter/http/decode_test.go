@@ -0,0 +1,109 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	type payload struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		opts       DecodeOpts
+		want       payload
+		wantKind   error
+		wantStatus int
+		wantDetail string
+	}{
+		{
+			name: "valid input",
+			body: `{"name":"todo","count":2}`,
+			opts: DecodeOpts{MaxBytes: 1024, DisallowUnknownFields: true},
+			want: payload{Name: "todo", Count: 2},
+		},
+		{
+			name: "unknown fields allowed by default",
+			body: `{"name":"todo","extra":true}`,
+			want: payload{Name: "todo"},
+		},
+		{
+			name:       "unknown fields rejected",
+			body:       `{"name":"todo","extra":true}`,
+			opts:       DecodeOpts{DisallowUnknownFields: true},
+			wantKind:   ErrUnknownField,
+			wantStatus: http.StatusBadRequest,
+			wantDetail: `"extra"`,
+		},
+		{
+			name:       "oversize body",
+			body:       `{"name":"` + strings.Repeat("x", 100) + `"}`,
+			opts:       DecodeOpts{MaxBytes: 16},
+			wantKind:   ErrBodyTooLarge,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "malformed syntax",
+			body:       `{"name":}`,
+			wantKind:   ErrMalformedJSON,
+			wantStatus: http.StatusBadRequest,
+			wantDetail: "syntax error",
+		},
+		{
+			name:       "truncated body",
+			body:       `{"name":"todo"`,
+			wantKind:   ErrMalformedJSON,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "wrong type",
+			body:       `{"count":"two"}`,
+			wantKind:   ErrMalformedJSON,
+			wantStatus: http.StatusBadRequest,
+			wantDetail: `"count"`,
+		},
+		{
+			name:       "empty body",
+			body:       ``,
+			wantKind:   ErrEmptyBody,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "trailing data",
+			body:       `{"name":"a"}{"name":"b"}`,
+			wantKind:   ErrMalformedJSON,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+
+			var got payload
+			err := DecodeJSON(req, &got, tt.opts)
+
+			if tt.wantKind == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.wantKind)
+			var derr *DecodeError
+			if assert.True(t, errors.As(err, &derr)) {
+				assert.Equal(t, tt.wantStatus, derr.StatusCode())
+				assert.Contains(t, derr.Error(), tt.wantDetail)
+			}
+		})
+	}
+}
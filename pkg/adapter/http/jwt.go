@@ -0,0 +1,438 @@
+package http
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // Registers SHA-256 for crypto.Hash
+	_ "crypto/sha512" // Registers SHA-384 and SHA-512 for crypto.Hash
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
+)
+
+const (
+	// jwksRefreshInterval is the minimum time between JWKS fetches, so
+	// tokens with unknown key IDs cannot be used to flood the provider
+	jwksRefreshInterval = time.Minute
+
+	// jwksFetchTimeout bounds a single JWKS fetch
+	jwksFetchTimeout = 10 * time.Second
+)
+
+// Claims are the claims of a validated JWT
+type Claims map[string]interface{}
+
+// claimsKey is the context key for validated JWT claims
+type claimsKey struct{}
+
+// ClaimsFrom returns the claims of the request's validated bearer token.
+// It returns nil when JWT auth is disabled or the path is exempt.
+func ClaimsFrom(ctx context.Context) Claims {
+	claims, _ := ctx.Value(claimsKey{}).(Claims)
+	return claims
+}
+
+// jwtHeader is the decoded JOSE header of a token
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jwtAlgorithm describes a supported signing algorithm
+type jwtAlgorithm struct {
+	hash  crypto.Hash
+	kind  string         // "HS", "RS" or "ES"
+	curve elliptic.Curve // ES only: the curve the algorithm is defined for
+}
+
+// jwtAlgorithms is the fixed set of accepted signing algorithms. Tokens
+// are verified with the standard library rather than a JOSE library: only
+// compact signed tokens with these algorithms are accepted, so there is no
+// "none", JWE or header-supplied key handling to get wrong, and services
+// using the router take on no further dependencies.
+var jwtAlgorithms = map[string]jwtAlgorithm{
+	"HS256": {crypto.SHA256, "HS", nil},
+	"HS384": {crypto.SHA384, "HS", nil},
+	"HS512": {crypto.SHA512, "HS", nil},
+	"RS256": {crypto.SHA256, "RS", nil},
+	"RS384": {crypto.SHA384, "RS", nil},
+	"RS512": {crypto.SHA512, "RS", nil},
+	"ES256": {crypto.SHA256, "ES", elliptic.P256()},
+	"ES384": {crypto.SHA384, "ES", elliptic.P384()},
+	"ES512": {crypto.SHA512, "ES", elliptic.P521()},
+}
+
+// jwtAuthMiddleware rejects requests to application routes without a valid
+// bearer token, storing the claims of valid tokens in the request context
+func (r *Router) jwtAuthMiddleware() func(http.Handler) http.Handler {
+	opts := r.opts.JWTAuth
+	keys := newJWTKeySource(opts)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if r.isBuiltinPath(req.URL.Path) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			claims, err := verifyJWT(req.Context(), token, keys, opts, time.Now())
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(req.Context(), claimsKey{}, claims)
+			for claim, key := range opts.ClaimsToContext {
+				if value, ok := claims[claim]; ok {
+					ctx = context.WithValue(ctx, key, value)
+				}
+			}
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
+
+// verifyJWT checks the token's signature and registered claims
+func verifyJWT(ctx context.Context, token string, keys *jwtKeySource, opts *domainhttp.JWTOptions, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("decoding header: %w", err)
+	}
+	alg, ok := jwtAlgorithms[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported algorithm: %s", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("decoding signature: %w", err)
+	}
+	key, err := keys.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyJWTSignature(alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("decoding claims: %w", err)
+	}
+	if err := checkJWTClaims(claims, opts, now); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// decodeJWTSegment decodes a base64url JSON segment into v
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// verifyJWTSignature verifies signature over signed using key. The key type
+// must match the algorithm family, so an RSA public key can never be used
+// as an HMAC secret, and an EC key must be on the algorithm's curve.
+func verifyJWTSignature(alg jwtAlgorithm, key interface{}, signed string, signature []byte) error {
+	switch alg.kind {
+	case "HS":
+		secret, ok := key.([]byte)
+		if !ok {
+			return errors.New("key does not match algorithm")
+		}
+		mac := hmac.New(alg.hash.New, secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case "RS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("key does not match algorithm")
+		}
+		return rsa.VerifyPKCS1v15(pub, alg.hash, digest(alg.hash, signed), signature)
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || pub.Curve != alg.curve {
+			return errors.New("key does not match algorithm")
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid signature")
+		}
+		rs, ss := new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest(alg.hash, signed), rs, ss) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	return errors.New("unsupported algorithm")
+}
+
+// digest returns the hash of s
+func digest(hash crypto.Hash, s string) []byte {
+	h := hash.New()
+	h.Write([]byte(s))
+	return h.Sum(nil)
+}
+
+// checkJWTClaims validates exp, nbf, iss and aud. A token without exp is
+// rejected unless opts.AllowMissingExpiry is set.
+func checkJWTClaims(claims Claims, opts *domainhttp.JWTOptions, now time.Time) error {
+	if exp, ok := claims["exp"].(float64); ok {
+		if now.After(time.Unix(int64(exp), 0).Add(opts.Leeway)) {
+			return errors.New("token expired")
+		}
+	} else if _, present := claims["exp"]; present {
+		return errors.New("invalid exp claim")
+	} else if !opts.AllowMissingExpiry {
+		return errors.New("missing exp claim")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok {
+		if now.Before(time.Unix(int64(nbf), 0).Add(-opts.Leeway)) {
+			return errors.New("token not yet valid")
+		}
+	} else if _, present := claims["nbf"]; present {
+		return errors.New("invalid nbf claim")
+	}
+
+	if opts.Issuer != "" && claims["iss"] != opts.Issuer {
+		return errors.New("unexpected issuer")
+	}
+	if opts.Audience != "" && !hasAudience(claims["aud"], opts.Audience) {
+		return errors.New("unexpected audience")
+	}
+	return nil
+}
+
+// hasAudience reports whether the aud claim, a string or array of
+// strings, contains audience
+func hasAudience(aud interface{}, audience string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == audience
+	case []interface{}:
+		for _, a := range v {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jwtKeySource resolves verification keys, either the static key or keys
+// fetched from a JWKS endpoint and cached by key ID
+type jwtKeySource struct {
+	static  interface{}
+	jwksURL string
+	client  *http.Client
+
+	mu        sync.RWMutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+
+	refresh singleflight.Group // Collapses concurrent JWKS fetches
+}
+
+// newJWTKeySource creates a key source for the options
+func newJWTKeySource(opts *domainhttp.JWTOptions) *jwtKeySource {
+	return &jwtKeySource{
+		static:  opts.Key,
+		jwksURL: opts.JWKSURL,
+		client:  &http.Client{Timeout: jwksFetchTimeout},
+	}
+}
+
+// key returns the key for kid, refreshing the JWKS when kid is unknown
+// and the last fetch is older than jwksRefreshInterval. Cached keys are
+// served while a refresh is in flight.
+func (s *jwtKeySource) key(ctx context.Context, kid string) (interface{}, error) {
+	if s.static != nil {
+		return s.static, nil
+	}
+
+	s.mu.RLock()
+	key, ok := s.lookup(kid)
+	fetchedAt := s.fetchedAt
+	s.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+	if time.Since(fetchedAt) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown key ID: %s", kid)
+	}
+
+	if _, err, _ := s.refresh.Do("jwks", func() (interface{}, error) {
+		return nil, s.refreshKeys(ctx)
+	}); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key ID: %s", kid)
+}
+
+// refreshKeys fetches the JWKS without holding the lock, unless another
+// caller refreshed it within jwksRefreshInterval
+func (s *jwtKeySource) refreshKeys(ctx context.Context) error {
+	s.mu.RLock()
+	recent := time.Since(s.fetchedAt) < jwksRefreshInterval
+	s.mu.RUnlock()
+	if recent {
+		return nil
+	}
+
+	keys, err := s.fetch(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetchedAt = time.Now()
+	if err != nil {
+		return err
+	}
+	s.keys = keys
+	return nil
+}
+
+// lookup returns the cached key for kid. Tokens without a key ID match
+// when the set holds a single key.
+func (s *jwtKeySource) lookup(kid string) (interface{}, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// jsonWebKey is a key in a JWKS document
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// fetch downloads the JWKS, skipping keys that are not RSA or EC signing keys
+func (s *jwtKeySource) fetch(ctx context.Context) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jwksFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.jwksURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating JWKS request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("decoding JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if key, err := jwk.publicKey(); err == nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	return keys, nil
+}
+
+// publicKey converts the JWK into an RSA or ECDSA public key
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type: %s", k.Kty)
+}
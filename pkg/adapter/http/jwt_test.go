@@ -0,0 +1,367 @@
+// pkg/adapter/http/jwt_test.go
+package http
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
+)
+
+type testSubjectKey struct{}
+
+// signTestJWT builds a token with the given header and claims signed by key
+func signTestJWT(t *testing.T, header, claims map[string]interface{}, key interface{}) string {
+	t.Helper()
+
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(header) + "." + encode(claims)
+	sum := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, sum[:])
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, sum[:])
+		require.NoError(t, err)
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newJWTRouter(t *testing.T, jwt domainhttp.JWTOptions) *Router {
+	t.Helper()
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithJWTAuth(jwt),
+	)
+	require.NoError(t, err)
+
+	r := router.(*Router)
+	r.Get("/api/items", func(w http.ResponseWriter, req *http.Request) {
+		if mapped, ok := req.Context().Value(testSubjectKey{}).(string); ok {
+			w.Header().Set("X-Subject", mapped)
+		}
+		subject, _ := ClaimsFrom(req.Context())["sub"].(string)
+		_, _ = w.Write([]byte(subject))
+	})
+	return r
+}
+
+func serveWithToken(router http.Handler, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRouterJWTAuth(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	now := time.Now()
+	valid := map[string]interface{}{
+		"sub": "user-1",
+		"iss": "https://idp.example.com",
+		"aud": []string{"orders", "billing"},
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := map[string]interface{}{}
+		for k, v := range valid {
+			claims[k] = v
+		}
+		claims[key] = value
+		return claims
+	}
+	without := func(key string) map[string]interface{} {
+		claims := with(key, nil)
+		delete(claims, key)
+		return claims
+	}
+	rs256 := map[string]interface{}{"alg": "RS256", "typ": "JWT"}
+
+	tests := []struct {
+		name       string
+		token      string
+		path       string
+		wantStatus int
+	}{
+		{
+			name:       "valid token",
+			token:      signTestJWT(t, rs256, valid, rsaKey),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing token",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "signed by another key",
+			token:      signTestJWT(t, rs256, valid, otherKey),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "expired",
+			token:      signTestJWT(t, rs256, with("exp", now.Add(-time.Hour).Unix()), rsaKey),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "expired within leeway",
+			token:      signTestJWT(t, rs256, with("exp", now.Add(-10*time.Second).Unix()), rsaKey),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing expiry",
+			token:      signTestJWT(t, rs256, without("exp"), rsaKey),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "not yet valid",
+			token:      signTestJWT(t, rs256, with("nbf", now.Add(time.Hour).Unix()), rsaKey),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong issuer",
+			token:      signTestJWT(t, rs256, with("iss", "https://evil.example.com"), rsaKey),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong audience",
+			token:      signTestJWT(t, rs256, with("aud", "inventory"), rsaKey),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "none algorithm",
+			token:      signTestJWT(t, map[string]interface{}{"alg": "none"}, valid, []byte{}),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "algorithm confusion with public key as HMAC secret",
+			token:      signTestJWT(t, map[string]interface{}{"alg": "HS256"}, valid, []byte("public")),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "malformed token",
+			token:      "not-a-jwt",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "internal endpoints exempt",
+			path:       "/internal/health",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	router := newJWTRouter(t, domainhttp.JWTOptions{
+		Key:             &rsaKey.PublicKey,
+		Issuer:          "https://idp.example.com",
+		Audience:        "orders",
+		Leeway:          30 * time.Second,
+		ClaimsToContext: map[string]interface{}{"sub": testSubjectKey{}},
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path
+			if path == "" {
+				path = "/api/items"
+			}
+			rec := serveWithToken(router, path, tt.token)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if rec.Code == http.StatusUnauthorized {
+				assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "Bearer")
+			}
+			if tt.wantStatus == http.StatusOK && path == "/api/items" {
+				assert.Equal(t, "user-1", rec.Body.String())
+				assert.Equal(t, "user-1", rec.Header().Get("X-Subject"), "claim mapped into context")
+			}
+		})
+	}
+}
+
+func TestRouterJWTAuthHMAC(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	router := newJWTRouter(t, domainhttp.JWTOptions{Key: secret})
+	claims := map[string]interface{}{"sub": "svc", "exp": time.Now().Add(time.Minute).Unix()}
+	header := map[string]interface{}{"alg": "HS256"}
+
+	assert.Equal(t, http.StatusOK, serveWithToken(router, "/api/items", signTestJWT(t, header, claims, secret)).Code)
+	assert.Equal(t, http.StatusUnauthorized,
+		serveWithToken(router, "/api/items", signTestJWT(t, header, claims, []byte("wrong"))).Code)
+}
+
+func TestRouterJWTAuthAllowMissingExpiry(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	header := map[string]interface{}{"alg": "HS256"}
+	token := signTestJWT(t, header, map[string]interface{}{"sub": "svc"}, secret)
+
+	strict := newJWTRouter(t, domainhttp.JWTOptions{Key: secret})
+	assert.Equal(t, http.StatusUnauthorized, serveWithToken(strict, "/api/items", token).Code)
+
+	lenient := newJWTRouter(t, domainhttp.JWTOptions{Key: secret, AllowMissingExpiry: true})
+	assert.Equal(t, http.StatusOK, serveWithToken(lenient, "/api/items", token).Code)
+}
+
+func TestVerifyJWTSignatureRejectsCurveMismatch(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	// A P-256 signature over a SHA-384 digest verifies under ecdsa, which
+	// truncates the digest, so only the curve check rejects it as ES384
+	signed := "header.claims"
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest(crypto.SHA384, signed))
+	require.NoError(t, err)
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	assert.Error(t, verifyJWTSignature(jwtAlgorithms["ES384"], &key.PublicKey, signed, signature))
+	assert.Error(t, verifyJWTSignature(jwtAlgorithms["ES512"], &key.PublicKey, signed, signature))
+
+	r, s, err = ecdsa.Sign(rand.Reader, key, digest(crypto.SHA256, signed))
+	require.NoError(t, err)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	assert.NoError(t, verifyJWTSignature(jwtAlgorithms["ES256"], &key.PublicKey, signed, signature))
+}
+
+func TestRouterJWTAuthJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	b64 := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	var fetches atomic.Int32
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA", "kid": "rsa-1", "use": "sig",
+					"n": b64(rsaKey.N.Bytes()),
+					"e": b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+				},
+				{
+					"kty": "EC", "kid": "ec-1", "crv": "P-256",
+					"x": b64(ecKey.X.FillBytes(make([]byte, 32))),
+					"y": b64(ecKey.Y.FillBytes(make([]byte, 32))),
+				},
+			},
+		})
+	}))
+	defer jwks.Close()
+
+	router := newJWTRouter(t, domainhttp.JWTOptions{JWKSURL: jwks.URL})
+	claims := map[string]interface{}{"sub": "user-2", "exp": time.Now().Add(time.Minute).Unix()}
+
+	rsaToken := signTestJWT(t, map[string]interface{}{"alg": "RS256", "kid": "rsa-1"}, claims, rsaKey)
+	ecToken := signTestJWT(t, map[string]interface{}{"alg": "ES256", "kid": "ec-1"}, claims, ecKey)
+	unknownKid := signTestJWT(t, map[string]interface{}{"alg": "RS256", "kid": "rsa-2"}, claims, rsaKey)
+
+	assert.Equal(t, http.StatusOK, serveWithToken(router, "/api/items", rsaToken).Code)
+	assert.Equal(t, http.StatusOK, serveWithToken(router, "/api/items", ecToken).Code)
+	assert.Equal(t, http.StatusUnauthorized, serveWithToken(router, "/api/items", unknownKid).Code)
+	assert.Equal(t, int32(1), fetches.Load(), "keys are cached and refetches are rate limited")
+}
+
+func TestJWTKeySourceRefreshDoesNotBlockCachedKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	b64 := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	var fetches atomic.Int32
+	refreshing := make(chan struct{})
+	release := make(chan struct{})
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fetches.Add(1) > 1 {
+			close(refreshing)
+			<-release
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA", "kid": "rsa-1",
+				"n": b64(rsaKey.N.Bytes()),
+				"e": b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+			}},
+		})
+	}))
+	defer jwks.Close()
+
+	keys := newJWTKeySource(&domainhttp.JWTOptions{JWKSURL: jwks.URL})
+	ctx := context.Background()
+	_, err = keys.key(ctx, "rsa-1")
+	require.NoError(t, err)
+
+	// Allow a refresh, then request unknown keys concurrently
+	keys.mu.Lock()
+	keys.fetchedAt = time.Now().Add(-2 * jwksRefreshInterval)
+	keys.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := keys.key(ctx, "rsa-2")
+			assert.EqualError(t, err, "unknown key ID: rsa-2")
+		}()
+	}
+	<-refreshing
+
+	// Cached keys are served while the refresh is in flight
+	done := make(chan error, 1)
+	go func() {
+		_, err := keys.key(ctx, "rsa-1")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("cached key lookup blocked on the JWKS refresh")
+	}
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(2), fetches.Load(), "concurrent refreshes share one fetch")
+}
@@ -0,0 +1,24 @@
+package http
+
+import (
+	"context"
+
+	"github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+// loggerKey is the context key for the request-scoped logger
+type loggerKey struct{}
+
+// contextWithLogger returns a copy of ctx carrying logger
+func contextWithLogger(ctx context.Context, logger logging.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFrom returns the request-scoped logger stored by the router's
+// logging middleware. It carries the request ID and, when the request is
+// traced, the trace and span IDs. It returns nil when the request was not
+// logged, for example because its path is excluded from logging.
+func LoggerFrom(ctx context.Context) logging.Logger {
+	logger, _ := ctx.Value(loggerKey{}).(logging.Logger)
+	return logger
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+// panicSpan receives the span a panic was recorded on. The recoverer runs
+// outside the tracing middleware, so it places one in the request context
+// for recordPanics to fill in, and correlates its log with the span.
+type panicSpan struct {
+	spanContext trace.SpanContext
+}
+
+// panicSpanKey is the context key for the request's panicSpan
+type panicSpanKey struct{}
+
+// panicError converts a recovered value to an error
+func panicError(value interface{}) error {
+	if err, ok := value.(error); ok {
+		return fmt.Errorf("panic: %w", err)
+	}
+	return fmt.Errorf("panic: %v", value)
+}
+
+// recoverMiddleware recovers from panics in later handlers, logs them with
+// their stack through the configured logger and responds with a 500.
+// Without a logger, chi's recoverer is used instead.
+func (r *Router) recoverMiddleware() func(http.Handler) http.Handler {
+	if r.opts.Logger == nil {
+		return middleware.Recoverer
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			recorded := &panicSpan{}
+			req = req.WithContext(context.WithValue(req.Context(), panicSpanKey{}, recorded))
+
+			defer func() {
+				value := recover()
+				if value == nil {
+					return
+				}
+				// Let the server abort the response as intended
+				if value == http.ErrAbortHandler {
+					panic(value)
+				}
+
+				ctx := req.Context()
+				if recorded.spanContext.IsValid() {
+					ctx = trace.ContextWithSpanContext(ctx, recorded.spanContext)
+				}
+
+				r.opts.Logger.WithContext(ctx).ErrorWith("Panic serving request", logging.Fields{
+					"panic":      fmt.Sprint(value),
+					"stack":      string(debug.Stack()),
+					"method":     req.Method,
+					"path":       req.URL.Path,
+					"request_id": middleware.GetReqID(ctx),
+				})
+
+				if req.Header.Get("Connection") != "Upgrade" {
+					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				}
+			}()
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// recordPanics records a panic on the request's span while the span is
+// still open, then re-panics with the original value so recoverers further
+// out see it unchanged
+func recordPanics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+			if value == http.ErrAbortHandler {
+				panic(value)
+			}
+
+			span := trace.SpanFromContext(req.Context())
+			span.RecordError(panicError(value), trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, "panic")
+			if recorded, ok := req.Context().Value(panicSpanKey{}).(*panicSpan); ok {
+				recorded.spanContext = span.SpanContext()
+			}
+			panic(value)
+		}()
+
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,164 @@
+// pkg/adapter/http/recover_test.go
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
+	"github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+	mocklog "github.com/damianoneill/go-bootstrap/pkg/domain/logging/mocks"
+	mocktracing "github.com/damianoneill/go-bootstrap/pkg/domain/tracing/mocks"
+)
+
+func TestRouterPanicRecovery(t *testing.T) {
+	// otelhttp records spans on the global provider
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctrl := gomock.NewController(t)
+	logger := mocklog.NewMockLogger(ctrl)
+	logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().With(gomock.Any()).Return(logger).AnyTimes()
+
+	var lastCtx context.Context
+	logger.EXPECT().WithContext(gomock.Any()).
+		DoAndReturn(func(ctx context.Context) logging.Logger {
+			lastCtx = ctx
+			return logger
+		}).AnyTimes()
+
+	var logged logging.Fields
+	logger.EXPECT().ErrorWith("Panic serving request", gomock.Any()).
+		Do(func(_ string, fields logging.Fields) { logged = fields })
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithLogger(logger),
+		domainhttp.WithTracingProvider(mocktracing.NewMockProvider(ctrl)),
+	)
+	require.NoError(t, err)
+	router.(*Router).Get("/explode", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/explode", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// The span is marked failed with the panic recorded as an exception
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, codes.Error, span.Status().Code)
+	var messages []string
+	for _, event := range span.Events() {
+		for _, attr := range event.Attributes {
+			if event.Name == "exception" && attr.Key == "exception.message" {
+				messages = append(messages, attr.Value.AsString())
+			}
+		}
+	}
+	assert.Contains(t, messages, "panic: boom")
+
+	// The log carries the panic, its stack and the span's trace
+	require.NotNil(t, logged)
+	assert.Equal(t, "boom", logged["panic"])
+	assert.Contains(t, logged["stack"], "TestRouterPanicRecovery")
+	assert.Equal(t, "/explode", logged["path"])
+	assert.Equal(t, span.SpanContext().TraceID(), trace.SpanContextFromContext(lastCtx).TraceID())
+}
+
+func TestRouterPanicRecoveryWithoutLogger(t *testing.T) {
+	router, err := NewFactory().NewRouter(domainhttp.WithService("test-service", "1.0"))
+	require.NoError(t, err)
+	router.(*Router).Get("/explode", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/explode", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestRouterPanicRecoveryAbortHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := mocklog.NewMockLogger(ctrl)
+	logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().With(gomock.Any()).Return(logger).AnyTimes()
+	logger.EXPECT().WithContext(gomock.Any()).Return(logger).AnyTimes()
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithLogger(logger),
+	)
+	require.NoError(t, err)
+	router.(*Router).Get("/abort", func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+
+	// The server relies on the panic to abort the response, so it is not logged
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/abort", nil))
+	})
+}
+
+func TestRouterPanicRecoveryPreservesValue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	errBoom := errors.New("boom")
+
+	var recovered interface{}
+	appRecoverer := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if recovered = recover(); recovered != nil {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithTracingProvider(mocktracing.NewMockProvider(ctrl)),
+		domainhttp.WithMiddlewareOrdering(&domainhttp.MiddlewareOrdering{
+			Order: []domainhttp.MiddlewareCategory{
+				domainhttp.CoreMiddleware,
+				domainhttp.SecurityMiddleware,
+				domainhttp.ApplicationMiddleware,
+				domainhttp.ObservabilityMiddleware,
+			},
+			CustomMiddleware: map[domainhttp.MiddlewareCategory][]func(http.Handler) http.Handler{
+				domainhttp.ApplicationMiddleware: {appRecoverer},
+			},
+		}),
+	)
+	require.NoError(t, err)
+	router.(*Router).Get("/explode", func(w http.ResponseWriter, r *http.Request) {
+		panic(errBoom)
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/explode", nil))
+
+	// Recoverers outside the tracing middleware see the original value
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, errBoom, recovered)
+}
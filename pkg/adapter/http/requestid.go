@@ -0,0 +1,59 @@
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// maxRequestIDLength bounds incoming request IDs, which end up in every
+// log line for the request
+const maxRequestIDLength = 128
+
+// requestIDMiddleware reads the request ID from the configured header,
+// generating one when it is missing or unusable, and echoes it on the
+// response. The ID is stored where chi's middleware.GetReqID finds it.
+// Without a configured header, chi's RequestID middleware is used instead.
+func (r *Router) requestIDMiddleware() func(http.Handler) http.Handler {
+	header := r.opts.RequestIDHeader
+	if header == "" {
+		return middleware.RequestID
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			id := req.Header.Get(header)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+
+			w.Header().Set(header, id)
+			ctx := context.WithValue(req.Context(), middleware.RequestIDKey, id)
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
+
+// validRequestID reports whether id is non-empty, bounded and made of
+// printable ASCII, so a client cannot inject arbitrary text into logs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random 128-bit request ID
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
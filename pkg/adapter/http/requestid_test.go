@@ -0,0 +1,104 @@
+// pkg/adapter/http/requestid_test.go
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
+	"github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+	mocklog "github.com/damianoneill/go-bootstrap/pkg/domain/logging/mocks"
+)
+
+func TestRouterRequestIDHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		wantID   string
+	}{
+		{
+			name:     "incoming ID is honored",
+			incoming: "lb-7f3a9c",
+			wantID:   "lb-7f3a9c",
+		},
+		{
+			name: "missing ID is generated",
+		},
+		{
+			name:     "unusable ID is replaced",
+			incoming: "forged\tid",
+		},
+		{
+			name:     "oversized ID is replaced",
+			incoming: strings.Repeat("a", maxRequestIDLength+1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			logger := mocklog.NewMockLogger(ctrl)
+			logger.EXPECT().WithContext(gomock.Any()).Return(logger).AnyTimes()
+			logger.EXPECT().With(gomock.Any()).Return(logger).AnyTimes()
+
+			var logged logging.Fields
+			logger.EXPECT().InfoWith("HTTP Request", gomock.Any()).
+				Do(func(_ string, fields logging.Fields) { logged = fields })
+
+			router, err := NewFactory().NewRouter(
+				domainhttp.WithService("test-service", "1.0"),
+				domainhttp.WithLogger(logger),
+				domainhttp.WithRequestIDHeader("X-Correlation-Id"),
+			)
+			require.NoError(t, err)
+
+			var handlerID string
+			router.(*Router).Get("/orders", func(w http.ResponseWriter, r *http.Request) {
+				handlerID = middleware.GetReqID(r.Context())
+			})
+
+			req := httptest.NewRequest("GET", "/orders", nil)
+			if tt.incoming != "" {
+				req.Header.Set("X-Correlation-Id", tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			echoed := rec.Header().Get("X-Correlation-Id")
+			if tt.wantID != "" {
+				assert.Equal(t, tt.wantID, echoed)
+			} else {
+				assert.Len(t, echoed, 32, "generated ID is echoed")
+				assert.NotEqual(t, tt.incoming, echoed)
+			}
+			assert.Equal(t, echoed, handlerID)
+			assert.Equal(t, echoed, logged["request_id"])
+		})
+	}
+}
+
+func TestRouterDefaultRequestID(t *testing.T) {
+	router, err := NewFactory().NewRouter(domainhttp.WithService("test-service", "1.0"))
+	require.NoError(t, err)
+
+	var handlerID string
+	router.(*Router).Get("/orders", func(w http.ResponseWriter, r *http.Request) {
+		handlerID = middleware.GetReqID(r.Context())
+	})
+
+	req := httptest.NewRequest("GET", "/orders", nil)
+	req.Header.Set("X-Correlation-Id", "lb-7f3a9c")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.NotEmpty(t, handlerID)
+	assert.NotEqual(t, "lb-7f3a9c", handlerID, "the header is only read when configured")
+	assert.Empty(t, rec.Header().Get("X-Correlation-Id"))
+}
@@ -4,20 +4,45 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/http/pprof"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 
 	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
 	"github.com/damianoneill/go-bootstrap/pkg/domain/logging"
 	"github.com/damianoneill/go-bootstrap/pkg/domain/metrics"
+	domainoptions "github.com/damianoneill/go-bootstrap/pkg/domain/options"
 )
 
+// defaultMetricsPath is the path serving metrics when none is configured
+const defaultMetricsPath = "/metrics"
+
+// defaultUnmatchedPathLabel is the metrics path label for requests that
+// match no route when none is configured
+const defaultUnmatchedPathLabel = "unmatched"
+
+// defaultRequestTimeout bounds each request unless the core middleware
+// category has its own timeout
+const defaultRequestTimeout = 30 * time.Second
+
+// gathererProvider is implemented by collectors backed by a Prometheus registry
+type gathererProvider interface {
+	Gatherer() prometheus.Gatherer
+}
+
 // Router implements the domain Router interface using Chi
 type Router struct {
 	chi.Router                   // Embed chi.Router for HTTP routing
@@ -44,7 +69,10 @@ func NewFactory() *Factory {
 func (f *Factory) NewRouter(opts ...domainhttp.Option) (domainhttp.Router, error) {
 	// Initialize options with defaults
 	options := domainhttp.RouterOptions{
-		ProbeHandlers: domainhttp.DefaultProbeHandlers(),
+		ProbeHandlers:      domainhttp.DefaultProbeHandlers(),
+		ProbePaths:         domainhttp.DefaultProbePaths(),
+		MetricsPath:        defaultMetricsPath,
+		UnmatchedPathLabel: defaultUnmatchedPathLabel,
 	}
 
 	// Apply provided options
@@ -56,18 +84,19 @@ func (f *Factory) NewRouter(opts ...domainhttp.Option) (domainhttp.Router, error
 
 	// Validate required options
 	if options.ServiceName == "" {
-		return nil, fmt.Errorf("service name is required")
+		return nil, domainoptions.Invalid("ServiceName", "service name is required")
 	}
 
 	// Create metrics collector if metrics factory provided
 	var metricsCollector metrics.Collector
 	if options.MetricsFactory != nil {
-		collector, err := options.MetricsFactory.NewCollector(
+		metricsOpts := append([]metrics.Option{
 			metrics.WithServiceName(options.ServiceName),
 			metrics.WithLabels(map[string]string{
 				"version": options.ServiceVersion,
 			}),
-		)
+		}, options.MetricsOptions...)
+		collector, err := options.MetricsFactory.NewCollector(metricsOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("creating metrics collector: %w", err)
 		}
@@ -83,6 +112,11 @@ func (f *Factory) NewRouter(opts ...domainhttp.Option) (domainhttp.Router, error
 	return router, nil
 }
 
+// Metrics implements domainhttp.MetricsProvider
+func (r *Router) Metrics() metrics.Collector {
+	return r.metrics
+}
+
 // newRouter creates a new configured Router instance
 func newRouter(opts domainhttp.RouterOptions, collector metrics.Collector) (*Router, error) {
 	r := &Router{
@@ -92,6 +126,11 @@ func newRouter(opts domainhttp.RouterOptions, collector metrics.Collector) (*Rou
 		matcher: newMatcher(),
 	}
 
+	// Never log or trace metrics scrapes
+	if collector != nil {
+		r.excludeFromObservability(r.opts.MetricsPath)
+	}
+
 	// Create and configure middleware
 	if err := r.configureMiddleware(); err != nil {
 		return nil, fmt.Errorf("configuring middleware: %w", err)
@@ -120,21 +159,66 @@ func (r *Router) configureMiddleware() error {
 		}
 	}
 
+	var coreMiddleware []func(http.Handler) http.Handler
+	if len(r.opts.ResponseHeaders) > 0 {
+		// First, so redirects and error responses carry the headers too
+		coreMiddleware = append(coreMiddleware, r.responseHeadersMiddleware())
+	}
+	if len(r.opts.AllowedHosts) > 0 {
+		// Ahead of the HTTPS redirect, which is built from the Host header
+		coreMiddleware = append(coreMiddleware, r.allowedHostsMiddleware())
+	}
+	if r.opts.RequireHTTPS != "" {
+		coreMiddleware = append(coreMiddleware, r.requireHTTPSMiddleware())
+	}
+	coreTimeout := defaultRequestTimeout
+	if timeout, ok := ordering.CategoryTimeouts[domainhttp.CoreMiddleware]; ok {
+		coreTimeout = timeout
+	}
+	coreMiddleware = append(coreMiddleware,
+		r.requestIDMiddleware(),
+		middleware.RealIP,
+		r.recoverMiddleware(),
+		middleware.Timeout(coreTimeout),
+	)
+	if len(r.opts.ContextValues) > 0 {
+		coreMiddleware = append(coreMiddleware, r.contextValuesMiddleware())
+	}
+	if r.opts.MaxRequestBodySize > 0 {
+		coreMiddleware = append(coreMiddleware, r.maxBodySizeMiddleware())
+	}
+	if r.opts.MaxRequestDuration > 0 {
+		coreMiddleware = append(coreMiddleware, r.maxDurationMiddleware())
+	}
+
 	// Categorize built-in middleware
 	middlewareByCategory := map[domainhttp.MiddlewareCategory][]func(http.Handler) http.Handler{
-		domainhttp.CoreMiddleware: {
-			middleware.RequestID,
-			middleware.RealIP,
-			middleware.Recoverer,
-			middleware.Timeout(30 * time.Second),
-		},
+		domainhttp.CoreMiddleware: coreMiddleware,
 		domainhttp.SecurityMiddleware: {
 			middleware.StripSlashes, // URL normalization for security
 			middleware.RedirectSlashes,
-			r.securityHeadersMiddleware(), // New middleware for basic security headers
+			r.securityHeadersMiddleware(),
 		},
 		domainhttp.ObservabilityMiddleware: r.getObservabilityMiddleware(),
 	}
+	if r.opts.CSRF != nil {
+		middlewareByCategory[domainhttp.SecurityMiddleware] = append(
+			middlewareByCategory[domainhttp.SecurityMiddleware],
+			r.csrfMiddleware(),
+		)
+	}
+	if r.opts.JWTAuth != nil {
+		middlewareByCategory[domainhttp.SecurityMiddleware] = append(
+			middlewareByCategory[domainhttp.SecurityMiddleware],
+			r.jwtAuthMiddleware(),
+		)
+	}
+	if r.opts.BasicAuth != nil {
+		middlewareByCategory[domainhttp.SecurityMiddleware] = append(
+			middlewareByCategory[domainhttp.SecurityMiddleware],
+			r.basicAuthMiddleware(),
+		)
+	}
 
 	// Merge custom middleware
 	if ordering.CustomMiddleware != nil {
@@ -146,6 +230,18 @@ func (r *Router) configureMiddleware() error {
 		}
 	}
 
+	// Bound later categories by their own timeouts, applied before their
+	// middleware so the deadline covers the rest of the chain
+	for category, timeout := range ordering.CategoryTimeouts {
+		if category == domainhttp.CoreMiddleware {
+			continue
+		}
+		middlewareByCategory[category] = append(
+			[]func(http.Handler) http.Handler{middleware.Timeout(timeout)},
+			middlewareByCategory[category]...,
+		)
+	}
+
 	// Apply middleware in configured order
 	for _, category := range ordering.Order {
 		for _, mw := range middlewareByCategory[category] {
@@ -174,6 +270,9 @@ func (r *Router) getObservabilityMiddleware() []func(http.Handler) http.Handler
 	if r.metrics != nil {
 		middleware = append(middleware, r.metricsMiddleware())
 	}
+	if clients, ok := r.metrics.(metrics.ClientCollector); ok && r.opts.UserAgentClassifier != nil {
+		middleware = append(middleware, r.userAgentMetricsMiddleware(clients))
+	}
 
 	return middleware
 }
@@ -183,26 +282,167 @@ func (r *Router) configureRoutes() error {
 	// Configure internal routes
 	internal := chi.NewRouter()
 
-	// Health probe routes
-	internal.Get("/health", r.probeHandler(r.opts.ProbeHandlers.LivenessCheck))
-	internal.Get("/ready", r.probeHandler(r.opts.ProbeHandlers.ReadinessCheck))
-	internal.Get("/startup", r.probeHandler(r.opts.ProbeHandlers.StartupCheck))
+	// Profiling routes, only when explicitly enabled
+	if r.opts.EnablePprof {
+		internal.Route("/debug/pprof", r.pprofRoutes)
+	}
+
+	if r.opts.EnableRouteViewer {
+		internal.Get("/routes", r.routesHandler)
+	}
+
+	// Internal endpoints go to a dedicated router when one is configured
+	target := chi.Router(r)
+	if r.opts.InternalRouter != nil {
+		target = r.opts.InternalRouter
+
+		// The internal router does not share the main router's middleware
+		if r.opts.BasicAuth != nil {
+			if len(target.Routes()) > 0 {
+				return fmt.Errorf("basic auth requires an internal router without routes")
+			}
+			target.Use(r.basicAuthMiddleware())
+		}
+	}
+
+	// Health probe routes, under /internal unless configured elsewhere
+	probes := map[string]http.HandlerFunc{
+		r.opts.ProbePaths.Liveness:  r.probeHandler(r.opts.ProbeHandlers.LivenessCheck),
+		r.opts.ProbePaths.Readiness: r.contextProbeHandler(r.readinessCheck()),
+		r.opts.ProbePaths.Startup:   r.probeHandler(r.opts.ProbeHandlers.StartupCheck),
+	}
+	for path, handler := range probes {
+		if path == "" {
+			continue
+		}
+		if sub, ok := strings.CutPrefix(path, "/internal/"); ok {
+			internal.Get("/"+sub, handler)
+			continue
+		}
+		target.Get(path, handler)
+	}
 
 	// Mount internal routes
-	r.Mount("/internal", internal)
+	target.Mount("/internal", internal)
 
 	// Add metrics endpoint if collector configured
 	if r.metrics != nil {
-		r.Handle("/metrics", promhttp.Handler())
+		target.Handle(r.opts.MetricsPath, r.metricsHandler())
 	}
 
 	return nil
 }
 
+// routeInfo describes a registered route in the route table endpoint
+type routeInfo struct {
+	Method  string `json:"method"`
+	Pattern string `json:"pattern"`
+}
+
+// routesHandler serves the registered routes of the main router and, when
+// set, the internal router. The tree is walked per request so routes added
+// after the router was created are listed.
+func (r *Router) routesHandler(w http.ResponseWriter, _ *http.Request) {
+	routers := []chi.Routes{r.Router}
+	if r.opts.InternalRouter != nil {
+		routers = append(routers, r.opts.InternalRouter)
+	}
+
+	routes := []routeInfo{}
+	for _, router := range routers {
+		err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			routes = append(routes, routeInfo{Method: method, Pattern: route})
+			return nil
+		})
+		if err != nil {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Pattern != routes[j].Pattern {
+			return routes[i].Pattern < routes[j].Pattern
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(routes)
+}
+
+// pprofRoutes registers the net/http/pprof handlers
+func (r *Router) pprofRoutes(pr chi.Router) {
+	pr.Get("/", pprof.Index)
+	pr.Get("/cmdline", pprof.Cmdline)
+	pr.Get("/profile", pprof.Profile)
+	pr.Get("/symbol", pprof.Symbol)
+	pr.Post("/symbol", pprof.Symbol)
+	pr.Get("/trace", pprof.Trace)
+	pr.Get("/{profile}", func(w http.ResponseWriter, req *http.Request) {
+		pprof.Handler(chi.URLParam(req, "profile")).ServeHTTP(w, req)
+	})
+}
+
+// metricsHandler serves metrics from the collector's registry,
+// falling back to the global registry for other collectors
+func (r *Router) metricsHandler() http.Handler {
+	opts := promhttp.HandlerOpts{EnableOpenMetrics: !r.opts.DisableOpenMetrics}
+	if provider, ok := r.metrics.(gathererProvider); ok {
+		return promhttp.HandlerFor(provider.Gatherer(), opts)
+	}
+	// As promhttp.Handler, which cannot be given options
+	return promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, opts))
+}
+
+// excludeFromObservability adds path to the logging and tracing
+// exclusions unless it is already covered by an existing pattern
+func (r *Router) excludeFromObservability(path string) {
+	if !r.matcher.Matches(path, r.opts.ExcludeFromLogging) {
+		r.opts.ExcludeFromLogging = append(
+			append([]string(nil), r.opts.ExcludeFromLogging...), path)
+	}
+	if !r.matcher.Matches(path, r.opts.ExcludeFromTracing) {
+		r.opts.ExcludeFromTracing = append(
+			append([]string(nil), r.opts.ExcludeFromTracing...), path)
+	}
+}
+
 // probeHandler creates a handler for probe endpoints
 func (r *Router) probeHandler(check domainhttp.ProbeCheck) http.HandlerFunc {
-	return func(w http.ResponseWriter, _ *http.Request) {
-		resp := check()
+	return r.contextProbeHandler(func(context.Context) domainhttp.ProbeResponse {
+		return check()
+	})
+}
+
+// readinessCheck returns the configured readiness check, reporting
+// "starting" without running it during the initial delay
+func (r *Router) readinessCheck() domainhttp.ContextProbeCheck {
+	check := r.opts.ProbeHandlers.ReadinessContextCheck
+	if check == nil {
+		readiness := r.opts.ProbeHandlers.ReadinessCheck
+		check = func(context.Context) domainhttp.ProbeResponse {
+			return readiness()
+		}
+	}
+
+	if r.opts.ReadinessInitialDelay <= 0 {
+		return check
+	}
+
+	readyAfter := time.Now().Add(r.opts.ReadinessInitialDelay)
+	return func(ctx context.Context) domainhttp.ProbeResponse {
+		if time.Now().Before(readyAfter) {
+			return domainhttp.ProbeResponse{Status: "starting"}
+		}
+		return check(ctx)
+	}
+}
+
+// contextProbeHandler creates a handler for probes that use the request context
+func (r *Router) contextProbeHandler(check domainhttp.ContextProbeCheck) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		resp := check(req.Context())
 		if err := r.writeProbeResponse(w, resp); err != nil {
 			if r.opts.Logger != nil {
 				r.opts.Logger.ErrorWith("Failed to write probe response", logging.Fields{
@@ -226,6 +466,11 @@ func (r *Router) writeProbeResponse(w http.ResponseWriter, resp domainhttp.Probe
 
 // loggingMiddleware creates a middleware for request logging
 func (r *Router) loggingMiddleware() func(http.Handler) http.Handler {
+	fieldsFunc := r.opts.AccessLogFields
+	if fieldsFunc == nil {
+		fieldsFunc = defaultAccessLogFields
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			// Skip excluded paths
@@ -237,18 +482,31 @@ func (r *Router) loggingMiddleware() func(http.Handler) http.Handler {
 			start := time.Now()
 			ww := middleware.NewWrapResponseWriter(w, req.ProtoMajor)
 
+			// Use WithContext to include trace information
+			contextLogger := r.opts.Logger.WithContext(req.Context())
+
+			// Handlers log via LoggerFrom with the request ID attached
+			requestLogger := contextLogger.With(logging.Fields{
+				"request_id": middleware.GetReqID(req.Context()),
+			})
+			req = req.WithContext(contextWithLogger(req.Context(), requestLogger))
+
 			defer func() {
-				// Use WithContext to include trace information
-				contextLogger := r.opts.Logger.WithContext(req.Context())
-
-				contextLogger.InfoWith("HTTP Request", logging.Fields{
-					"method":     req.Method,
-					"path":       req.URL.Path,
-					"status":     ww.Status(),
-					"duration":   time.Since(start).String(),
-					"size":       ww.BytesWritten(),
-					"request_id": middleware.GetReqID(req.Context()),
-				})
+				resp := domainhttp.ResponseInfo{
+					Status:       ww.Status(),
+					BytesWritten: ww.BytesWritten(),
+					Duration:     time.Since(start),
+				}
+				fields := fieldsFunc(req, resp)
+				for field, key := range r.opts.LoggedContextKeys {
+					if value := req.Context().Value(key); value != nil {
+						if fields == nil {
+							fields = logging.Fields{}
+						}
+						fields[field] = value
+					}
+				}
+				contextLogger.InfoWith("HTTP Request", fields)
 			}()
 
 			next.ServeHTTP(ww, req)
@@ -256,6 +514,18 @@ func (r *Router) loggingMiddleware() func(http.Handler) http.Handler {
 	}
 }
 
+// defaultAccessLogFields returns the standard set of access log fields
+func defaultAccessLogFields(req *http.Request, resp domainhttp.ResponseInfo) logging.Fields {
+	return logging.Fields{
+		"method":     req.Method,
+		"path":       req.URL.Path,
+		"status":     resp.Status,
+		"duration":   resp.Duration.String(),
+		"size":       resp.BytesWritten,
+		"request_id": middleware.GetReqID(req.Context()),
+	}
+}
+
 // tracingMiddleware creates a middleware for request tracing
 func (r *Router) tracingMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -265,16 +535,13 @@ func (r *Router) tracingMiddleware() func(http.Handler) http.Handler {
 				return
 			}
 
-			// Create operation name from request
-			operation := fmt.Sprintf("%s %s", req.Method, req.URL.Path)
-
-			// Use otelhttp with proper operation name
-			opts := r.opts // capture options for formatter
+			// The span starts before routing, so it is named and annotated
+			// once the route pattern is known
 			handler := otelhttp.NewHandler(
-				next,
-				operation,
-				otelhttp.WithSpanNameFormatter(func(operation string, _ *http.Request) string {
-					return fmt.Sprintf("%s.http %s", opts.RouterOptions.ServiceName, operation)
+				r.annotateSpan(recordPanics(next)),
+				req.Method,
+				otelhttp.WithSpanNameFormatter(func(_ string, req *http.Request) string {
+					return r.spanName(req)
 				}),
 			)
 			handler.ServeHTTP(w, req)
@@ -282,6 +549,43 @@ func (r *Router) tracingMiddleware() func(http.Handler) http.Handler {
 	}
 }
 
+// annotateSpan renames the request span and records the route, request ID
+// and response size once the handler has run, including when it panics,
+// as the route pattern is only known after routing
+func (r *Router) annotateSpan(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, req.ProtoMajor)
+		defer func() {
+			span := trace.SpanFromContext(req.Context())
+			span.SetName(r.spanName(req))
+
+			attrs := []attribute.KeyValue{
+				attribute.Int("http.response_content_length", ww.BytesWritten()),
+			}
+			if rctx := chi.RouteContext(req.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				attrs = append(attrs, semconv.HTTPRoute(rctx.RoutePattern()))
+			}
+			if requestID := middleware.GetReqID(req.Context()); requestID != "" {
+				attrs = append(attrs, attribute.String("http.request_id", requestID))
+			}
+			if r.opts.SpanAttributes != nil {
+				attrs = append(attrs, r.opts.SpanAttributes(req)...)
+			}
+			span.SetAttributes(attrs...)
+		}()
+		next.ServeHTTP(ww, req)
+	})
+}
+
+// spanName names a request span using the configured formatter, or the
+// method and route pattern by default
+func (r *Router) spanName(req *http.Request) string {
+	if r.opts.SpanNameFormatter != nil {
+		return r.opts.SpanNameFormatter(req)
+	}
+	return req.Method + " " + r.normalizePath(req)
+}
+
 // metricsMiddleware creates a middleware for collecting request metrics
 func (r *Router) metricsMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -304,26 +608,149 @@ func (r *Router) metricsMiddleware() func(http.Handler) http.Handler {
 	}
 }
 
-// Add basic security headers middleware
+// userAgentMetricsMiddleware counts requests by client class
+func (r *Router) userAgentMetricsMiddleware(clients metrics.ClientCollector) func(http.Handler) http.Handler {
+	classify := r.opts.UserAgentClassifier
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if !r.matcher.Matches(req.URL.Path, r.opts.ExcludeFromLogging) {
+				clients.CollectClientRequest(classify(req.UserAgent()))
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// securityHeadersMiddleware sets the configured security headers, or the
+// basic set when none are configured
 func (r *Router) securityHeadersMiddleware() func(http.Handler) http.Handler {
+	headers := r.opts.SecurityHeaders
+	if headers == nil {
+		headers = &domainhttp.SecurityHeaderOptions{FrameOptions: "DENY"}
+	}
+
+	var hsts string
+	if r.opts.SecurityHeaders != nil {
+		hsts = fmt.Sprintf("max-age=%d", int64(headers.HSTSMaxAge.Seconds()))
+		if headers.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+	trusted := parseTrustedProxies(r.opts.TrustedProxies)
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			// Basic security headers
 			w.Header().Set("X-Content-Type-Options", "nosniff")
-			w.Header().Set("X-Frame-Options", "DENY")
+			w.Header().Set("X-Frame-Options", headers.FrameOptions)
 			w.Header().Set("X-XSS-Protection", "1; mode=block")
 
+			if headers.ContentSecurityPolicy != "" {
+				w.Header().Set("Content-Security-Policy", headers.ContentSecurityPolicy)
+			}
+			if headers.ReferrerPolicy != "" {
+				w.Header().Set("Referrer-Policy", headers.ReferrerPolicy)
+			}
+			// Browsers ignore HSTS received over plaintext
+			if hsts != "" && isHTTPS(req, trusted) {
+				w.Header().Set("Strict-Transport-Security", hsts)
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// responseHeadersMiddleware sets the configured static headers on every
+// response, leaving any already set. Handlers run afterwards and may
+// replace them.
+func (r *Router) responseHeadersMiddleware() func(http.Handler) http.Handler {
+	headers := r.opts.ResponseHeaders
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			for name, value := range headers {
+				if w.Header().Get(name) == "" {
+					w.Header().Set(name, value)
+				}
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// contextValuesMiddleware adds the configured values to each request context
+func (r *Router) contextValuesMiddleware() func(http.Handler) http.Handler {
+	values := r.opts.ContextValues
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx := req.Context()
+			for key, value := range values {
+				ctx = context.WithValue(ctx, key, value)
+			}
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
+
+// maxBodySizeMiddleware limits the size of request bodies. Requests that
+// declare an oversized Content-Length are rejected up front, otherwise the
+// body is wrapped so reads beyond the limit fail.
+func (r *Router) maxBodySizeMiddleware() func(http.Handler) http.Handler {
+	limit := r.opts.MaxRequestBodySize
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.ContentLength > limit {
+				http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+				return
+			}
+			req.Body = http.MaxBytesReader(w, req.Body, limit)
 			next.ServeHTTP(w, req)
 		})
 	}
 }
 
-// normalizePath returns a normalized path for metrics collection
+// maxDurationMiddleware cancels the request context once the configured
+// maximum duration is reached and reports requests that hit the cap
+func (r *Router) maxDurationMiddleware() func(http.Handler) http.Handler {
+	limit := r.opts.MaxRequestDuration
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx, cancel := context.WithTimeout(req.Context(), limit)
+			defer cancel()
+
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, req.ProtoMajor)
+			next.ServeHTTP(ww, req.WithContext(ctx))
+
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return
+			}
+
+			if r.opts.Logger != nil {
+				r.opts.Logger.WithContext(req.Context()).WarnWith("Request exceeded maximum duration", logging.Fields{
+					"method":       req.Method,
+					"path":         req.URL.Path,
+					"max_duration": limit.String(),
+					"duration":     time.Since(start).String(),
+					"request_id":   middleware.GetReqID(req.Context()),
+				})
+			}
+
+			if ww.Status() == 0 {
+				w.WriteHeader(http.StatusGatewayTimeout)
+			}
+		})
+	}
+}
+
+// normalizePath returns a normalized path for metrics collection.
+// Requests matching no route collapse to a single label so arbitrary
+// URLs cannot inflate label cardinality.
 func (r *Router) normalizePath(req *http.Request) string {
 	if rctx := chi.RouteContext(req.Context()); rctx != nil && rctx.RoutePattern() != "" {
 		return rctx.RoutePattern()
 	}
-	return req.URL.Path
+	return r.opts.UnmatchedPathLabel
 }
 
 // Close handles cleanup of router resources
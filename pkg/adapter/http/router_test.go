@@ -4,17 +4,32 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/mock/gomock"
 
+	adaptermetrics "github.com/damianoneill/go-bootstrap/pkg/adapter/metrics"
 	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
+	"github.com/damianoneill/go-bootstrap/pkg/domain/logging"
 	mocklog "github.com/damianoneill/go-bootstrap/pkg/domain/logging/mocks"
 	mockmetrics "github.com/damianoneill/go-bootstrap/pkg/domain/metrics/mocks"
+	domainoptions "github.com/damianoneill/go-bootstrap/pkg/domain/options"
 	mocktracing "github.com/damianoneill/go-bootstrap/pkg/domain/tracing/mocks"
 )
 
@@ -84,6 +99,15 @@ func TestNewRouter(t *testing.T) {
 	}
 }
 
+func TestNewRouterValidationError(t *testing.T) {
+	_, err := NewFactory().NewRouter()
+
+	var verr *domainoptions.ValidationError
+	if assert.ErrorAs(t, err, &verr) {
+		assert.Equal(t, "ServiceName", verr.Field)
+	}
+}
+
 func TestRouterProbeEndpoints(t *testing.T) {
 	factory := NewFactory()
 	router, err := factory.NewRouter(
@@ -140,6 +164,111 @@ func TestRouterProbeEndpoints(t *testing.T) {
 	}
 }
 
+func TestRouterProbePaths(t *testing.T) {
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithProbePaths("/healthz", "/readyz", ""),
+		domainhttp.WithAllowedHosts([]string{"example.com"}),
+	)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "custom liveness path", path: "/healthz", wantStatus: http.StatusOK},
+		{name: "custom readiness path", path: "/readyz", wantStatus: http.StatusOK},
+		{name: "default liveness path removed", path: "/internal/health", wantStatus: http.StatusNotFound},
+		{name: "disabled startup probe", path: "/internal/startup", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+
+	// Probes are reached by pod IP, so a disallowed host must not block them
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/healthz", nil)
+	req.Host = "10.0.0.1"
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRouterReadinessContextCheck(t *testing.T) {
+	probes := domainhttp.DefaultProbeHandlers()
+	probes.ReadinessContextCheck = func(ctx context.Context) domainhttp.ProbeResponse {
+		// The request ID middleware runs before probes, so its ID proves
+		// the check received the request context
+		return domainhttp.NewProbeResponse("failed", map[string]interface{}{
+			"request_id": middleware.GetReqID(ctx),
+		})
+	}
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithProbeHandlers(probes),
+	)
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/internal/ready", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-123")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var got domainhttp.ProbeResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	assert.Equal(t, "req-123", got.Details["request_id"])
+}
+
+func TestRouterReadinessInitialDelay(t *testing.T) {
+	var checks atomic.Int32
+	probes := domainhttp.DefaultProbeHandlers()
+	probes.ReadinessCheck = func() domainhttp.ProbeResponse {
+		checks.Add(1)
+		return domainhttp.NewProbeResponse("ok", nil)
+	}
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithProbeHandlers(probes),
+		domainhttp.WithReadinessInitialDelay(100*time.Millisecond),
+	)
+	assert.NoError(t, err)
+
+	ready := func() (int, string) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/internal/ready", nil))
+		var got domainhttp.ProbeResponse
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		return w.Code, got.Status
+	}
+
+	// During the delay readiness reports starting without running checks
+	code, status := ready()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "starting", status)
+	assert.Equal(t, int32(0), checks.Load())
+
+	// Liveness is unaffected by the delay
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/internal/health", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// After the delay the real check runs
+	assert.Eventually(t, func() bool {
+		code, status := ready()
+		return code == http.StatusOK && status == "ok"
+	}, time.Second, 20*time.Millisecond)
+	assert.Positive(t, checks.Load())
+}
+
 func TestRouterMiddleware(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -147,6 +276,7 @@ func TestRouterMiddleware(t *testing.T) {
 	logger := mocklog.NewMockLogger(ctrl)
 	logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
 	logger.EXPECT().WithContext(gomock.Any()).Return(logger).AnyTimes()
+	logger.EXPECT().With(gomock.Any()).Return(logger).AnyTimes()
 
 	collector := mockmetrics.NewMockCollector(ctrl)
 	collector.EXPECT().CollectRequestMetrics(
@@ -180,6 +310,63 @@ func TestRouterMiddleware(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestRouterMetricsPathLabel(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []domainhttp.Option
+		path       string
+		wantLabel  string
+		wantStatus int
+	}{
+		{
+			name:       "matched route uses pattern",
+			path:       "/users/42",
+			wantLabel:  "/users/{id}",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unregistered path collapses to default label",
+			path:       "/wp-admin/setup.php",
+			wantLabel:  "unmatched",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unregistered path uses configured label",
+			opts:       []domainhttp.Option{domainhttp.WithUnmatchedPathLabel("other")},
+			path:       "/random",
+			wantLabel:  "other",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			collector := mockmetrics.NewMockCollector(ctrl)
+			collector.EXPECT().CollectRequestMetrics("GET", tt.wantLabel, tt.wantStatus, gomock.Any())
+
+			metricsFactory := mockmetrics.NewMockFactory(ctrl)
+			metricsFactory.EXPECT().NewCollector(gomock.Any()).Return(collector, nil)
+
+			opts := append([]domainhttp.Option{
+				domainhttp.WithService("test-service", "1.0"),
+				domainhttp.WithMetricsFactory(metricsFactory),
+			}, tt.opts...)
+			router, err := NewFactory().NewRouter(opts...)
+			assert.NoError(t, err)
+
+			router.(*Router).Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
 func TestRouterClose(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -209,6 +396,7 @@ func TestRouterObservabilityExclusions(t *testing.T) {
 	logger := mocklog.NewMockLogger(ctrl)
 	logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
 	logger.EXPECT().WithContext(gomock.Any()).Return(logger).MinTimes(1)
+	logger.EXPECT().With(gomock.Any()).Return(logger).MinTimes(1)
 
 	collector := mockmetrics.NewMockCollector(ctrl)
 	collector.EXPECT().CollectRequestMetrics(
@@ -263,3 +451,846 @@ func TestRouterObservabilityExclusions(t *testing.T) {
 		})
 	}
 }
+
+func TestRouterMaxRequestBodySize(t *testing.T) {
+	factory := NewFactory()
+	router, err := factory.NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithMaxRequestBodySize(16),
+	)
+	assert.NoError(t, err)
+
+	router.(*Router).Post("/upload", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64
+		wantStatus    int
+	}{
+		{
+			name:          "body within limit",
+			body:          "small",
+			contentLength: 5,
+			wantStatus:    http.StatusOK,
+		},
+		{
+			name:          "declared length exceeds limit",
+			body:          strings.Repeat("x", 64),
+			contentLength: 64,
+			wantStatus:    http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:          "undeclared length exceeds limit",
+			body:          strings.Repeat("x", 64),
+			contentLength: -1,
+			wantStatus:    http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/upload", strings.NewReader(tt.body))
+			req.ContentLength = tt.contentLength
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestRouterResponseHeaders(t *testing.T) {
+	factory := NewFactory()
+	router, err := factory.NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithResponseHeaders(map[string]string{
+			"Server":            "test-service",
+			"X-Service-Version": "1.0",
+		}),
+	)
+	assert.NoError(t, err)
+
+	router.(*Router).Get("/static", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router.(*Router).Get("/override", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "custom")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("static headers are set", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/static", nil))
+
+		assert.Equal(t, "test-service", w.Header().Get("Server"))
+		assert.Equal(t, "1.0", w.Header().Get("X-Service-Version"))
+	})
+
+	t.Run("handler value is not overwritten", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/override", nil))
+
+		assert.Equal(t, []string{"custom"}, w.Header().Values("Server"))
+		assert.Equal(t, "1.0", w.Header().Get("X-Service-Version"))
+	})
+
+	t.Run("unmatched routes carry the headers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/missing", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "test-service", w.Header().Get("Server"))
+	})
+}
+
+func TestRouterCategoryTimeouts(t *testing.T) {
+	order := []domainhttp.MiddlewareCategory{
+		domainhttp.CoreMiddleware,
+		domainhttp.SecurityMiddleware,
+		domainhttp.ApplicationMiddleware,
+		domainhttp.ObservabilityMiddleware,
+	}
+
+	tests := []struct {
+		name     string
+		timeouts map[domainhttp.MiddlewareCategory]time.Duration
+		want     time.Duration
+	}{
+		{
+			name: "default core timeout",
+			want: 30 * time.Second,
+		},
+		{
+			name: "core timeout replaces default",
+			timeouts: map[domainhttp.MiddlewareCategory]time.Duration{
+				domainhttp.CoreMiddleware: 10 * time.Second,
+			},
+			want: 10 * time.Second,
+		},
+		{
+			name: "shorter category timeout wins",
+			timeouts: map[domainhttp.MiddlewareCategory]time.Duration{
+				domainhttp.ApplicationMiddleware: 2 * time.Second,
+			},
+			want: 2 * time.Second,
+		},
+		{
+			name: "longer category timeout cannot extend core",
+			timeouts: map[domainhttp.MiddlewareCategory]time.Duration{
+				domainhttp.CoreMiddleware:        time.Second,
+				domainhttp.ApplicationMiddleware: time.Minute,
+			},
+			want: time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, err := NewFactory().NewRouter(
+				domainhttp.WithService("test-service", "1.0"),
+				domainhttp.WithMiddlewareOrdering(&domainhttp.MiddlewareOrdering{
+					Order:            order,
+					CategoryTimeouts: tt.timeouts,
+				}),
+			)
+			assert.NoError(t, err)
+
+			var remaining time.Duration
+			router.(*Router).Get("/test", func(w http.ResponseWriter, r *http.Request) {
+				deadline, ok := r.Context().Deadline()
+				assert.True(t, ok)
+				remaining = time.Until(deadline)
+				w.WriteHeader(http.StatusOK)
+			})
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/test", nil))
+
+			assert.LessOrEqual(t, remaining, tt.want)
+			assert.Greater(t, remaining, tt.want-time.Second/2)
+		})
+	}
+}
+
+func TestRouterMaxRequestDuration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := mocklog.NewMockLogger(ctrl)
+	logger.EXPECT().WithContext(gomock.Any()).Return(logger).AnyTimes()
+	logger.EXPECT().With(gomock.Any()).Return(logger).AnyTimes()
+	logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().WarnWith("Request exceeded maximum duration", gomock.Any()).Times(1)
+
+	factory := NewFactory()
+	router, err := factory.NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithLogger(logger),
+		domainhttp.WithMaxRequestDuration(50*time.Millisecond),
+	)
+	assert.NoError(t, err)
+
+	router.(*Router).Get("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(time.Second):
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/slow", nil)
+
+	start := time.Now()
+	router.ServeHTTP(w, req)
+	elapsed := time.Since(start)
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.Less(t, elapsed, 500*time.Millisecond)
+}
+
+func TestRouterAccessLogFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		fieldsFunc domainhttp.AccessLogFieldsFunc
+		validate   func(*testing.T, logging.Fields)
+	}{
+		{
+			name: "default fields",
+			validate: func(t *testing.T, fields logging.Fields) {
+				assert.Equal(t, "GET", fields["method"])
+				assert.Equal(t, "/test", fields["path"])
+				assert.Equal(t, http.StatusCreated, fields["status"])
+				assert.Equal(t, 5, fields["size"])
+				assert.Contains(t, fields, "duration")
+				assert.Contains(t, fields, "request_id")
+			},
+		},
+		{
+			name: "custom fields",
+			fieldsFunc: func(r *http.Request, resp domainhttp.ResponseInfo) logging.Fields {
+				return logging.Fields{
+					"path":       r.URL.Path,
+					"query":      r.URL.RawQuery,
+					"user_agent": r.UserAgent(),
+					"status":     resp.Status,
+				}
+			},
+			validate: func(t *testing.T, fields logging.Fields) {
+				assert.Equal(t, logging.Fields{
+					"path":       "/test",
+					"query":      "q=1",
+					"user_agent": "test-agent",
+					"status":     http.StatusCreated,
+				}, fields)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var logged logging.Fields
+			logger := mocklog.NewMockLogger(ctrl)
+			logger.EXPECT().WithContext(gomock.Any()).Return(logger)
+			logger.EXPECT().With(gomock.Any()).Return(logger)
+			logger.EXPECT().InfoWith("HTTP Request", gomock.Any()).
+				Do(func(_ string, fields logging.Fields) { logged = fields })
+
+			opts := []domainhttp.Option{
+				domainhttp.WithService("test-service", "1.0"),
+				domainhttp.WithLogger(logger),
+			}
+			if tt.fieldsFunc != nil {
+				opts = append(opts, domainhttp.WithAccessLogFields(tt.fieldsFunc))
+			}
+
+			router, err := NewFactory().NewRouter(opts...)
+			assert.NoError(t, err)
+
+			router.(*Router).Get("/test", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte("hello"))
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/test?q=1", nil)
+			req.Header.Set("User-Agent", "test-agent")
+			router.ServeHTTP(w, req)
+
+			tt.validate(t, logged)
+		})
+	}
+}
+
+func TestRouterPprof(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		path       string
+		wantStatus int
+	}{
+		{
+			name:       "disabled by default",
+			path:       "/internal/debug/pprof/",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "index when enabled",
+			enabled:    true,
+			path:       "/internal/debug/pprof/",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "named profile when enabled",
+			enabled:    true,
+			path:       "/internal/debug/pprof/goroutine?debug=1",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "cmdline when enabled",
+			enabled:    true,
+			path:       "/internal/debug/pprof/cmdline",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []domainhttp.Option{
+				domainhttp.WithService("test-service", "1.0"),
+			}
+			if tt.enabled {
+				opts = append(opts, domainhttp.WithPprof(true))
+			}
+
+			router, err := NewFactory().NewRouter(opts...)
+			assert.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestRouterInternalRouter(t *testing.T) {
+	internal := chi.NewRouter()
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithInternalRouter(internal),
+	)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		wantStatus int
+	}{
+		{
+			name:       "probes served by internal router",
+			handler:    internal,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "probes not served by main router",
+			handler:    router,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/internal/health", nil)
+			tt.handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestRouterUserAgentMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithMetricsFactory(adaptermetrics.NewMetricsFactoryWithRegistry(registry)),
+		domainhttp.WithUserAgentMetrics(nil),
+	)
+	assert.NoError(t, err)
+	defer router.(*Router).Close(context.Background())
+
+	router.(*Router).Get("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, ua := range []string{"curl/8.4.0", "curl/7.88.1", "Mozilla/5.0 (X11; Linux x86_64) Firefox/120.0"} {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set("User-Agent", ua)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Contains(t, w.Body.String(), `http_requests_by_client{client="cli",service="test-service",version="1.0"} 2`)
+	assert.Contains(t, w.Body.String(), `http_requests_by_client{client="browser",service="test-service",version="1.0"} 1`)
+}
+
+func TestRouterMetricsPath(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithMetricsFactory(adaptermetrics.NewMetricsFactoryWithRegistry(registry)),
+		domainhttp.WithMetricsPath("/prom"),
+		domainhttp.WithObservabilityExclusions([]string{"/internal/*"}, []string{"/internal/*"}),
+	)
+	assert.NoError(t, err)
+	defer router.(*Router).Close(context.Background())
+
+	// Metrics path is excluded from observability automatically
+	assert.Contains(t, router.(*Router).opts.ExcludeFromLogging, "/prom")
+	assert.Contains(t, router.(*Router).opts.ExcludeFromTracing, "/prom")
+
+	router.(*Router).Get("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/test", nil))
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "custom path serves collector registry",
+			path:       "/prom",
+			wantStatus: http.StatusOK,
+			wantBody:   `http_requests_total{method="GET",path="/test",service="test-service",status="200",version="1.0"} 1`,
+		},
+		{
+			name:       "default path not served",
+			path:       "/metrics",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.Contains(t, w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRouterOpenMetrics(t *testing.T) {
+	const openMetricsAccept = "application/openmetrics-text; version=1.0.0"
+
+	tests := []struct {
+		name            string
+		opts            []domainhttp.Option
+		accept          string
+		wantContentType string
+	}{
+		{
+			name:            "negotiated by default",
+			accept:          openMetricsAccept,
+			wantContentType: "application/openmetrics-text",
+		},
+		{
+			name:            "classic format without negotiation",
+			wantContentType: "text/plain",
+		},
+		{
+			name:            "classic format when disabled",
+			opts:            []domainhttp.Option{domainhttp.WithOpenMetrics(false)},
+			accept:          openMetricsAccept,
+			wantContentType: "text/plain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]domainhttp.Option{
+				domainhttp.WithService("test-service", "1.0"),
+				domainhttp.WithMetricsFactory(adaptermetrics.NewMetricsFactoryWithRegistry(prometheus.NewRegistry())),
+			}, tt.opts...)
+			router, err := NewFactory().NewRouter(opts...)
+			assert.NoError(t, err)
+			defer router.(*Router).Close(context.Background())
+
+			req := httptest.NewRequest("GET", "/metrics", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), tt.wantContentType),
+				"content type %q", w.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func TestRouterMetricsProvider(t *testing.T) {
+	withMetrics, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithMetricsFactory(adaptermetrics.NewMetricsFactoryWithRegistry(prometheus.NewRegistry())),
+	)
+	assert.NoError(t, err)
+	defer withMetrics.(*Router).Close(context.Background())
+
+	provider, ok := withMetrics.(domainhttp.MetricsProvider)
+	assert.True(t, ok)
+	assert.NotNil(t, provider.Metrics())
+
+	withoutMetrics, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+	)
+	assert.NoError(t, err)
+	assert.Nil(t, withoutMetrics.(domainhttp.MetricsProvider).Metrics())
+}
+
+type testTenantKey struct{}
+
+func TestRouterLoggedContextKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var logged logging.Fields
+	logger := mocklog.NewMockLogger(ctrl)
+	logger.EXPECT().WithContext(gomock.Any()).Return(logger)
+	logger.EXPECT().With(gomock.Any()).Return(logger)
+	logger.EXPECT().InfoWith("HTTP Request", gomock.Any()).
+		Do(func(_ string, fields logging.Fields) { logged = fields })
+
+	// Upstream security middleware seeds the request context
+	seedContext := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), testTenantKey{}, "acme")
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithLogger(logger),
+		domainhttp.WithMiddlewareOrdering(&domainhttp.MiddlewareOrdering{
+			Order: []domainhttp.MiddlewareCategory{
+				domainhttp.CoreMiddleware,
+				domainhttp.SecurityMiddleware,
+				domainhttp.ApplicationMiddleware,
+				domainhttp.ObservabilityMiddleware,
+			},
+			CustomMiddleware: map[domainhttp.MiddlewareCategory][]func(http.Handler) http.Handler{
+				domainhttp.SecurityMiddleware: {seedContext},
+			},
+		}),
+		domainhttp.WithLoggedContextKeys(map[string]interface{}{
+			"tenant":  testTenantKey{},
+			"subject": "auth-subject",
+		}),
+	)
+	assert.NoError(t, err)
+
+	router.(*Router).Get("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/test", nil))
+
+	assert.Equal(t, "acme", logged["tenant"])
+	assert.NotContains(t, logged, "subject", "absent context values are not logged")
+	assert.Equal(t, "/test", logged["path"])
+}
+
+func TestRouterRequestLogger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	contextLogger := mocklog.NewMockLogger(ctrl)
+	requestLogger := mocklog.NewMockLogger(ctrl)
+	logger := mocklog.NewMockLogger(ctrl)
+	logger.EXPECT().WithContext(gomock.Any()).Return(contextLogger)
+
+	var requestID string
+	contextLogger.EXPECT().With(gomock.Any()).
+		DoAndReturn(func(fields logging.Fields) logging.Logger {
+			requestID, _ = fields["request_id"].(string)
+			return requestLogger
+		})
+	contextLogger.EXPECT().InfoWith("HTTP Request", gomock.Any())
+	requestLogger.EXPECT().Info("handling")
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithLogger(logger),
+		domainhttp.WithObservabilityExclusions([]string{"/internal/*"}, nil),
+	)
+	assert.NoError(t, err)
+
+	router.(*Router).Get("/test", func(w http.ResponseWriter, r *http.Request) {
+		LoggerFrom(r.Context()).Info("handling")
+		w.WriteHeader(http.StatusOK)
+	})
+	router.(*Router).Get("/internal/status", func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, LoggerFrom(r.Context()), "excluded paths have no request logger")
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/test", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/internal/status", nil))
+
+	assert.NotEmpty(t, requestID)
+}
+
+func TestRouterRouteViewer(t *testing.T) {
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithRouteViewer(true),
+	)
+	assert.NoError(t, err)
+
+	// Registered after creation, so only found by walking at request time
+	router.(*Router).Post("/api/items", func(w http.ResponseWriter, r *http.Request) {})
+	router.(*Router).Get("/api/items/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/internal/routes", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var routes []routeInfo
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&routes))
+	assert.Contains(t, routes, routeInfo{Method: "POST", Pattern: "/api/items"})
+	assert.Contains(t, routes, routeInfo{Method: "GET", Pattern: "/api/items/{id}"})
+	assert.Contains(t, routes, routeInfo{Method: "GET", Pattern: "/internal/routes"})
+	assert.Equal(t, routeInfo{Method: "POST", Pattern: "/api/items"}, routes[0], "routes are sorted by pattern")
+
+	disabled, err := NewFactory().NewRouter(domainhttp.WithService("test-service", "1.0"))
+	assert.NoError(t, err)
+	rec = httptest.NewRecorder()
+	disabled.ServeHTTP(rec, httptest.NewRequest("GET", "/internal/routes", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRouterContextValues(t *testing.T) {
+	type regionKey struct{}
+	type buildKey struct{}
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithContextValues(map[interface{}]interface{}{regionKey{}: "eu-west-1"}),
+		domainhttp.WithContextValues(map[interface{}]interface{}{buildKey{}: "abc123"}),
+	)
+	assert.NoError(t, err)
+
+	router.(*Router).Get("/test", func(w http.ResponseWriter, r *http.Request) {
+		region, _ := r.Context().Value(regionKey{}).(string)
+		build, _ := r.Context().Value(buildKey{}).(string)
+		_, _ = w.Write([]byte(region + " " + build))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/test", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "eu-west-1 abc123", w.Body.String())
+}
+
+func TestRouterSharedMetricsRegistry(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	newRouter := func() (*Router, error) {
+		router, err := NewFactory().NewRouter(
+			domainhttp.WithService("test-service", "1.0"),
+			domainhttp.WithMetricsFactory(adaptermetrics.NewMetricsFactoryWithRegistry(registry)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return router.(*Router), nil
+	}
+
+	// Two routers in one process share the registered HTTP metrics
+	first, err := newRouter()
+	assert.NoError(t, err)
+	defer first.Close(context.Background())
+	second, err := newRouter()
+	assert.NoError(t, err, "a second router does not panic or fail on registration")
+	defer second.Close(context.Background())
+
+	for _, router := range []*Router{first, second} {
+		router.Get("/test", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/test", nil))
+	}
+
+	w := httptest.NewRecorder()
+	first.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Contains(t, w.Body.String(),
+		`http_requests_total{method="GET",path="/test",service="test-service",status="200",version="1.0"} 2`)
+
+	// An incompatible metric of the same name is reported, not panicked on
+	conflicting := prometheus.NewRegistry()
+	conflicting.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "http_request_duration_seconds",
+		Help: "Conflicting metric",
+	}))
+	_, err = NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithMetricsFactory(adaptermetrics.NewMetricsFactoryWithRegistry(conflicting)),
+	)
+	assert.Error(t, err)
+}
+
+func TestRouterRegistersOneSetOfHTTPMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithMetricsFactory(adaptermetrics.NewMetricsFactoryWithRegistry(registry)),
+	)
+	assert.NoError(t, err)
+	defer router.(*Router).Close(context.Background())
+
+	router.(*Router).Get("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/test", nil))
+
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+
+	// HTTP metrics come only from the metrics.Collector
+	var names []string
+	for _, family := range families {
+		if strings.HasPrefix(family.GetName(), "http_") {
+			names = append(names, family.GetName())
+		}
+	}
+	assert.Equal(t, []string{
+		"http_errors_total",
+		"http_request_duration_seconds",
+		"http_requests_total",
+	}, names)
+}
+
+func TestRouterSpanNames(t *testing.T) {
+	// otelhttp records spans on the global provider
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	tests := []struct {
+		name    string
+		options []domainhttp.Option
+		path    string
+		want    string
+	}{
+		{
+			name: "route pattern by default",
+			path: "/users/42",
+			want: "GET /users/{id}",
+		},
+		{
+			name: "unmatched path",
+			path: "/wp-login.php",
+			want: "GET unmatched",
+		},
+		{
+			name: "custom formatter",
+			options: []domainhttp.Option{
+				domainhttp.WithSpanNameFormatter(func(req *http.Request) string {
+					return "test-service " + chi.RouteContext(req.Context()).RoutePattern()
+				}),
+			},
+			path: "/users/42",
+			want: "test-service /users/{id}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.Reset()
+			ctrl := gomock.NewController(t)
+
+			opts := append([]domainhttp.Option{
+				domainhttp.WithService("test-service", "1.0"),
+				domainhttp.WithTracingProvider(mocktracing.NewMockProvider(ctrl)),
+			}, tt.options...)
+			router, err := NewFactory().NewRouter(opts...)
+			require.NoError(t, err)
+			router.(*Router).Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", tt.path, nil))
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.want, spans[0].Name())
+		})
+	}
+}
+
+func TestRouterSpanAttributes(t *testing.T) {
+	// otelhttp records spans on the global provider
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctrl := gomock.NewController(t)
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithTracingProvider(mocktracing.NewMockProvider(ctrl)),
+		domainhttp.WithSpanAttributes(func(req *http.Request) []attribute.KeyValue {
+			return []attribute.KeyValue{attribute.String("tenant", req.Header.Get("X-Tenant"))}
+		}),
+	)
+	require.NoError(t, err)
+	router.(*Router).Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello"))
+	})
+
+	req := httptest.NewRequest("GET", "/users/42", nil)
+	req.Header.Set("X-Request-Id", "req-123")
+	req.Header.Set("X-Tenant", "acme")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range spans[0].Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	assert.Equal(t, "/users/{id}", attrs["http.route"].AsString())
+	assert.Equal(t, "req-123", attrs["http.request_id"].AsString())
+	assert.Equal(t, int64(5), attrs["http.response_content_length"].AsInt64())
+	assert.Equal(t, "acme", attrs["tenant"].AsString())
+}
@@ -0,0 +1,213 @@
+package http
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
+)
+
+// isProbePath reports whether the path is one of the health probe
+// endpoints, which are exempt from request-rejecting security middleware
+// so Kubernetes can always reach them
+func (r *Router) isProbePath(path string) bool {
+	for _, p := range r.opts.ProbePaths.All() {
+		if path == p {
+			return true
+		}
+	}
+	return false
+}
+
+// isBuiltinPath reports whether the path is served by the router itself:
+// probes, metrics and the /internal endpoints. Application-level access
+// controls such as JWT auth and CSRF protection exempt these paths so
+// built-in features keep working when they are enabled.
+func (r *Router) isBuiltinPath(path string) bool {
+	return r.isProbePath(path) || hasPathPrefix(path, []string{"/internal", r.opts.MetricsPath})
+}
+
+// requireHTTPSMiddleware redirects or rejects plaintext requests.
+// It runs ahead of RealIP so the peer address is that of the connection,
+// which is what trusted proxies are matched against.
+func (r *Router) requireHTTPSMiddleware() func(http.Handler) http.Handler {
+	mode := r.opts.RequireHTTPS
+	trusted := parseTrustedProxies(r.opts.TrustedProxies)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if r.isProbePath(req.URL.Path) || isHTTPS(req, trusted) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			if mode == domainhttp.HTTPSRedirect {
+				target := "https://" + req.Host + req.URL.RequestURI()
+				http.Redirect(w, req, target, http.StatusPermanentRedirect)
+				return
+			}
+
+			http.Error(w, "HTTPS required", http.StatusBadRequest)
+		})
+	}
+}
+
+// isHTTPS reports whether the request arrived over TLS, either directly or
+// via a trusted proxy that set X-Forwarded-Proto
+func isHTTPS(req *http.Request, trusted []*net.IPNet) bool {
+	if req.TLS != nil {
+		return true
+	}
+
+	proto := req.Header.Get("X-Forwarded-Proto")
+	if proto == "" {
+		return false
+	}
+	if len(trusted) > 0 && !fromTrustedProxy(req.RemoteAddr, trusted) {
+		return false
+	}
+
+	// The header may hold a comma separated list when proxies are chained
+	first, _, _ := strings.Cut(proto, ",")
+	return strings.EqualFold(strings.TrimSpace(first), "https")
+}
+
+// fromTrustedProxy reports whether the remote address falls within the trusted networks
+func fromTrustedProxy(remoteAddr string, trusted []*net.IPNet) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies converts IP and CIDR strings into networks.
+// Entries are validated by the domain option, so invalid values are skipped.
+func parseTrustedProxies(proxies []string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			networks = append(networks, network)
+			continue
+		}
+		ip := net.ParseIP(proxy)
+		if ip == nil {
+			continue
+		}
+		bits := 8 * net.IPv4len
+		if ip.To4() == nil {
+			bits = 8 * net.IPv6len
+		}
+		networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return networks
+}
+
+// allowedHostsMiddleware rejects requests whose Host is not allowed
+func (r *Router) allowedHostsMiddleware() func(http.Handler) http.Handler {
+	allowed := make([]string, len(r.opts.AllowedHosts))
+	for i, host := range r.opts.AllowedHosts {
+		allowed[i] = strings.ToLower(host)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if r.isProbePath(req.URL.Path) || hostAllowed(req.Host, allowed) {
+				next.ServeHTTP(w, req)
+				return
+			}
+			http.Error(w, "Invalid host", http.StatusBadRequest)
+		})
+	}
+}
+
+// hostAllowed reports whether host, ignoring any port, matches one of the
+// lower-cased patterns. A "*." prefix matches any subdomain.
+func hostAllowed(host string, allowed []string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	for _, pattern := range allowed {
+		if suffix, ok := strings.CutPrefix(pattern, "*"); ok {
+			if strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+				return true
+			}
+			continue
+		}
+		if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// basicAuthMiddleware requires basic auth credentials for the configured
+// path prefixes, exempting health probes
+func (r *Router) basicAuthMiddleware() func(http.Handler) http.Handler {
+	auth := r.opts.BasicAuth
+	paths := auth.Paths
+	if len(paths) == 0 {
+		paths = []string{"/internal", r.opts.MetricsPath}
+	}
+	challenge := fmt.Sprintf("Basic realm=%q, charset=\"UTF-8\"", auth.Realm)
+
+	// Passwords are compared as digests so their length is not leaked
+	digests := make(map[string][sha256.Size]byte, len(auth.Credentials))
+	for user, password := range auth.Credentials {
+		digests[user] = sha256.Sum256([]byte(password))
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if r.isProbePath(req.URL.Path) || !hasPathPrefix(req.URL.Path, paths) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			user, password, ok := req.BasicAuth()
+			if ok && validCredentials(digests, user, password) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			w.Header().Set("WWW-Authenticate", challenge)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		})
+	}
+}
+
+// validCredentials reports whether password matches the user's digest,
+// comparing in constant time whether or not the user exists
+func validCredentials(digests map[string][sha256.Size]byte, user, password string) bool {
+	expected, known := digests[user]
+	actual := sha256.Sum256([]byte(password))
+	match := subtle.ConstantTimeCompare(actual[:], expected[:]) == 1
+	return known && match
+}
+
+// hasPathPrefix reports whether path equals one of the prefixes or lies
+// beneath it
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if prefix == "" || path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
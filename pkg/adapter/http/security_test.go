@@ -0,0 +1,414 @@
+// pkg/adapter/http/security_test.go
+package http
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+
+	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
+)
+
+func TestRouterRequireHTTPS(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           domainhttp.HTTPSMode
+		trustedProxies []string
+		path           string
+		remoteAddr     string
+		forwardedProto string
+		tls            bool
+		wantStatus     int
+		wantLocation   string
+	}{
+		{
+			name:         "plaintext request redirected",
+			mode:         domainhttp.HTTPSRedirect,
+			path:         "/test?q=1",
+			wantStatus:   http.StatusPermanentRedirect,
+			wantLocation: "https://example.com/test?q=1",
+		},
+		{
+			name:       "plaintext request rejected",
+			mode:       domainhttp.HTTPSReject,
+			path:       "/test",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "forwarded https passes through",
+			mode:           domainhttp.HTTPSRedirect,
+			path:           "/test",
+			forwardedProto: "https",
+			wantStatus:     http.StatusOK,
+		},
+		{
+			name:       "direct tls passes through",
+			mode:       domainhttp.HTTPSReject,
+			path:       "/test",
+			tls:        true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:           "forwarded https from trusted proxy passes through",
+			mode:           domainhttp.HTTPSReject,
+			trustedProxies: []string{"10.0.0.0/8"},
+			path:           "/test",
+			remoteAddr:     "10.1.2.3:4567",
+			forwardedProto: "https",
+			wantStatus:     http.StatusOK,
+		},
+		{
+			name:           "forwarded https from untrusted peer rejected",
+			mode:           domainhttp.HTTPSReject,
+			trustedProxies: []string{"10.0.0.1"},
+			path:           "/test",
+			remoteAddr:     "192.168.1.1:4567",
+			forwardedProto: "https",
+			wantStatus:     http.StatusBadRequest,
+		},
+		{
+			name:       "probe paths are exempt",
+			mode:       domainhttp.HTTPSReject,
+			path:       "/internal/health",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, err := NewFactory().NewRouter(
+				domainhttp.WithService("test-service", "1.0"),
+				domainhttp.WithRequireHTTPS(tt.mode, tt.trustedProxies...),
+			)
+			assert.NoError(t, err)
+
+			router.(*Router).Get("/test", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "http://example.com"+tt.path, nil)
+			if tt.remoteAddr != "" {
+				req.RemoteAddr = tt.remoteAddr
+			}
+			if tt.forwardedProto != "" {
+				req.Header.Set("X-Forwarded-Proto", tt.forwardedProto)
+			}
+			if tt.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantLocation != "" {
+				assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestRouterAllowedHosts(t *testing.T) {
+	tests := []struct {
+		name       string
+		host       string
+		path       string
+		wantStatus int
+	}{
+		{
+			name:       "allowed host",
+			host:       "example.com",
+			path:       "/test",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "allowed host with port and different case",
+			host:       "Example.COM:8080",
+			path:       "/test",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "disallowed host rejected",
+			host:       "evil.com",
+			path:       "/test",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "wildcard matches subdomain",
+			host:       "api.internal.example.org",
+			path:       "/test",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "wildcard does not match apex",
+			host:       "example.org",
+			path:       "/test",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "probe paths are exempt",
+			host:       "10.0.0.12:8080",
+			path:       "/internal/ready",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, err := NewFactory().NewRouter(
+				domainhttp.WithService("test-service", "1.0"),
+				domainhttp.WithAllowedHosts([]string{"example.com", "*.example.org"}),
+			)
+			assert.NoError(t, err)
+
+			router.(*Router).Get("/test", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Host = tt.host
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestRouterRequireHTTPSRedirectChecksAllowedHosts(t *testing.T) {
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithRequireHTTPS(domainhttp.HTTPSRedirect),
+		domainhttp.WithAllowedHosts([]string{"example.com"}),
+	)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		host         string
+		wantStatus   int
+		wantLocation string
+	}{
+		{
+			name:         "allowed host redirected",
+			host:         "example.com",
+			wantStatus:   http.StatusPermanentRedirect,
+			wantLocation: "https://example.com/test",
+		},
+		{
+			name:       "disallowed host rejected without redirect",
+			host:       "evil.com",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.Host = tt.host
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestRouterSecurityHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []domainhttp.Option
+		tls     bool
+		want    map[string]string
+		missing []string
+	}{
+		{
+			name: "basic headers without option",
+			want: map[string]string{
+				"X-Content-Type-Options": "nosniff",
+				"X-Frame-Options":        "DENY",
+				"X-XSS-Protection":       "1; mode=block",
+			},
+			missing: []string{"Content-Security-Policy", "Referrer-Policy", "Strict-Transport-Security"},
+		},
+		{
+			name: "defaults over plaintext omit HSTS",
+			opts: []domainhttp.Option{domainhttp.WithSecurityHeaders(domainhttp.SecurityHeaderOptions{})},
+			want: map[string]string{
+				"X-Content-Type-Options":  "nosniff",
+				"X-Frame-Options":         "DENY",
+				"Content-Security-Policy": "default-src 'self'; frame-ancestors 'none'",
+				"Referrer-Policy":         "no-referrer",
+			},
+			missing: []string{"Strict-Transport-Security"},
+		},
+		{
+			name: "custom headers over tls",
+			opts: []domainhttp.Option{domainhttp.WithSecurityHeaders(domainhttp.SecurityHeaderOptions{
+				FrameOptions:          "SAMEORIGIN",
+				HSTSMaxAge:            time.Hour,
+				HSTSIncludeSubdomains: true,
+				ContentSecurityPolicy: "default-src 'none'",
+				ReferrerPolicy:        "same-origin",
+			})},
+			tls: true,
+			want: map[string]string{
+				"X-Frame-Options":           "SAMEORIGIN",
+				"Content-Security-Policy":   "default-src 'none'",
+				"Referrer-Policy":           "same-origin",
+				"Strict-Transport-Security": "max-age=3600; includeSubDomains",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]domainhttp.Option{domainhttp.WithService("test-service", "1.0")}, tt.opts...)
+			router, err := NewFactory().NewRouter(opts...)
+			assert.NoError(t, err)
+			router.(*Router).Get("/test", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", "/test", nil)
+			if tt.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			for name, value := range tt.want {
+				assert.Equal(t, value, rec.Header().Get(name), name)
+			}
+			for _, name := range tt.missing {
+				assert.Empty(t, rec.Header().Get(name), name)
+			}
+		})
+	}
+}
+
+func TestRouterBasicAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		paths      []string
+		path       string
+		user       string
+		password   string
+		wantStatus int
+	}{
+		{
+			name:       "internal endpoint without credentials",
+			path:       "/internal/config",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "metrics without credentials",
+			path:       "/metrics",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong password",
+			path:       "/internal/config",
+			user:       "admin",
+			password:   "wrong",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown user",
+			path:       "/internal/config",
+			user:       "guest",
+			password:   "secret",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "valid credentials",
+			path:       "/internal/config",
+			user:       "admin",
+			password:   "secret",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "health probe exempt",
+			path:       "/internal/health",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "application route unprotected",
+			path:       "/api/items",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "custom prefix protected",
+			paths:      []string{"/api"},
+			path:       "/api/items",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "custom prefix leaves internal endpoints open",
+			paths:      []string{"/api"},
+			path:       "/internal/config",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "prefix does not match partial segment",
+			paths:      []string{"/api"},
+			path:       "/apiary",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, err := NewFactory().NewRouter(
+				domainhttp.WithService("test-service", "1.0"),
+				domainhttp.WithBasicAuth("internal", map[string]string{"admin": "secret"}, tt.paths...),
+			)
+			assert.NoError(t, err)
+			ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+			for _, path := range []string{"/internal/config", "/metrics", "/api/items", "/apiary"} {
+				router.(*Router).Get(path, ok)
+			}
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.user != "" {
+				req.SetBasicAuth(tt.user, tt.password)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, `Basic realm="internal", charset="UTF-8"`, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestRouterBasicAuthInternalRouter(t *testing.T) {
+	internal := chi.NewRouter()
+	_, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithInternalRouter(internal),
+		domainhttp.WithBasicAuth("internal", map[string]string{"admin": "secret"}),
+	)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	internal.ServeHTTP(rec, httptest.NewRequest("GET", "/internal/startup", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, "probes stay open")
+
+	req := httptest.NewRequest("GET", "/internal/debug", nil)
+	rec = httptest.NewRecorder()
+	internal.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req.SetBasicAuth("admin", "secret")
+	rec = httptest.NewRecorder()
+	internal.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
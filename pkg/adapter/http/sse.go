@@ -0,0 +1,163 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SSEWriter streams Server-Sent Events to a client. It sets the event
+// stream headers, flushes each event, sends comment heartbeats to keep
+// idle connections open and stops when the client disconnects.
+//
+// Events are written through the ResponseWriter handed to the handler, so
+// the router's wrappers record a 200 status for metrics and access logs.
+// Streams end at the router's request timeout, so long-lived streams need
+// a raised Core category timeout.
+//
+// A typical handler:
+//
+//	func events(w http.ResponseWriter, r *http.Request) {
+//		sse, err := httpadapter.NewSSEWriter(w, r, 15*time.Second)
+//		if err != nil {
+//			return
+//		}
+//		defer sse.Close()
+//
+//		for {
+//			select {
+//			case update := <-updates:
+//				if err := sse.Send("update", update); err != nil {
+//					return
+//				}
+//			case <-sse.Done():
+//				return
+//			}
+//		}
+//	}
+type SSEWriter struct {
+	w   http.ResponseWriter
+	rc  *http.ResponseController
+	ctx context.Context
+
+	mu     sync.Mutex // Serializes events and heartbeats
+	closed bool
+
+	stop    chan struct{}
+	stopped sync.WaitGroup
+}
+
+// NewSSEWriter starts an event stream on w. A positive heartbeat sends a
+// comment line at that interval while no events are sent. The server's
+// write timeout is lifted for the stream. It returns an error when w
+// cannot be flushed; the 200 status has been written by then, so the
+// handler should just return.
+func NewSSEWriter(w http.ResponseWriter, req *http.Request, heartbeat time.Duration) (*SSEWriter, error) {
+	rc := http.NewResponseController(w)
+
+	// Lift the server write timeout, which would otherwise end the stream
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return nil, fmt.Errorf("clearing write deadline: %w", err)
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no") // Disable proxy buffering, e.g. nginx
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return nil, fmt.Errorf("response does not support streaming: %w", err)
+	}
+
+	s := &SSEWriter{
+		w:    w,
+		rc:   rc,
+		ctx:  req.Context(),
+		stop: make(chan struct{}),
+	}
+	if heartbeat > 0 {
+		s.stopped.Add(1)
+		go s.heartbeat(heartbeat)
+	}
+	return s, nil
+}
+
+// Send writes an event and flushes it to the client. An empty event name
+// sends an unnamed message event. Multi-line data is split across data
+// fields. It returns the context's error once the client has gone.
+func (s *SSEWriter) Send(event, data string) error {
+	var b strings.Builder
+	if event != "" {
+		b.WriteString("event: " + event + "\n")
+	}
+	for _, line := range strings.Split(data, "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+	return s.write(b.String())
+}
+
+// Done is closed when the client disconnects or the request is cancelled
+func (s *SSEWriter) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+// Close stops the heartbeat. It must be called before the handler returns,
+// after which the writer must not be used.
+func (s *SSEWriter) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	close(s.stop)
+	s.stopped.Wait()
+}
+
+// heartbeat sends a comment line every interval until stopped or the
+// client disconnects
+func (s *SSEWriter) heartbeat(interval time.Duration) {
+	defer s.stopped.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		case <-s.stop:
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// write sends raw stream data and flushes it
+func (s *SSEWriter) write(data string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("event stream closed")
+	}
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if _, err := s.w.Write([]byte(data)); err != nil {
+		return fmt.Errorf("writing event: %w", err)
+	}
+	if err := s.rc.Flush(); err != nil {
+		return fmt.Errorf("flushing event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// pkg/adapter/http/sse_test.go
+package http
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
+	mockmetrics "github.com/damianoneill/go-bootstrap/pkg/domain/metrics/mocks"
+)
+
+func TestSSEWriterThroughRouter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recorded := make(chan int, 1)
+	collector := mockmetrics.NewMockCollector(ctrl)
+	collector.EXPECT().CollectRequestMetrics("GET", "/events", gomock.Any(), gomock.Any()).
+		Do(func(_, _ string, status int, _ float64) { recorded <- status })
+
+	metricsFactory := mockmetrics.NewMockFactory(ctrl)
+	metricsFactory.EXPECT().NewCollector(gomock.Any()).Return(collector, nil)
+
+	router, err := NewFactory().NewRouter(
+		domainhttp.WithService("test-service", "1.0"),
+		domainhttp.WithMetricsFactory(metricsFactory),
+	)
+	require.NoError(t, err)
+
+	disconnected := make(chan struct{})
+	router.(*Router).Get("/events", func(w http.ResponseWriter, r *http.Request) {
+		sse, err := NewSSEWriter(w, r, 10*time.Millisecond)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer sse.Close()
+
+		assert.NoError(t, sse.Send("greeting", "hello"))
+		assert.NoError(t, sse.Send("", "line one\nline two"))
+
+		<-sse.Done()
+		assert.Error(t, sse.Send("late", "gone"), "sends fail once the client has gone")
+		close(disconnected)
+	})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/events")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	// Read both events and at least one heartbeat as they are flushed
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for !containsLine(lines, ": heartbeat") {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+	assert.Equal(t, []string{
+		"event: greeting",
+		"data: hello",
+		"",
+		"data: line one",
+		"data: line two",
+		"",
+	}, lines[:6])
+
+	// Disconnecting ends the handler, which still records its status
+	resp.Body.Close()
+	select {
+	case <-disconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler did not observe the disconnect")
+	}
+	select {
+	case status := <-recorded:
+		assert.Equal(t, http.StatusOK, status)
+	case <-time.After(5 * time.Second):
+		t.Fatal("request metrics were not recorded")
+	}
+}
+
+func TestSSEWriterClose(t *testing.T) {
+	rec := httptest.NewRecorder()
+	sse, err := NewSSEWriter(rec, httptest.NewRequest("GET", "/events", nil), time.Millisecond)
+	require.NoError(t, err)
+
+	require.NoError(t, sse.Send("update", "1"))
+	sse.Close()
+	sse.Close()
+
+	assert.Error(t, sse.Send("update", "2"))
+	assert.True(t, rec.Flushed)
+	assert.NotContains(t, rec.Body.String(), "data: 2")
+}
+
+// plainWriter is a ResponseWriter that cannot flush
+type plainWriter struct {
+	http.ResponseWriter
+}
+
+func TestSSEWriterRequiresFlusher(t *testing.T) {
+	w := plainWriter{httptest.NewRecorder()}
+	_, err := NewSSEWriter(w, httptest.NewRequest("GET", "/events", nil), 0)
+	assert.ErrorContains(t, err, "response does not support streaming")
+}
+
+func containsLine(lines []string, want string) bool {
+	for _, line := range lines {
+		if line == want {
+			return true
+		}
+	}
+	return false
+}
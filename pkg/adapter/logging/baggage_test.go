@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+func contextWithBaggage(t *testing.T, members map[string]string) context.Context {
+	t.Helper()
+
+	var list []baggage.Member
+	for k, v := range members {
+		m, err := baggage.NewMember(k, v)
+		require.NoError(t, err)
+		list = append(list, m)
+	}
+	bag, err := baggage.New(list...)
+	require.NoError(t, err)
+	return baggage.ContextWithBaggage(context.Background(), bag)
+}
+
+func TestZapLogger_WithContextBaggage(t *testing.T) {
+	logger, obs := newTestLogger(t)
+	logger.baggageKeys = []string{"tenant.id", "user.token", "region"}
+	logger.redactKeys = []string{"token"}
+
+	ctx := contextWithBaggage(t, map[string]string{
+		"tenant.id":  "acme",
+		"user.token": "abc",
+		"other":      "ignored",
+	})
+	logger.WithContext(ctx).Info("with baggage")
+	logger.WithContext(context.Background()).Info("without baggage")
+
+	logs := obs.TakeAll()
+	require.Len(t, logs, 2)
+	fields := logs[0].ContextMap()
+	assert.Equal(t, "acme", fields["tenant.id"])
+	assert.Equal(t, redactedValue, fields["user.token"])
+	assert.NotContains(t, fields, "region")
+	assert.NotContains(t, fields, "other")
+	assert.Empty(t, logs[1].ContextMap())
+}
+
+func TestFactory_WithBaggageKeys(t *testing.T) {
+	logger, err := NewFactory().NewLoggerWithOptions(
+		[]domainlog.Option{
+			domainlog.WithLogBuffer(10),
+			domainlog.WithCorrelationIDFunc(func(context.Context) string { return "req-1" }),
+		},
+		[]ZapOption{WithBaggageKeys([]string{"tenant.id"})},
+	)
+	require.NoError(t, err)
+
+	ctx := contextWithBaggage(t, map[string]string{"tenant.id": "acme"})
+	logger.WithContext(ctx).Info("handled")
+
+	entries := logger.(*ZapLogger).buffer.snapshot()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "acme", entries[0].Fields["tenant.id"])
+	assert.Equal(t, "req-1", entries[0].Fields["correlation_id"])
+}
+
+func TestWithBaggageKeys_EmptyKey(t *testing.T) {
+	var zopts ZapOptions
+	assert.EqualError(t, WithBaggageKeys([]string{""}).ApplyOption(&zopts), "baggage key cannot be empty")
+}
@@ -0,0 +1,129 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// bufferedEntry is a log entry retained in the in-memory buffer
+type bufferedEntry struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Level     string                 `json:"level"`
+	Message   string                 `json:"message"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+}
+
+// logBuffer is a fixed-size ring of the most recent log entries.
+// It is safe for concurrent use.
+type logBuffer struct {
+	mu      sync.Mutex
+	entries []bufferedEntry
+	next    int
+	full    bool
+}
+
+func newLogBuffer(size int) *logBuffer {
+	return &logBuffer{
+		entries: make([]bufferedEntry, size),
+	}
+}
+
+// add stores an entry, overwriting the oldest once the buffer is full
+func (b *logBuffer) add(entry bufferedEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries[b.next] = entry
+	b.next = (b.next + 1) % len(b.entries)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// snapshot returns a copy of the buffered entries, oldest first
+func (b *logBuffer) snapshot() []bufferedEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.full {
+		return append([]bufferedEntry(nil), b.entries[:b.next]...)
+	}
+
+	result := make([]bufferedEntry, 0, len(b.entries))
+	result = append(result, b.entries[b.next:]...)
+	return append(result, b.entries[:b.next]...)
+}
+
+// ServeHTTP serves the buffered entries as a JSON array
+func (b *logBuffer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(b.snapshot()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// bufferCore is a zapcore.Core that writes entries to a logBuffer.
+// It is teed with the output core so entries are both written and retained.
+type bufferCore struct {
+	zapcore.LevelEnabler
+	buffer *logBuffer
+	fields []zapcore.Field
+}
+
+func newBufferCore(enabler zapcore.LevelEnabler, buffer *logBuffer) *bufferCore {
+	return &bufferCore{
+		LevelEnabler: enabler,
+		buffer:       buffer,
+	}
+}
+
+func (c *bufferCore) With(fields []zapcore.Field) zapcore.Core {
+	combined := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	combined = append(combined, c.fields...)
+	return &bufferCore{
+		LevelEnabler: c.LevelEnabler,
+		buffer:       c.buffer,
+		fields:       append(combined, fields...),
+	}
+}
+
+func (c *bufferCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *bufferCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	entry := bufferedEntry{
+		Timestamp: ent.Time,
+		Level:     ent.Level.String(),
+		Message:   ent.Message,
+	}
+	if len(enc.Fields) > 0 {
+		entry.Fields = enc.Fields
+	}
+	c.buffer.add(entry)
+	return nil
+}
+
+func (c *bufferCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,106 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+func TestLogBuffer_RetainsLastN(t *testing.T) {
+	tests := []struct {
+		name  string
+		size  int
+		count int
+		want  []string
+	}{
+		{name: "empty", size: 3, count: 0, want: []string{}},
+		{name: "partially filled", size: 3, count: 2, want: []string{"msg-0", "msg-1"}},
+		{name: "exactly full", size: 3, count: 3, want: []string{"msg-0", "msg-1", "msg-2"}},
+		{name: "wrapped", size: 3, count: 5, want: []string{"msg-2", "msg-3", "msg-4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := newLogBuffer(tt.size)
+			for i := 0; i < tt.count; i++ {
+				buf.add(bufferedEntry{Message: fmt.Sprintf("msg-%d", i)})
+			}
+
+			got := make([]string, 0, tt.count)
+			for _, e := range buf.snapshot() {
+				got = append(got, e.Message)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLogBuffer_Concurrent(t *testing.T) {
+	buf := newLogBuffer(10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				buf.add(bufferedEntry{Message: "concurrent"})
+				_ = buf.snapshot()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, buf.snapshot(), 10)
+}
+
+func TestZapLogger_LogsHandler(t *testing.T) {
+	logger, err := NewFactory().NewLogger(
+		domainlog.WithServiceName("test-service"),
+		domainlog.WithLogBuffer(2),
+	)
+	require.NoError(t, err)
+
+	logger.Debug("below level")
+	logger.Info("first")
+	logger.With(domainlog.Fields{"request": "abc"}).InfoWith("second", domainlog.Fields{"count": 1})
+	logger.Warn("third")
+
+	handler := logger.(domainlog.Buffered).GetLogsHandler()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/internal/logs", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var entries []bufferedEntry
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "second", entries[0].Message)
+	assert.Equal(t, "info", entries[0].Level)
+	assert.Equal(t, "test-service", entries[0].Fields["service"])
+	assert.Equal(t, "abc", entries[0].Fields["request"])
+	assert.Equal(t, float64(1), entries[0].Fields["count"])
+
+	assert.Equal(t, "third", entries[1].Message)
+	assert.Equal(t, "warn", entries[1].Level)
+}
+
+func TestZapLogger_LogsHandlerWithoutBuffer(t *testing.T) {
+	logger, err := NewFactory().NewLogger()
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	logger.(domainlog.Buffered).GetLogsHandler().ServeHTTP(w, httptest.NewRequest("GET", "/internal/logs", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,107 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+// levelPayload is the request and response body of the level handler
+type levelPayload struct {
+	Level domainlog.Level `json:"level"`
+}
+
+// levelHandler reads and updates a logger's level over HTTP.
+// GET returns {"level":"info"}; PUT accepts {"level":"debug"}.
+type levelHandler struct {
+	logger *ZapLogger
+}
+
+func (h *levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.writeLevel(w)
+	case http.MethodPut:
+		var payload levelPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+		if !isKnownLevel(payload.Level) {
+			http.Error(w, fmt.Sprintf("unknown level: %q", payload.Level), http.StatusBadRequest)
+			return
+		}
+		h.logger.SetLevel(payload.Level)
+		h.writeLevel(w)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *levelHandler) writeLevel(w http.ResponseWriter) {
+	// The atomic level is shared by derived loggers, so it reflects the
+	// level actually in effect
+	level := domainlog.Level(h.logger.atom.Level().String())
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(levelPayload{Level: level}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// isKnownLevel reports whether level is one of the domain Level constants
+func isKnownLevel(level domainlog.Level) bool {
+	switch level {
+	case domainlog.DebugLevel, domainlog.InfoLevel, domainlog.WarnLevel,
+		domainlog.ErrorLevel, domainlog.FatalLevel:
+		return true
+	default:
+		return false
+	}
+}
+
+// loggerSettings records the configuration a logger was created with
+type loggerSettings struct {
+	serviceName string
+	fields      domainlog.Fields // Redacted default fields
+	encoding    string
+}
+
+// effectiveConfig is the response body of the effective config handler
+type effectiveConfig struct {
+	Level       domainlog.Level  `json:"level"`
+	ServiceName string           `json:"service_name,omitempty"`
+	Fields      domainlog.Fields `json:"fields,omitempty"`
+	Encoding    string           `json:"encoding"`
+}
+
+// effectiveConfigHandler serves a logger's effective configuration on GET.
+// PUT updates the level as the level handler does.
+type effectiveConfigHandler struct {
+	logger *ZapLogger
+}
+
+func (h *effectiveConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		config := effectiveConfig{
+			Level: domainlog.Level(h.logger.atom.Level().String()),
+		}
+		if settings := h.logger.settings; settings != nil {
+			config.ServiceName = settings.serviceName
+			config.Fields = settings.fields
+			config.Encoding = settings.encoding
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(config); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	case http.MethodPut:
+		(&levelHandler{logger: h.logger}).ServeHTTP(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
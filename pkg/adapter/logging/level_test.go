@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+func TestZapLogger_ConfigHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantBody   string
+		wantLevel  domainlog.Level
+	}{
+		{
+			name:       "GET returns current level",
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"level":"info"}`,
+			wantLevel:  domainlog.InfoLevel,
+		},
+		{
+			name:       "PUT updates level",
+			method:     http.MethodPut,
+			body:       `{"level":"debug"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"level":"debug"}`,
+			wantLevel:  domainlog.DebugLevel,
+		},
+		{
+			name:       "PUT rejects unknown level",
+			method:     http.MethodPut,
+			body:       `{"level":"verbose"}`,
+			wantStatus: http.StatusBadRequest,
+			wantLevel:  domainlog.InfoLevel,
+		},
+		{
+			name:       "PUT rejects malformed body",
+			method:     http.MethodPut,
+			body:       `{"level":`,
+			wantStatus: http.StatusBadRequest,
+			wantLevel:  domainlog.InfoLevel,
+		},
+		{
+			name:       "POST not allowed",
+			method:     http.MethodPost,
+			body:       `{"level":"debug"}`,
+			wantStatus: http.StatusMethodNotAllowed,
+			wantLevel:  domainlog.InfoLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, err := NewFactory().NewLogger(domainlog.WithLevel(domainlog.InfoLevel))
+			require.NoError(t, err)
+
+			handler := logger.(domainlog.RuntimeConfigurable).GetConfigHandler()
+			req := httptest.NewRequest(tt.method, "/internal/logging", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+			assert.Equal(t, tt.wantLevel, logger.GetLevel())
+		})
+	}
+}
+
+func TestZapLogger_EffectiveConfigHandler(t *testing.T) {
+	logger, err := NewFactory().NewLoggerWithOptions(
+		[]domainlog.Option{
+			domainlog.WithServiceName("test-service"),
+			domainlog.WithLevel(domainlog.WarnLevel),
+			domainlog.WithFields(domainlog.Fields{"region": "eu-west", "api_token": "abc"}),
+		},
+		[]ZapOption{
+			WithEncoding(ConsoleEncoding),
+			WithRedactedKeys([]string{"token"}),
+		},
+	)
+	require.NoError(t, err)
+
+	handler := logger.(domainlog.Inspectable).GetEffectiveConfigHandler()
+
+	t.Run("GET returns effective config", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/internal/logging/config", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"level": "warn",
+			"service_name": "test-service",
+			"fields": {"region": "eu-west", "api_token": "******"},
+			"encoding": "console"
+		}`, w.Body.String())
+	})
+
+	t.Run("PUT updates level", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/internal/logging/config", strings.NewReader(`{"level":"debug"}`))
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, domainlog.DebugLevel, logger.GetLevel())
+
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/internal/logging/config", nil))
+		assert.Contains(t, w.Body.String(), `"level":"debug"`)
+	})
+}
@@ -0,0 +1,18 @@
+package logging
+
+import (
+	"go.uber.org/zap"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+// NewNopLogger returns a logger that discards every entry. It is useful in
+// tests and libraries that need a non-nil logger, for example to exercise
+// the router's logging middleware without output. Levels can still be set
+// and read.
+func NewNopLogger() domainlog.LeveledLogger {
+	return &ZapLogger{
+		logger: zap.NewNop(),
+		atom:   zap.NewAtomicLevelAt(zap.InfoLevel),
+	}
+}
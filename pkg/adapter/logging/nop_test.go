@@ -0,0 +1,54 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+func TestNopLogger(t *testing.T) {
+	output := captureOutput(t, func() {
+		logger := NewNopLogger()
+
+		logger.SetLevel(domainlog.DebugLevel)
+		assert.Equal(t, domainlog.DebugLevel, logger.GetLevel())
+
+		logger.Debug("debug")
+		logger.InfoWith("info", domainlog.Fields{"key": "value"})
+		logger.Warn("warn")
+		logger.ErrorWith("error", domainlog.Fields{"key": "value"})
+
+		derived := logger.With(domainlog.Fields{"component": "test"}).
+			WithError(errors.New("boom")).
+			WithContext(domainlog.ContextWithCorrelationID(context.Background(), "corr-123"))
+		derived.Info("derived")
+	})
+
+	assert.Empty(t, output)
+}
+
+// captureOutput returns what fn writes to stdout and stderr
+func captureOutput(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = w, w
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+
+	fn()
+	require.NoError(t, w.Close())
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(output)
+}
@@ -0,0 +1,115 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/contrib/bridges/otelzap"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
+)
+
+// OTLPOptions configures export of log entries to an OpenTelemetry collector.
+// Entries are still written to the configured outputs.
+type OTLPOptions struct {
+	// Endpoint is the collector host:port, typically the tracing endpoint
+	Endpoint string
+	// Insecure disables TLS for the connection to the collector
+	Insecure bool
+	// Exporter overrides the OTLP/HTTP exporter built from Endpoint,
+	// for example to use a custom transport
+	Exporter sdklog.Exporter
+}
+
+// WithOTLPExport also exports log entries over OTLP so they are correlated
+// with traces in the same backend. Entries logged through WithContext carry
+// the active span's trace and span IDs.
+func WithOTLPExport(opts OTLPOptions) ZapOption {
+	return options.OptionFunc[ZapOptions](func(o *ZapOptions) error {
+		if opts.Endpoint == "" && opts.Exporter == nil {
+			return fmt.Errorf("otlp endpoint cannot be empty")
+		}
+		o.OTLP = &opts
+		return nil
+	})
+}
+
+// newOTLPProvider creates a log provider that batches entries to the collector
+func newOTLPProvider(zopts ZapOptions) (*sdklog.LoggerProvider, error) {
+	exporter := zopts.OTLP.Exporter
+	if exporter == nil {
+		exporterOpts := []otlploghttp.Option{
+			otlploghttp.WithEndpoint(zopts.OTLP.Endpoint),
+		}
+		if zopts.OTLP.Insecure {
+			exporterOpts = append(exporterOpts, otlploghttp.WithInsecure())
+		}
+
+		var err error
+		exporter, err = otlploghttp.New(context.Background(), exporterOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating otlp log exporter: %w", err)
+		}
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(zopts.ServiceName),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating otlp log resource: %w", err)
+	}
+
+	return sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+		sdklog.WithResource(res),
+	), nil
+}
+
+// newOTLPCore bridges zap entries to the provider, filtered by enabler.
+// The bridge itself only consults the provider, which accepts every level.
+func newOTLPCore(enabler zapcore.LevelEnabler, provider *sdklog.LoggerProvider, name string) zapcore.Core {
+	return &leveledCore{
+		Core:    otelzap.NewCore(name, otelzap.WithLoggerProvider(provider)),
+		enabler: enabler,
+	}
+}
+
+// leveledCore restricts a core to the levels allowed by enabler
+type leveledCore struct {
+	zapcore.Core
+	enabler zapcore.LevelEnabler
+}
+
+func (c *leveledCore) Enabled(level zapcore.Level) bool {
+	return c.enabler.Enabled(level)
+}
+
+func (c *leveledCore) With(fields []zapcore.Field) zapcore.Core {
+	return &leveledCore{
+		Core:    c.Core.With(fields),
+		enabler: c.enabler,
+	}
+}
+
+func (c *leveledCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.enabler.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// spanContextField carries ctx to the OTLP bridge, which uses it to set the
+// record's trace and span IDs. Skip fields are ignored by the other cores.
+func spanContextField(ctx context.Context) zap.Field {
+	return zap.Field{Key: "context", Type: zapcore.SkipType, Interface: ctx}
+}
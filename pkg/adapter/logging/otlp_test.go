@@ -0,0 +1,126 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+// memoryExporter records exported log records
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error   { return nil }
+func (e *memoryExporter) ForceFlush(context.Context) error { return nil }
+
+func (e *memoryExporter) all() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]sdklog.Record(nil), e.records...)
+}
+
+func TestFactory_OTLPExport(t *testing.T) {
+	exporter := &memoryExporter{}
+	path := filepath.Join(t.TempDir(), "service.log")
+
+	logger, err := NewFactory().NewLoggerWithOptions(
+		[]domainlog.Option{domainlog.WithServiceName("test-service")},
+		[]ZapOption{
+			WithOutputPaths([]string{path}),
+			WithOTLPExport(OTLPOptions{Exporter: exporter}),
+		},
+	)
+	require.NoError(t, err)
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "operation")
+	logger.WithContext(ctx).InfoWith("exported", domainlog.Fields{"key": "value"})
+	logger.Debug("below level")
+	span.End()
+
+	require.NoError(t, logger.(*ZapLogger).Shutdown(context.Background()))
+
+	// Entries are still written to the configured outputs
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 1)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "exported", entry["message"])
+	assert.NotContains(t, entry, "context")
+
+	records := exporter.all()
+	require.Len(t, records, 1)
+
+	record := records[0]
+	assert.Equal(t, "exported", record.Body().AsString())
+	assert.Equal(t, otellog.SeverityInfo, record.Severity())
+	assert.Equal(t, span.SpanContext().TraceID(), record.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), record.SpanID())
+
+	attrs := make(map[string]string)
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.String()
+		return true
+	})
+	assert.Equal(t, "test-service", attrs["service"])
+	assert.Equal(t, "value", attrs["key"])
+	assert.Equal(t, span.SpanContext().TraceID().String(), attrs["trace_id"])
+}
+
+func TestNewFactoryWithOptions_OTLPExport(t *testing.T) {
+	exporter := &memoryExporter{}
+	factory := NewFactoryWithOptions(
+		WithOutputPaths([]string{filepath.Join(t.TempDir(), "service.log")}),
+		WithOTLPExport(OTLPOptions{Exporter: exporter}),
+	)
+
+	// Loggers created through the domain interface get the factory's options
+	logger, err := factory.NewLogger(domainlog.WithServiceName("test-service"))
+	require.NoError(t, err)
+	logger.Info("exported")
+
+	exporting, ok := logger.(domainlog.Exporting)
+	require.True(t, ok)
+	require.NoError(t, exporting.Shutdown(context.Background()))
+
+	records := exporter.all()
+	require.Len(t, records, 1)
+	assert.Equal(t, "exported", records[0].Body().AsString())
+}
+
+func TestWithOTLPExport_RequiresEndpoint(t *testing.T) {
+	var opts ZapOptions
+	err := WithOTLPExport(OTLPOptions{}).ApplyOption(&opts)
+	assert.EqualError(t, err, "otlp endpoint cannot be empty")
+}
+
+func TestZapLogger_ShutdownWithoutOTLP(t *testing.T) {
+	logger, _ := newTestLogger(t)
+	assert.NoError(t, logger.Shutdown(context.Background()))
+}
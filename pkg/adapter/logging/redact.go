@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"strings"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+// redactedValue replaces the values of redacted fields
+const redactedValue = "******"
+
+// redactFields returns a copy of fields with the values of keys containing
+// any of the patterns (case-insensitively) replaced. Nested maps are
+// redacted recursively. Patterns must already be lower case.
+func redactFields(fields domainlog.Fields, patterns []string) domainlog.Fields {
+	if len(patterns) == 0 || len(fields) == 0 {
+		return fields
+	}
+	return domainlog.Fields(redactMap(fields, patterns))
+}
+
+func redactMap(m map[string]interface{}, patterns []string) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if isRedacted(k, patterns) {
+			result[k] = redactedValue
+			continue
+		}
+
+		switch nested := v.(type) {
+		case domainlog.Fields:
+			result[k] = domainlog.Fields(redactMap(nested, patterns))
+		case map[string]interface{}:
+			result[k] = redactMap(nested, patterns)
+		default:
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// isRedacted reports whether key contains any of the patterns
+func isRedacted(key string, patterns []string) bool {
+	key = strings.ToLower(key)
+	for _, p := range patterns {
+		if strings.Contains(key, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+func TestRedactFields(t *testing.T) {
+	patterns := []string{"password", "token"}
+
+	input := domainlog.Fields{
+		"user":        "alice",
+		"Password":    "hunter2",
+		"db_password": "secret",
+		"request": domainlog.Fields{
+			"path":         "/login",
+			"ACCESS_TOKEN": "abc123",
+			"headers": map[string]interface{}{
+				"X-Auth-Token": "def456",
+				"Accept":       "application/json",
+			},
+		},
+	}
+
+	got := redactFields(input, patterns)
+
+	assert.Equal(t, domainlog.Fields{
+		"user":        "alice",
+		"Password":    redactedValue,
+		"db_password": redactedValue,
+		"request": domainlog.Fields{
+			"path":         "/login",
+			"ACCESS_TOKEN": redactedValue,
+			"headers": map[string]interface{}{
+				"X-Auth-Token": redactedValue,
+				"Accept":       "application/json",
+			},
+		},
+	}, got)
+
+	// The caller's fields are never modified
+	assert.Equal(t, "hunter2", input["Password"])
+	assert.Equal(t, "abc123", input["request"].(domainlog.Fields)["ACCESS_TOKEN"])
+}
+
+func TestZapLogger_RedactedKeys(t *testing.T) {
+	logger, obs := newTestLogger(t)
+	logger.redactKeys = []string{"password"}
+
+	logger.InfoWith("login", domainlog.Fields{
+		"user":     "alice",
+		"password": "hunter2",
+	})
+	logger.With(domainlog.Fields{"PASSWORD": "hunter2"}).Warn("derived")
+
+	logs := obs.TakeAll()
+	require.Len(t, logs, 2)
+	assert.Equal(t, "alice", logs[0].ContextMap()["user"])
+	assert.Equal(t, redactedValue, logs[0].ContextMap()["password"])
+	assert.Equal(t, redactedValue, logs[1].ContextMap()["PASSWORD"])
+}
+
+func TestFactory_WithRedactedKeys(t *testing.T) {
+	logger, err := NewFactory().NewLoggerWithOptions(
+		[]domainlog.Option{
+			domainlog.WithFields(domainlog.Fields{"api_token": "abc"}),
+			domainlog.WithLogBuffer(10),
+		},
+		[]ZapOption{WithRedactedKeys([]string{"Token", "secret"})},
+	)
+	require.NoError(t, err)
+
+	logger.ErrorWith("failed", domainlog.Fields{
+		"config": domainlog.Fields{"client_secret": "xyz", "region": "eu"},
+	})
+
+	entries := logger.(*ZapLogger).buffer.snapshot()
+	require.Len(t, entries, 1)
+	assert.Equal(t, redactedValue, entries[0].Fields["api_token"])
+	assert.Equal(t, domainlog.Fields{
+		"client_secret": redactedValue,
+		"region":        "eu",
+	}, entries[0].Fields["config"])
+}
@@ -0,0 +1,54 @@
+package logging
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// splitFileOutputs separates the standard streams from file paths
+func splitFileOutputs(paths []string) (streams, files []string) {
+	for _, p := range paths {
+		if p == "stdout" || p == "stderr" {
+			streams = append(streams, p)
+			continue
+		}
+		files = append(files, p)
+	}
+	return streams, files
+}
+
+// newRotatingCore creates a core writing to the given files through
+// lumberjack, using the same encoding and level as config
+func newRotatingCore(config zap.Config, files []string, rotation RotationOptions) (zapcore.Core, error) {
+	encoder, err := newEncoder(config)
+	if err != nil {
+		return nil, err
+	}
+
+	syncers := make([]zapcore.WriteSyncer, 0, len(files))
+	for _, file := range files {
+		syncers = append(syncers, zapcore.AddSync(&lumberjack.Logger{
+			Filename:   file,
+			MaxSize:    rotation.MaxSizeMB,
+			MaxBackups: rotation.MaxBackups,
+			MaxAge:     rotation.MaxAgeDays,
+		}))
+	}
+
+	return zapcore.NewCore(encoder, zapcore.NewMultiWriteSyncer(syncers...), config.Level), nil
+}
+
+// newEncoder creates the encoder for config's encoding
+func newEncoder(config zap.Config) (zapcore.Encoder, error) {
+	switch config.Encoding {
+	case JSONEncoding:
+		return zapcore.NewJSONEncoder(config.EncoderConfig), nil
+	case ConsoleEncoding:
+		return zapcore.NewConsoleEncoder(config.EncoderConfig), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", config.Encoding)
+	}
+}
@@ -0,0 +1,137 @@
+package logging
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+func TestFactory_OutputPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		zopts func(path string) []ZapOption
+	}{
+		{
+			name: "file output",
+			zopts: func(path string) []ZapOption {
+				return []ZapOption{WithOutputPaths([]string{path})}
+			},
+		},
+		{
+			name: "rotated file output",
+			zopts: func(path string) []ZapOption {
+				return []ZapOption{
+					WithOutputPaths([]string{path}),
+					WithRotation(10, 3, 7),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "service.log")
+
+			logger, err := NewFactory().NewLoggerWithOptions(
+				[]domainlog.Option{domainlog.WithServiceName("test-service")},
+				tt.zopts(path),
+			)
+			require.NoError(t, err)
+
+			logger.InfoWith("written to file", domainlog.Fields{"key": "value"})
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			require.Len(t, lines, 1)
+
+			var entry map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+			assert.Equal(t, "written to file", entry["message"])
+			assert.Equal(t, "test-service", entry["service"])
+			assert.Equal(t, "value", entry["key"])
+		})
+	}
+}
+
+func TestFactory_TimeFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "service.log")
+
+	logger, err := NewFactory().NewLoggerWithOptions(nil, []ZapOption{
+		WithOutputPaths([]string{path}),
+		WithTimeKey("@timestamp"),
+		WithTimeEncoder(EpochMillisTimeEncoder),
+	})
+	require.NoError(t, err)
+
+	before := time.Now().UnixMilli()
+	logger.Info("timestamped")
+	after := time.Now().UnixMilli()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &entry))
+	assert.NotContains(t, entry, "timestamp")
+	millis, ok := entry["@timestamp"].(float64)
+	require.True(t, ok, "timestamp should be a number, got %v", entry["@timestamp"])
+	assert.GreaterOrEqual(t, int64(millis), before)
+	assert.LessOrEqual(t, int64(millis), after)
+}
+
+func TestZapOptions_OutputValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     ZapOption
+		wantErr string
+	}{
+		{
+			name:    "empty output paths",
+			opt:     WithOutputPaths(nil),
+			wantErr: "output paths cannot be empty",
+		},
+		{
+			name:    "zero rotation size",
+			opt:     WithRotation(0, 1, 1),
+			wantErr: "rotation max size must be positive",
+		},
+		{
+			name:    "negative backups",
+			opt:     WithRotation(10, -1, 1),
+			wantErr: "rotation max backups and max age cannot be negative",
+		},
+		{
+			name:    "empty time key",
+			opt:     WithTimeKey(""),
+			wantErr: "time key cannot be empty",
+		},
+		{
+			name:    "nil time encoder",
+			opt:     WithTimeEncoder(nil),
+			wantErr: "time encoder cannot be nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var zopts ZapOptions
+			assert.EqualError(t, tt.opt.ApplyOption(&zopts), tt.wantErr)
+		})
+	}
+}
+
+func TestSplitFileOutputs(t *testing.T) {
+	streams, files := splitFileOutputs([]string{"stdout", "/var/log/a.log", "stderr", "b.log"})
+	assert.Equal(t, []string{"stdout", "stderr"}, streams)
+	assert.Equal(t, []string{"/var/log/a.log", "b.log"}, files)
+}
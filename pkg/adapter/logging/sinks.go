@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
+)
+
+// SinkOptions configures an additional log destination with its own
+// encoding and level, written alongside the main outputs
+type SinkOptions struct {
+	// Encoding is "json" or "console"
+	Encoding string
+	// OutputPaths are the sink destinations: "stdout", "stderr" or file
+	// paths. File paths are rotated when rotation is configured.
+	OutputPaths []string
+	// Level is the minimum level written to the sink. If empty, the sink
+	// follows the logger's level, including changes at runtime.
+	Level domainlog.Level
+}
+
+// WithSinks writes every entry to the given sinks in addition to the main
+// outputs, for example console output on stderr while developing and JSON
+// to a file for tooling. Sinks share the logger's time format, fields and
+// redaction.
+func WithSinks(sinks ...SinkOptions) ZapOption {
+	return options.OptionFunc[ZapOptions](func(o *ZapOptions) error {
+		for _, sink := range sinks {
+			if sink.Encoding != JSONEncoding && sink.Encoding != ConsoleEncoding {
+				return fmt.Errorf("invalid sink encoding: %s", sink.Encoding)
+			}
+			if len(sink.OutputPaths) == 0 {
+				return fmt.Errorf("sink output paths cannot be empty")
+			}
+			if sink.Level != "" && !isKnownLevel(sink.Level) {
+				return fmt.Errorf("invalid sink level: %s", sink.Level)
+			}
+		}
+		o.Sinks = append(o.Sinks, sinks...)
+		return nil
+	})
+}
+
+// newSinkCore creates a core writing to the sink's outputs with its
+// encoding. Without a sink level it shares the logger's atomic level.
+func newSinkCore(zopts ZapOptions, sink SinkOptions, level zap.AtomicLevel) (zapcore.Core, error) {
+	zopts.Encoding = sink.Encoding
+	zopts.OutputPaths = sink.OutputPaths
+	config := newZapConfig(zopts)
+
+	config.Level = level
+	if sink.Level != "" {
+		config.Level = zap.NewAtomicLevelAt(convertToZapLevel(sink.Level))
+	}
+
+	var cores []zapcore.Core
+	paths := config.OutputPaths
+	if zopts.Rotation != nil {
+		var rotated []string
+		paths, rotated = splitFileOutputs(paths)
+		if len(rotated) > 0 {
+			core, err := newRotatingCore(config, rotated, *zopts.Rotation)
+			if err != nil {
+				return nil, err
+			}
+			cores = append(cores, core)
+		}
+	}
+
+	if len(paths) > 0 {
+		encoder, err := newEncoder(config)
+		if err != nil {
+			return nil, err
+		}
+		sync, _, err := zap.Open(paths...)
+		if err != nil {
+			return nil, fmt.Errorf("opening sink outputs: %w", err)
+		}
+		cores = append(cores, zapcore.NewCore(encoder, sync, config.Level))
+	}
+
+	return zapcore.NewTee(cores...), nil
+}
@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+func TestFactory_Sinks(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "service.json")
+	consolePath := filepath.Join(dir, "console.log")
+	warnPath := filepath.Join(dir, "warnings.log")
+
+	logger, err := NewFactory().NewLoggerWithOptions(
+		[]domainlog.Option{domainlog.WithServiceName("test-service")},
+		[]ZapOption{
+			WithOutputPaths([]string{jsonPath}),
+			WithSinks(
+				SinkOptions{Encoding: ConsoleEncoding, OutputPaths: []string{consolePath}},
+				SinkOptions{Encoding: JSONEncoding, OutputPaths: []string{warnPath}, Level: domainlog.WarnLevel},
+			),
+		},
+	)
+	require.NoError(t, err)
+
+	logger.InfoWith("written twice", domainlog.Fields{"key": "value"})
+
+	// The main output is JSON
+	content, err := os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &entry))
+	assert.Equal(t, "written twice", entry["message"])
+	assert.Equal(t, "value", entry["key"])
+
+	// The console sink gets the same entry as text
+	content, err = os.ReadFile(consolePath)
+	require.NoError(t, err)
+	line := strings.TrimSpace(string(content))
+	assert.Contains(t, line, "INFO")
+	assert.Contains(t, line, "written twice")
+	assert.Contains(t, line, `"key": "value"`)
+	assert.False(t, json.Valid([]byte(line)), "console output should not be JSON")
+
+	// The warn sink filters by its own level, independent of the logger
+	content, err = os.ReadFile(warnPath)
+	require.NoError(t, err)
+	assert.Empty(t, content)
+
+	logger.Warn("written three times")
+	content, err = os.ReadFile(warnPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "written three times")
+
+	// Sinks without a level follow runtime level changes
+	logger.SetLevel(domainlog.DebugLevel)
+	logger.Debug("debug entry")
+	content, err = os.ReadFile(consolePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "debug entry")
+}
+
+func TestWithSinks_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		sink    SinkOptions
+		wantErr string
+	}{
+		{
+			name:    "invalid encoding",
+			sink:    SinkOptions{Encoding: "xml", OutputPaths: []string{"stderr"}},
+			wantErr: "invalid sink encoding: xml",
+		},
+		{
+			name:    "no output paths",
+			sink:    SinkOptions{Encoding: JSONEncoding},
+			wantErr: "sink output paths cannot be empty",
+		},
+		{
+			name:    "unknown level",
+			sink:    SinkOptions{Encoding: JSONEncoding, OutputPaths: []string{"stderr"}, Level: "verbose"},
+			wantErr: "invalid sink level: verbose",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var zopts ZapOptions
+			assert.EqualError(t, WithSinks(tt.sink).ApplyOption(&zopts), tt.wantErr)
+		})
+	}
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
+	"go.opentelemetry.io/otel/baggage"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -13,17 +18,96 @@ import (
 	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
 )
 
+// Verify interface implementation
+var (
+	_ domainlog.LeveledLogger       = (*ZapLogger)(nil)
+	_ domainlog.RuntimeConfigurable = (*ZapLogger)(nil)
+	_ domainlog.Buffered            = (*ZapLogger)(nil)
+	_ domainlog.Inspectable         = (*ZapLogger)(nil)
+	_ domainlog.Exporting           = (*ZapLogger)(nil)
+	_ domainlog.Factory             = (*Factory)(nil)
+)
+
 type ZapLogger struct {
-	logger *zap.Logger
-	level  domainlog.Level
-	atom   zap.AtomicLevel
+	logger        *zap.Logger
+	atom          zap.AtomicLevel // Shared by derived loggers so level changes apply to all
+	correlationID domainlog.CorrelationIDFunc
+	buffer        *logBuffer
+	redactKeys    []string               // Lower-cased key patterns whose values are scrubbed
+	baggageKeys   []string               // Baggage members attached by WithContext
+	otlp          *sdklog.LoggerProvider // Set when entries are exported over OTLP
+	settings      *loggerSettings        // Configuration reported by the effective config handler
 }
 
 type ZapOptions struct {
 	domainlog.LoggerOptions
 	Development bool
+	// Encoding is "json" or "console". If empty, development mode uses
+	// console and production uses json.
+	Encoding string
+	// OutputPaths are the log destinations: "stdout", "stderr" or file paths.
+	// If empty, logs are written to stdout.
+	OutputPaths []string
+	// Rotation enables size-based rotation for file output paths
+	Rotation *RotationOptions
+	// RedactedKeys are key patterns whose field values are replaced with
+	// "******". Keys containing a pattern, case-insensitively, are redacted.
+	RedactedKeys []string
+	// BaggageKeys are OpenTelemetry baggage members added as fields by
+	// WithContext when present in the context
+	BaggageKeys []string
+	// OTLP exports entries to an OpenTelemetry collector in addition to
+	// the outputs
+	OTLP *OTLPOptions
+	// Sampling limits repeated entries. If nil, every entry is logged.
+	Sampling *SamplingOptions
+	// Sinks are additional destinations, each with its own encoding and level
+	Sinks []SinkOptions
+	// TimeKey is the key of the entry timestamp.
+	// If empty, defaults to "timestamp".
+	TimeKey string
+	// TimeEncoder formats the entry timestamp.
+	// If nil, defaults to ISO8601TimeEncoder.
+	TimeEncoder zapcore.TimeEncoder
+}
+
+// SamplingOptions configures sampling of repeated entries. Entries with the
+// same level and message are counted per second: the first Initial are
+// logged, then every Thereafter-th.
+type SamplingOptions struct {
+	Initial    int
+	Thereafter int
+}
+
+// RotationOptions configures rotation of file outputs
+type RotationOptions struct {
+	// MaxSizeMB is the size in megabytes at which a file is rotated
+	MaxSizeMB int
+	// MaxBackups is the number of rotated files kept; zero keeps all
+	MaxBackups int
+	// MaxAgeDays is the age at which rotated files are removed; zero keeps all
+	MaxAgeDays int
 }
 
+const (
+	// JSONEncoding writes one JSON object per entry
+	JSONEncoding = "json"
+	// ConsoleEncoding writes human-readable text entries
+	ConsoleEncoding = "console"
+)
+
+// Timestamp formats for WithTimeEncoder
+var (
+	// ISO8601TimeEncoder writes times like 2024-01-31T14:05:00.000Z (default)
+	ISO8601TimeEncoder zapcore.TimeEncoder = zapcore.ISO8601TimeEncoder
+	// RFC3339NanoTimeEncoder writes RFC 3339 times with nanoseconds
+	RFC3339NanoTimeEncoder zapcore.TimeEncoder = zapcore.RFC3339NanoTimeEncoder
+	// EpochMillisTimeEncoder writes milliseconds since the Unix epoch
+	EpochMillisTimeEncoder zapcore.TimeEncoder = zapcore.EpochMillisTimeEncoder
+	// EpochNanosTimeEncoder writes nanoseconds since the Unix epoch
+	EpochNanosTimeEncoder zapcore.TimeEncoder = zapcore.EpochNanosTimeEncoder
+)
+
 type ZapOption = options.Option[ZapOptions]
 
 // WithDevelopment enables development mode
@@ -34,31 +118,136 @@ func WithDevelopment(enabled bool) ZapOption {
 	})
 }
 
-type Factory struct{}
+// WithEncoding sets the log encoding, either "json" or "console"
+func WithEncoding(encoding string) ZapOption {
+	return options.OptionFunc[ZapOptions](func(o *ZapOptions) error {
+		if encoding != JSONEncoding && encoding != ConsoleEncoding {
+			return fmt.Errorf("invalid encoding: %s", encoding)
+		}
+		o.Encoding = encoding
+		return nil
+	})
+}
 
-func NewFactory() *Factory {
-	return &Factory{}
+// WithOutputPaths sets where logs are written.
+// Accepts "stdout", "stderr" and file paths.
+func WithOutputPaths(paths []string) ZapOption {
+	return options.OptionFunc[ZapOptions](func(o *ZapOptions) error {
+		if len(paths) == 0 {
+			return fmt.Errorf("output paths cannot be empty")
+		}
+		o.OutputPaths = paths
+		return nil
+	})
 }
 
-func (f *Factory) NewLogger(opts ...domainlog.Option) (domainlog.LeveledLogger, error) {
-	// Initialize domain options
-	zopts := ZapOptions{
-		LoggerOptions: domainlog.LoggerOptions{
-			Level: domainlog.InfoLevel,
-		},
-	}
+// WithRotation rotates file output paths once they reach maxSizeMB,
+// keeping at most maxBackups files for up to maxAgeDays.
+// Zero maxBackups or maxAgeDays means no limit.
+func WithRotation(maxSizeMB, maxBackups, maxAgeDays int) ZapOption {
+	return options.OptionFunc[ZapOptions](func(o *ZapOptions) error {
+		if maxSizeMB <= 0 {
+			return fmt.Errorf("rotation max size must be positive")
+		}
+		if maxBackups < 0 || maxAgeDays < 0 {
+			return fmt.Errorf("rotation max backups and max age cannot be negative")
+		}
+		o.Rotation = &RotationOptions{
+			MaxSizeMB:  maxSizeMB,
+			MaxBackups: maxBackups,
+			MaxAgeDays: maxAgeDays,
+		}
+		return nil
+	})
+}
 
-	// Apply domain options
-	for _, opt := range opts {
-		if err := opt.ApplyOption(&zopts.LoggerOptions); err != nil {
-			return nil, fmt.Errorf("applying domain options: %w", err)
+// WithRedactedKeys scrubs the values of fields whose keys contain any of
+// the given patterns, case-insensitively, including in nested Fields maps.
+// For example "password" redacts both "password" and "db_password".
+func WithRedactedKeys(keys []string) ZapOption {
+	return options.OptionFunc[ZapOptions](func(o *ZapOptions) error {
+		o.RedactedKeys = keys
+		return nil
+	})
+}
+
+// WithBaggageKeys adds the named OpenTelemetry baggage members as fields
+// on loggers derived with WithContext. Members absent from the context are
+// skipped, and values are subject to redaction like any other field.
+func WithBaggageKeys(keys []string) ZapOption {
+	return options.OptionFunc[ZapOptions](func(o *ZapOptions) error {
+		for _, k := range keys {
+			if k == "" {
+				return fmt.Errorf("baggage key cannot be empty")
+			}
 		}
-	}
+		o.BaggageKeys = keys
+		return nil
+	})
+}
+
+// WithTimeKey sets the key of the entry timestamp, for example
+// "@timestamp" for pipelines that expect it.
+func WithTimeKey(key string) ZapOption {
+	return options.OptionFunc[ZapOptions](func(o *ZapOptions) error {
+		if key == "" {
+			return fmt.Errorf("time key cannot be empty")
+		}
+		o.TimeKey = key
+		return nil
+	})
+}
+
+// WithTimeEncoder sets how entry timestamps are formatted, such as
+// EpochMillisTimeEncoder or any zapcore.TimeEncoder.
+func WithTimeEncoder(encoder zapcore.TimeEncoder) ZapOption {
+	return options.OptionFunc[ZapOptions](func(o *ZapOptions) error {
+		if encoder == nil {
+			return fmt.Errorf("time encoder cannot be nil")
+		}
+		o.TimeEncoder = encoder
+		return nil
+	})
+}
+
+// WithSampling logs the first initial entries with the same level and
+// message each second, then only every thereafter-th, so bursts of
+// identical entries do not flood the outputs. A thereafter of zero drops
+// all entries after the first initial. Sampling is off by default.
+func WithSampling(initial, thereafter int) ZapOption {
+	return options.OptionFunc[ZapOptions](func(o *ZapOptions) error {
+		if initial <= 0 {
+			return fmt.Errorf("sampling initial must be positive")
+		}
+		if thereafter < 0 {
+			return fmt.Errorf("sampling thereafter cannot be negative")
+		}
+		o.Sampling = &SamplingOptions{Initial: initial, Thereafter: thereafter}
+		return nil
+	})
+}
+
+type Factory struct {
+	zopts []ZapOption // Applied to every logger, before per-call options
+}
+
+func NewFactory() *Factory {
+	return &Factory{}
+}
 
-	return f.createLogger(zopts)
+// NewFactoryWithOptions creates a factory that applies zopts to every
+// logger it creates. Use it to pass Zap options, such as OTLP export, to
+// code that only sees the domain Factory, like bootstrap.NewService.
+func NewFactoryWithOptions(zopts ...ZapOption) *Factory {
+	return &Factory{zopts: zopts}
 }
 
-// NewLoggerWithOptions creates a logger with both domain and Zap options
+func (f *Factory) NewLogger(opts ...domainlog.Option) (domainlog.LeveledLogger, error) {
+	return f.NewLoggerWithOptions(opts, nil)
+}
+
+// NewLoggerWithOptions creates a logger with both domain and Zap options.
+// Options given to NewFactoryWithOptions are applied before zopts.
 func (f *Factory) NewLoggerWithOptions(dopts []domainlog.Option, zopts []ZapOption) (domainlog.LeveledLogger, error) {
 	options := ZapOptions{
 		LoggerOptions: domainlog.LoggerOptions{
@@ -74,7 +263,7 @@ func (f *Factory) NewLoggerWithOptions(dopts []domainlog.Option, zopts []ZapOpti
 	}
 
 	// Apply zap-specific options
-	for _, opt := range zopts {
+	for _, opt := range slices.Concat(f.zopts, zopts) {
 		if err := opt.ApplyOption(&options); err != nil {
 			return nil, fmt.Errorf("applying zap options: %w", err)
 		}
@@ -83,7 +272,13 @@ func (f *Factory) NewLoggerWithOptions(dopts []domainlog.Option, zopts []ZapOpti
 	return f.createLogger(options)
 }
 
-func (f *Factory) createLogger(zopts ZapOptions) (domainlog.LeveledLogger, error) {
+// newSampledCore wraps core to sample repeated entries each second
+func newSampledCore(core zapcore.Core, sampling *zap.SamplingConfig) zapcore.Core {
+	return zapcore.NewSamplerWithOptions(core, time.Second, sampling.Initial, sampling.Thereafter)
+}
+
+// newZapConfig builds the zap configuration for the given options
+func newZapConfig(zopts ZapOptions) zap.Config {
 	encoderConfig := zapcore.EncoderConfig{
 		TimeKey:        "timestamp",
 		LevelKey:       "level",
@@ -94,22 +289,58 @@ func (f *Factory) createLogger(zopts ZapOptions) (domainlog.LeveledLogger, error
 		StacktraceKey:  "stacktrace",
 		LineEnding:     zapcore.DefaultLineEnding,
 		EncodeLevel:    zapcore.LowercaseLevelEncoder,
-		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeTime:     ISO8601TimeEncoder,
 		EncodeDuration: zapcore.StringDurationEncoder,
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 	}
 
+	if zopts.TimeKey != "" {
+		encoderConfig.TimeKey = zopts.TimeKey
+	}
+	if zopts.TimeEncoder != nil {
+		encoderConfig.EncodeTime = zopts.TimeEncoder
+	}
+
+	encoding := zopts.Encoding
+	if encoding == "" {
+		encoding = JSONEncoding
+		if zopts.Development {
+			encoding = ConsoleEncoding
+		}
+	}
+
+	// Colorize levels for console output, but only while developing so
+	// escape codes never end up in collected production logs
+	if encoding == ConsoleEncoding {
+		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		if zopts.Development {
+			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		}
+	}
+
+	outputPaths := []string{"stdout"}
+	if len(zopts.OutputPaths) > 0 {
+		outputPaths = zopts.OutputPaths
+	}
+
 	config := zap.Config{
 		Level:            zap.NewAtomicLevelAt(convertToZapLevel(zopts.Level)),
 		Development:      zopts.Development,
 		Sampling:         nil,
-		Encoding:         "json",
+		Encoding:         encoding,
 		EncoderConfig:    encoderConfig,
-		OutputPaths:      []string{"stdout"},
+		OutputPaths:      outputPaths,
 		ErrorOutputPaths: []string{"stderr"},
 		InitialFields:    make(map[string]interface{}),
 	}
 
+	if zopts.Sampling != nil {
+		config.Sampling = &zap.SamplingConfig{
+			Initial:    zopts.Sampling.Initial,
+			Thereafter: zopts.Sampling.Thereafter,
+		}
+	}
+
 	if zopts.Development {
 		config.Development = true
 		config.DisableStacktrace = false
@@ -118,6 +349,23 @@ func (f *Factory) createLogger(zopts ZapOptions) (domainlog.LeveledLogger, error
 		config.DisableStacktrace = true
 	}
 
+	return config
+}
+
+func (f *Factory) createLogger(zopts ZapOptions) (domainlog.LeveledLogger, error) {
+	config := newZapConfig(zopts)
+
+	// Sample after the extra cores are teed in, so every output keeps the
+	// same entries, rather than in Build
+	sampling := config.Sampling
+	config.Sampling = nil
+
+	// Rotated files are written by lumberjack rather than opened by zap
+	var rotated []string
+	if zopts.Rotation != nil {
+		config.OutputPaths, rotated = splitFileOutputs(config.OutputPaths)
+	}
+
 	logger, err := config.Build(
 		zap.AddCallerSkip(1),
 		zap.AddCaller(),
@@ -126,18 +374,84 @@ func (f *Factory) createLogger(zopts ZapOptions) (domainlog.LeveledLogger, error
 		return nil, fmt.Errorf("building zap logger: %w", err)
 	}
 
+	if len(rotated) > 0 {
+		rotatingCore, err := newRotatingCore(config, rotated, *zopts.Rotation)
+		if err != nil {
+			return nil, err
+		}
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, rotatingCore)
+		}))
+	}
+
+	// Tee entries into each additional sink
+	for _, sink := range zopts.Sinks {
+		sinkCore, err := newSinkCore(zopts, sink, config.Level)
+		if err != nil {
+			return nil, err
+		}
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, sinkCore)
+		}))
+	}
+
+	// Tee entries into an in-memory ring buffer when requested
+	var buffer *logBuffer
+	if zopts.BufferSize > 0 {
+		buffer = newLogBuffer(zopts.BufferSize)
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, newBufferCore(config.Level, buffer))
+		}))
+	}
+
+	// Bridge entries to an OTLP collector when requested
+	var otlpProvider *sdklog.LoggerProvider
+	if zopts.OTLP != nil {
+		otlpProvider, err = newOTLPProvider(zopts)
+		if err != nil {
+			return nil, err
+		}
+		otlpCore := newOTLPCore(config.Level, otlpProvider, zopts.ServiceName)
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, otlpCore)
+		}))
+	}
+
+	if sampling != nil {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return newSampledCore(core, sampling)
+		}))
+	}
+
 	if zopts.ServiceName != "" {
 		logger = logger.With(zap.String("service", zopts.ServiceName))
 	}
 
-	if len(zopts.Fields) > 0 {
-		logger = logger.With(convertFields(zopts.Fields)...)
+	redactKeys := make([]string, 0, len(zopts.RedactedKeys))
+	for _, k := range zopts.RedactedKeys {
+		if k != "" {
+			redactKeys = append(redactKeys, strings.ToLower(k))
+		}
+	}
+
+	fields := redactFields(zopts.Fields, redactKeys)
+	if len(fields) > 0 {
+		logger = logger.With(convertFields(fields)...)
 	}
 
 	return &ZapLogger{
-		logger: logger,
-		level:  zopts.Level,
-		atom:   config.Level,
+		logger:        logger,
+		atom:          config.Level,
+		correlationID: zopts.CorrelationID,
+		buffer:        buffer,
+		redactKeys:    redactKeys,
+		baggageKeys:   zopts.BaggageKeys,
+		otlp:          otlpProvider,
+		settings: &loggerSettings{
+			serviceName: zopts.ServiceName,
+			fields:      fields,
+			encoding:    config.Encoding,
+		},
 	}, nil
 }
 
@@ -157,64 +471,164 @@ func (l *ZapLogger) Error(msg string) {
 	l.logger.Error(msg)
 }
 
+// Fatal logs at fatal level, then exits the process
+func (l *ZapLogger) Fatal(msg string) {
+	l.logger.Fatal(msg)
+}
+
 // Implementation of methods with fields
 func (l *ZapLogger) DebugWith(msg string, fields domainlog.Fields) {
-	l.logger.Debug(msg, convertFields(fields)...)
+	l.logger.Debug(msg, l.fields(fields)...)
 }
 
 func (l *ZapLogger) InfoWith(msg string, fields domainlog.Fields) {
-	l.logger.Info(msg, convertFields(fields)...)
+	l.logger.Info(msg, l.fields(fields)...)
 }
 
 func (l *ZapLogger) WarnWith(msg string, fields domainlog.Fields) {
-	l.logger.Warn(msg, convertFields(fields)...)
+	l.logger.Warn(msg, l.fields(fields)...)
 }
 
 func (l *ZapLogger) ErrorWith(msg string, fields domainlog.Fields) {
-	l.logger.Error(msg, convertFields(fields)...)
+	l.logger.Error(msg, l.fields(fields)...)
+}
+
+// FatalWith logs at fatal level with fields, then exits the process
+func (l *ZapLogger) FatalWith(msg string, fields domainlog.Fields) {
+	l.logger.Fatal(msg, l.fields(fields)...)
 }
 
 func (l *ZapLogger) With(fields domainlog.Fields) domainlog.Logger {
-	return &ZapLogger{
-		logger: l.logger.With(convertFields(fields)...),
-		level:  l.level,
-		atom:   l.atom,
-	}
+	return l.derive(l.logger.With(l.fields(fields)...))
+}
+
+// WithError attaches err as the "error" field. Errors that format
+// differently with %+v, such as those carrying stack traces, also get an
+// "errorVerbose" field.
+func (l *ZapLogger) WithError(err error) domainlog.Logger {
+	return l.derive(l.logger.With(zap.Error(err)))
 }
 
 func (l *ZapLogger) WithContext(ctx context.Context) domainlog.Logger {
+	fields := l.traceFields(ctx)
+	fields = append(fields, l.baggageFields(ctx)...)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.derive(l.logger.With(fields...))
+}
+
+// traceFields returns the active span's identifiers, falling back to a
+// correlation ID when there is no recording span
+func (l *ZapLogger) traceFields(ctx context.Context) []zap.Field {
 	span := trace.SpanFromContext(ctx)
 	if span.IsRecording() {
 		spanCtx := span.SpanContext()
 		if spanCtx.HasTraceID() {
-			logger := l.logger.With(
+			fields := []zap.Field{
 				zap.String("trace_id", spanCtx.TraceID().String()),
 				zap.String("span_id", spanCtx.SpanID().String()),
-			)
-			if spanCtx.IsSampled() {
-				logger = logger.With(zap.Bool("sampled", true))
+				// Detached from ctx so cancellation cannot affect export
+				spanContextField(trace.ContextWithSpanContext(context.Background(), spanCtx)),
 			}
-			return &ZapLogger{
-				logger: logger,
-				level:  l.level,
-				atom:   l.atom,
+			if spanCtx.IsSampled() {
+				fields = append(fields, zap.Bool("sampled", true))
 			}
+			return fields
+		}
+	}
+
+	// Without an active span fall back to a correlation ID if available
+	correlationID := l.correlationID
+	if correlationID == nil {
+		correlationID = domainlog.CorrelationIDFromContext
+	}
+	if id := correlationID(ctx); id != "" {
+		return []zap.Field{zap.String("correlation_id", id)}
+	}
+	return nil
+}
+
+// baggageFields returns the configured baggage members present in ctx
+func (l *ZapLogger) baggageFields(ctx context.Context) []zap.Field {
+	if len(l.baggageKeys) == 0 {
+		return nil
+	}
+
+	bag := baggage.FromContext(ctx)
+	members := domainlog.Fields{}
+	for _, key := range l.baggageKeys {
+		if member := bag.Member(key); member.Key() != "" {
+			members[key] = member.Value()
 		}
 	}
-	return l
+	if len(members) == 0 {
+		return nil
+	}
+	return l.fields(members)
+}
+
+// derive returns a logger sharing l's configuration but writing via logger
+func (l *ZapLogger) derive(logger *zap.Logger) *ZapLogger {
+	return &ZapLogger{
+		logger:        logger,
+		atom:          l.atom,
+		correlationID: l.correlationID,
+		buffer:        l.buffer,
+		redactKeys:    l.redactKeys,
+		baggageKeys:   l.baggageKeys,
+		otlp:          l.otlp,
+		settings:      l.settings,
+	}
+}
+
+// fields converts fields to zap fields, scrubbing redacted keys
+func (l *ZapLogger) fields(fields domainlog.Fields) []zap.Field {
+	return convertFields(redactFields(fields, l.redactKeys))
 }
 
+// SetLevel changes the level of this logger, the logger it was derived
+// from and every other logger derived from the same root
 func (l *ZapLogger) SetLevel(level domainlog.Level) {
-	l.level = level
 	l.atom.SetLevel(convertToZapLevel(level))
 }
 
 func (l *ZapLogger) GetLevel() domainlog.Level {
-	return l.level
+	return convertFromZapLevel(l.atom.Level())
 }
 
+// GetConfigHandler serves the current level on GET as {"level":"info"}
+// and updates it on PUT with the same shape. Unknown levels are rejected
+// with 400.
 func (l *ZapLogger) GetConfigHandler() http.Handler {
-	return l.atom
+	return &levelHandler{logger: l}
+}
+
+// Shutdown flushes entries pending OTLP export and stops the exporter.
+// It is a no-op when OTLP export is not configured.
+func (l *ZapLogger) Shutdown(ctx context.Context) error {
+	if l.otlp == nil {
+		return nil
+	}
+	if err := l.otlp.Shutdown(ctx); err != nil {
+		return fmt.Errorf("shutting down otlp log export: %w", err)
+	}
+	return nil
+}
+
+// GetEffectiveConfigHandler serves the level, service name, default fields
+// and encoding as JSON on GET. PUT updates the level like GetConfigHandler.
+func (l *ZapLogger) GetEffectiveConfigHandler() http.Handler {
+	return &effectiveConfigHandler{logger: l}
+}
+
+// GetLogsHandler serves the buffered log entries as JSON.
+// It responds 404 when the logger was created without a buffer.
+func (l *ZapLogger) GetLogsHandler() http.Handler {
+	if l.buffer == nil {
+		return http.NotFoundHandler()
+	}
+	return l.buffer
 }
 
 func convertToZapLevel(level domainlog.Level) zapcore.Level {
@@ -227,11 +641,30 @@ func convertToZapLevel(level domainlog.Level) zapcore.Level {
 		return zapcore.WarnLevel
 	case domainlog.ErrorLevel:
 		return zapcore.ErrorLevel
+	case domainlog.FatalLevel:
+		return zapcore.FatalLevel
 	default:
 		return zapcore.InfoLevel
 	}
 }
 
+func convertFromZapLevel(level zapcore.Level) domainlog.Level {
+	switch level {
+	case zapcore.DebugLevel:
+		return domainlog.DebugLevel
+	case zapcore.InfoLevel:
+		return domainlog.InfoLevel
+	case zapcore.WarnLevel:
+		return domainlog.WarnLevel
+	case zapcore.ErrorLevel:
+		return domainlog.ErrorLevel
+	case zapcore.FatalLevel:
+		return domainlog.FatalLevel
+	default:
+		return domainlog.InfoLevel
+	}
+}
+
 func convertFields(fields domainlog.Fields) []zap.Field {
 	if len(fields) == 0 {
 		return nil
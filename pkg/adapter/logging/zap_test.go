@@ -3,6 +3,9 @@ package logging
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -10,6 +13,7 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 
 	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
@@ -21,7 +25,6 @@ func newTestLogger(t *testing.T) (*ZapLogger, *observer.ObservedLogs) {
 
 	return &ZapLogger{
 		logger: logger,
-		level:  domainlog.InfoLevel,
 		atom:   zap.NewAtomicLevelAt(zap.InfoLevel),
 	}, obs
 }
@@ -85,6 +88,75 @@ func TestZapLogger_Levels(t *testing.T) {
 	}
 }
 
+func TestZapLogger_MessageForms(t *testing.T) {
+	fields := domainlog.Fields{"key": "value"}
+
+	tests := []struct {
+		name       string
+		log        func(l *ZapLogger)
+		wantLevel  zapcore.Level
+		wantFields bool
+	}{
+		{name: "Debug", log: func(l *ZapLogger) { l.Debug("message") }, wantLevel: zap.DebugLevel},
+		{name: "DebugWith", log: func(l *ZapLogger) { l.DebugWith("message", fields) }, wantLevel: zap.DebugLevel, wantFields: true},
+		{name: "Info", log: func(l *ZapLogger) { l.Info("message") }, wantLevel: zap.InfoLevel},
+		{name: "InfoWith", log: func(l *ZapLogger) { l.InfoWith("message", fields) }, wantLevel: zap.InfoLevel, wantFields: true},
+		{name: "Warn", log: func(l *ZapLogger) { l.Warn("message") }, wantLevel: zap.WarnLevel},
+		{name: "WarnWith", log: func(l *ZapLogger) { l.WarnWith("message", fields) }, wantLevel: zap.WarnLevel, wantFields: true},
+		{name: "Error", log: func(l *ZapLogger) { l.Error("message") }, wantLevel: zap.ErrorLevel},
+		{name: "ErrorWith", log: func(l *ZapLogger) { l.ErrorWith("message", fields) }, wantLevel: zap.ErrorLevel, wantFields: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, obs := observer.New(zap.DebugLevel)
+			logger := &ZapLogger{
+				logger: zap.New(core),
+				atom:   zap.NewAtomicLevelAt(zap.DebugLevel),
+			}
+
+			tt.log(logger)
+
+			logs := obs.All()
+			if assert.Len(t, logs, 1) {
+				assert.Equal(t, "message", logs[0].Message)
+				assert.Equal(t, tt.wantLevel, logs[0].Level)
+				if tt.wantFields {
+					assert.Equal(t, map[string]interface{}{"key": "value"}, logs[0].ContextMap())
+				} else {
+					assert.Empty(t, logs[0].Context)
+				}
+			}
+		})
+	}
+}
+
+func TestZapLogger_Fatal(t *testing.T) {
+	core, obs := observer.New(zap.InfoLevel)
+	// Panic instead of exiting so the test process survives
+	logger := &ZapLogger{
+		logger: zap.New(core, zap.WithFatalHook(zapcore.WriteThenPanic)),
+		atom:   zap.NewAtomicLevelAt(zap.InfoLevel),
+	}
+
+	assert.Panics(t, func() { logger.Fatal("fatal message") })
+	assert.Panics(t, func() {
+		logger.FatalWith("fatal with fields", domainlog.Fields{"error": "boom"})
+	})
+
+	logs := obs.TakeAll()
+	if assert.Equal(t, 2, len(logs)) {
+		assert.Equal(t, zapcore.FatalLevel, logs[0].Level)
+		assert.Equal(t, "fatal message", logs[0].Message)
+		assert.Equal(t, "boom", logs[1].ContextMap()["error"])
+	}
+}
+
+func TestConvertToZapLevel(t *testing.T) {
+	assert.Equal(t, zapcore.FatalLevel, convertToZapLevel(domainlog.FatalLevel))
+	assert.Equal(t, zapcore.InfoLevel, convertToZapLevel(domainlog.Level("unknown")))
+}
+
 func TestZapLogger_With(t *testing.T) {
 	logger, obs := newTestLogger(t)
 
@@ -107,6 +179,51 @@ func TestZapLogger_With(t *testing.T) {
 	assert.Equal(t, true, loggedFields["bool"])
 }
 
+func TestZapLogger_DerivedLoggersShareLevel(t *testing.T) {
+	// Filter on the logger's own level, as the factory-built cores do
+	atom := zap.NewAtomicLevelAt(zap.InfoLevel)
+	core, obs := observer.New(atom)
+	logger := &ZapLogger{logger: zap.New(core), atom: atom}
+
+	// A span makes WithContext derive a new logger
+	provider := sdktrace.NewTracerProvider()
+	ctx, span := provider.Tracer("test").Start(context.Background(), "test-span")
+	defer span.End()
+
+	derived := logger.With(domainlog.Fields{"component": "worker"}).WithContext(ctx).(*ZapLogger)
+
+	derived.Debug("before")
+	assert.Equal(t, 0, obs.Len())
+
+	// Raising verbosity on the root applies to the derived logger
+	logger.SetLevel(domainlog.DebugLevel)
+	assert.Equal(t, domainlog.DebugLevel, derived.GetLevel())
+	derived.Debug("after")
+	assert.Equal(t, 1, obs.Len())
+
+	// Changes made through a derived logger apply to the root
+	derived.SetLevel(domainlog.ErrorLevel)
+	assert.Equal(t, domainlog.ErrorLevel, logger.GetLevel())
+	logger.Info("suppressed")
+	assert.Equal(t, 1, obs.Len())
+}
+
+func TestZapLogger_WithError(t *testing.T) {
+	logger, obs := newTestLogger(t)
+
+	base := errors.New("connection refused")
+	err := fmt.Errorf("dialing database: %w", base)
+
+	logger.WithError(err).Error("failed")
+
+	logs := obs.All()
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, "failed", logs[0].Message)
+
+	loggedFields := logs[0].ContextMap()
+	assert.Equal(t, "dialing database: connection refused", loggedFields["error"])
+}
+
 func TestZapLogger_WithContext(t *testing.T) {
 	logger, obs := newTestLogger(t)
 
@@ -165,7 +282,122 @@ func TestZapLogger_WithContext(t *testing.T) {
 		if assert.Equal(t, 1, len(spans), "should have one recorded span") {
 			assert.Equal(t, "test-span", spans[0].Name())
 		}
+
+		// Clear the observer for next test
+		obs.TakeAll()
+	})
+
+	t.Run("with correlation id and no span", func(t *testing.T) {
+		ctx := domainlog.ContextWithCorrelationID(context.Background(), "corr-123")
+		logger.WithContext(ctx).Info("correlated message")
+
+		logs := obs.TakeAll()
+		if assert.Equal(t, 1, len(logs), "should have one log message") {
+			loggedFields := logs[0].ContextMap()
+			assert.Equal(t, "corr-123", loggedFields["correlation_id"])
+			assert.NotContains(t, loggedFields, "trace_id")
+		}
 	})
+
+	t.Run("with custom correlation id func", func(t *testing.T) {
+		custom := *logger
+		custom.correlationID = func(ctx context.Context) string {
+			id, _ := ctx.Value(testContextKey{}).(string)
+			return id
+		}
+
+		ctx := context.WithValue(context.Background(), testContextKey{}, "req-456")
+		custom.WithContext(ctx).Info("correlated message")
+
+		logs := obs.TakeAll()
+		if assert.Equal(t, 1, len(logs), "should have one log message") {
+			assert.Equal(t, "req-456", logs[0].ContextMap()["correlation_id"])
+		}
+	})
+}
+
+type testContextKey struct{}
+
+func TestNewZapConfig_Encoding(t *testing.T) {
+	tests := []struct {
+		name         string
+		zopts        []ZapOption
+		wantEncoding string
+		wantLevelEnc zapcore.LevelEncoder
+	}{
+		{
+			name:         "production defaults to json",
+			wantEncoding: JSONEncoding,
+			wantLevelEnc: zapcore.LowercaseLevelEncoder,
+		},
+		{
+			name:         "development defaults to colored console",
+			zopts:        []ZapOption{WithDevelopment(true)},
+			wantEncoding: ConsoleEncoding,
+			wantLevelEnc: zapcore.CapitalColorLevelEncoder,
+		},
+		{
+			name:         "console in production is not colored",
+			zopts:        []ZapOption{WithEncoding(ConsoleEncoding)},
+			wantEncoding: ConsoleEncoding,
+			wantLevelEnc: zapcore.CapitalLevelEncoder,
+		},
+		{
+			name:         "json overrides development default",
+			zopts:        []ZapOption{WithDevelopment(true), WithEncoding(JSONEncoding)},
+			wantEncoding: JSONEncoding,
+			wantLevelEnc: zapcore.LowercaseLevelEncoder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var zopts ZapOptions
+			for _, opt := range tt.zopts {
+				assert.NoError(t, opt.ApplyOption(&zopts))
+			}
+
+			config := newZapConfig(zopts)
+			assert.Equal(t, tt.wantEncoding, config.Encoding)
+			assert.Equal(t,
+				reflect.ValueOf(tt.wantLevelEnc).Pointer(),
+				reflect.ValueOf(config.EncoderConfig.EncodeLevel).Pointer())
+		})
+	}
+}
+
+func TestWithEncoding_Invalid(t *testing.T) {
+	var zopts ZapOptions
+	assert.EqualError(t, WithEncoding("xml").ApplyOption(&zopts), "invalid encoding: xml")
+}
+
+func TestWithSampling(t *testing.T) {
+	var zopts ZapOptions
+	assert.Nil(t, newZapConfig(zopts).Sampling, "sampling is off by default")
+
+	assert.NoError(t, WithSampling(100, 10).ApplyOption(&zopts))
+	assert.Equal(t, &zap.SamplingConfig{Initial: 100, Thereafter: 10}, newZapConfig(zopts).Sampling)
+
+	assert.EqualError(t, WithSampling(0, 10).ApplyOption(&zopts), "sampling initial must be positive")
+	assert.EqualError(t, WithSampling(1, -1).ApplyOption(&zopts), "sampling thereafter cannot be negative")
+}
+
+func TestZapLogger_Sampling(t *testing.T) {
+	core, obs := observer.New(zap.InfoLevel)
+	sampled := newSampledCore(core, &zap.SamplingConfig{Initial: 3, Thereafter: 10})
+	logger := &ZapLogger{
+		logger: zap.New(sampled),
+		atom:   zap.NewAtomicLevelAt(zap.InfoLevel),
+	}
+
+	for i := 0; i < 100; i++ {
+		logger.Info("repeated")
+	}
+	logger.Info("distinct")
+
+	// The first 3, then the 13th, 23rd ... 93rd repeat
+	assert.Equal(t, 12, obs.FilterMessage("repeated").Len())
+	assert.Equal(t, 1, obs.FilterMessage("distinct").Len())
 }
 
 func TestFactory_NewLogger(t *testing.T) {
@@ -197,6 +429,22 @@ func TestFactory_NewLogger(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "with console encoding",
+			opts: nil,
+			zopts: []ZapOption{
+				WithEncoding(ConsoleEncoding),
+			},
+			wantErr: false,
+		},
+		{
+			name: "with invalid encoding",
+			opts: nil,
+			zopts: []ZapOption{
+				WithEncoding("xml"),
+			},
+			wantErr: true,
+		},
 	}
 
 	factory := NewFactory()
@@ -0,0 +1,120 @@
+// pkg/adapter/metrics/custom.go
+package metrics
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/damianoneill/go-bootstrap/pkg/domain/metrics"
+)
+
+// Metric kinds for custom metrics
+const (
+	counterKind   = "counter"
+	gaugeKind     = "gauge"
+	histogramKind = "histogram"
+)
+
+// customMetric is a lazily registered business metric
+type customMetric struct {
+	kind      string
+	labels    []string
+	collector prometheus.Collector
+}
+
+func (c *prometheusCollector) Counter(name string, labels ...string) metrics.CounterHandle {
+	vec := c.custom(name, counterKind, labels, func() prometheus.Collector {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        name,
+			Help:        name,
+			ConstLabels: c.constLabels,
+		}, labels)
+	})
+	return counterHandle{vec: vec.(*prometheus.CounterVec)}
+}
+
+func (c *prometheusCollector) Gauge(name string, labels ...string) metrics.GaugeHandle {
+	vec := c.custom(name, gaugeKind, labels, func() prometheus.Collector {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        name,
+			Help:        name,
+			ConstLabels: c.constLabels,
+		}, labels)
+	})
+	return gaugeHandle{vec: vec.(*prometheus.GaugeVec)}
+}
+
+func (c *prometheusCollector) Histogram(name string, labels ...string) metrics.HistogramHandle {
+	vec := c.custom(name, histogramKind, labels, func() prometheus.Collector {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        name,
+			Help:        name,
+			Buckets:     c.buckets,
+			ConstLabels: c.constLabels,
+		}, labels)
+	})
+	return histogramHandle{vec: vec.(*prometheus.HistogramVec)}
+}
+
+// custom returns the named metric, creating and registering it on first use.
+// Requesting an existing name as a different kind or with different labels
+// is a programming error and panics, as prometheus.MustRegister does.
+func (c *prometheusCollector) custom(name, kind string, labels []string, create func() prometheus.Collector) prometheus.Collector {
+	c.customMu.Lock()
+	defer c.customMu.Unlock()
+
+	if existing, ok := c.customs[name]; ok {
+		if existing.kind != kind || !slices.Equal(existing.labels, labels) {
+			panic(fmt.Sprintf("metric %s already registered as %s with labels %v",
+				name, existing.kind, existing.labels))
+		}
+		return existing.collector
+	}
+
+	// Reuses a compatible metric registered by another collector
+	collector, err := registerShared(c.reg, create())
+	if err != nil {
+		panic(fmt.Sprintf("registering metric %s: %v", name, err))
+	}
+
+	c.customs[name] = customMetric{
+		kind:      kind,
+		labels:    slices.Clone(labels),
+		collector: collector,
+	}
+	return collector
+}
+
+type counterHandle struct {
+	vec *prometheus.CounterVec
+}
+
+func (h counterHandle) Inc(labelValues ...string) {
+	h.vec.WithLabelValues(labelValues...).Inc()
+}
+
+func (h counterHandle) Add(value float64, labelValues ...string) {
+	h.vec.WithLabelValues(labelValues...).Add(value)
+}
+
+type gaugeHandle struct {
+	vec *prometheus.GaugeVec
+}
+
+func (h gaugeHandle) Set(value float64, labelValues ...string) {
+	h.vec.WithLabelValues(labelValues...).Set(value)
+}
+
+func (h gaugeHandle) Add(value float64, labelValues ...string) {
+	h.vec.WithLabelValues(labelValues...).Add(value)
+}
+
+type histogramHandle struct {
+	vec *prometheus.HistogramVec
+}
+
+func (h histogramHandle) Observe(value float64, labelValues ...string) {
+	h.vec.WithLabelValues(labelValues...).Observe(value)
+}
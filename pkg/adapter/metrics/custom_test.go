@@ -0,0 +1,84 @@
+// pkg/adapter/metrics/custom_test.go
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/damianoneill/go-bootstrap/pkg/domain/metrics"
+)
+
+func newCustomTestCollector(t *testing.T) (metrics.Collector, *prometheus.Registry) {
+	t.Helper()
+
+	registry := prometheus.NewRegistry()
+	collector, err := NewMetricsFactoryWithRegistry(registry).NewCollector(
+		metrics.WithServiceName("test-service"),
+		metrics.WithBuckets([]float64{1, 5, 10}),
+	)
+	assert.NoError(t, err)
+	return collector, registry
+}
+
+func TestPrometheusCollectorCustomMetrics(t *testing.T) {
+	collector, registry := newCustomTestCollector(t)
+
+	created := collector.Counter("todos_created_total", "priority")
+	created.Inc("high")
+	created.Add(2, "low")
+
+	// The same name returns the same counter
+	collector.Counter("todos_created_total", "priority").Inc("high")
+
+	open := collector.Gauge("todos_open")
+	open.Set(5)
+	open.Add(-2)
+
+	duration := collector.Histogram("todo_completion_seconds")
+	duration.Observe(3)
+	duration.Observe(7)
+
+	expected := `
+# HELP todos_created_total todos_created_total
+# TYPE todos_created_total counter
+todos_created_total{priority="high",service="test-service"} 2
+todos_created_total{priority="low",service="test-service"} 2
+# HELP todos_open todos_open
+# TYPE todos_open gauge
+todos_open{service="test-service"} 3
+# HELP todo_completion_seconds todo_completion_seconds
+# TYPE todo_completion_seconds histogram
+todo_completion_seconds_bucket{service="test-service",le="1"} 0
+todo_completion_seconds_bucket{service="test-service",le="5"} 1
+todo_completion_seconds_bucket{service="test-service",le="10"} 2
+todo_completion_seconds_bucket{service="test-service",le="+Inf"} 2
+todo_completion_seconds_sum{service="test-service"} 10
+todo_completion_seconds_count{service="test-service"} 2
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"todos_created_total", "todos_open", "todo_completion_seconds"))
+
+	// Close unregisters the custom metrics along with the built-in ones
+	assert.NoError(t, collector.Close())
+	count, err := testutil.GatherAndCount(registry)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestPrometheusCollectorCustomMetricConflicts(t *testing.T) {
+	collector, _ := newCustomTestCollector(t)
+	defer collector.Close()
+
+	collector.Counter("jobs_total", "queue")
+
+	assert.Panics(t, func() { collector.Gauge("jobs_total", "queue") },
+		"same name as a different kind")
+	assert.Panics(t, func() { collector.Counter("jobs_total", "worker") },
+		"same name with different labels")
+	assert.Panics(t, func() { collector.Counter("http_requests_total") },
+		"name clashes with a built-in metric")
+}
@@ -2,27 +2,46 @@
 package metrics
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 
 	"github.com/damianoneill/go-bootstrap/pkg/domain/metrics"
+	domainoptions "github.com/damianoneill/go-bootstrap/pkg/domain/options"
 )
 
 type prometheusCollector struct {
 	requestDuration *prometheus.HistogramVec
 	requestsTotal   *prometheus.CounterVec
 	errorsTotal     *prometheus.CounterVec
+	clientRequests  *prometheus.CounterVec
+	methodClass     bool // Whether request metrics carry a method_class label
 	reg             prometheus.Registerer
+	gatherer        prometheus.Gatherer
 	mu              sync.RWMutex
+	constLabels     prometheus.Labels       // Applied to custom metrics
+	buckets         []float64               // Applied to custom histograms
+	customMu        sync.Mutex              // Guards customs
+	customs         map[string]customMetric // Custom metrics by name
 }
 
 func NewMetricsFactory() metrics.Factory {
 	return &PrometheusFactory{}
 }
 
-type PrometheusFactory struct{}
+// NewMetricsFactoryWithRegistry creates a factory whose collectors register
+// against the given registry instead of the global default registry
+func NewMetricsFactoryWithRegistry(registry *prometheus.Registry) metrics.Factory {
+	return &PrometheusFactory{registry: registry}
+}
+
+type PrometheusFactory struct {
+	registry *prometheus.Registry
+}
 
 func (f *PrometheusFactory) NewCollector(opts ...metrics.Option) (metrics.Collector, error) {
 	options := metrics.DefaultOptions()
@@ -34,7 +53,7 @@ func (f *PrometheusFactory) NewCollector(opts ...metrics.Option) (metrics.Collec
 
 	// Validate options
 	if options.ServiceName == "" {
-		return nil, fmt.Errorf("service name is required")
+		return nil, domainoptions.Invalid("ServiceName", "service name is required")
 	}
 
 	labels := prometheus.Labels{
@@ -56,8 +75,20 @@ func (f *PrometheusFactory) NewCollector(opts ...metrics.Option) (metrics.Collec
 		}
 	}
 
+	reg, gatherer := f.registries()
+
+	requestLabels := []string{"method", "path", "status"}
+	if options.MethodClass {
+		requestLabels = append(requestLabels, "method_class")
+	}
+
 	c := &prometheusCollector{
-		reg: prometheus.DefaultRegisterer,
+		methodClass: options.MethodClass,
+		reg:         reg,
+		gatherer:    gatherer,
+		constLabels: labels,
+		buckets:     buckets,
+		customs:     make(map[string]customMetric),
 		requestDuration: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Name:        "http_request_duration_seconds",
@@ -65,7 +96,7 @@ func (f *PrometheusFactory) NewCollector(opts ...metrics.Option) (metrics.Collec
 				Buckets:     buckets,
 				ConstLabels: labels,
 			},
-			[]string{"method", "path", "status"},
+			requestLabels,
 		),
 		requestsTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
@@ -73,7 +104,7 @@ func (f *PrometheusFactory) NewCollector(opts ...metrics.Option) (metrics.Collec
 				Help:        "Total number of HTTP requests",
 				ConstLabels: labels,
 			},
-			[]string{"method", "path", "status"},
+			requestLabels,
 		),
 		errorsTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
@@ -81,30 +112,148 @@ func (f *PrometheusFactory) NewCollector(opts ...metrics.Option) (metrics.Collec
 				Help:        "Total number of HTTP errors",
 				ConstLabels: labels,
 			},
-			[]string{"method", "path", "status"},
+			requestLabels,
+		),
+		clientRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "http_requests_by_client",
+				Help:        "Total number of HTTP requests by client type",
+				ConstLabels: labels,
+			},
+			[]string{"client"},
 		),
 	}
 
-	// Register all collectors
-	collectors := []prometheus.Collector{
-		c.requestDuration,
-		c.requestsTotal,
-		c.errorsTotal,
+	// Register all collectors, reusing identical ones already registered
+	// by another collector, such as a second service in the same process
+	var err error
+	if c.requestDuration, err = registerShared(c.reg, c.requestDuration); err != nil {
+		return nil, fmt.Errorf("registering collector: %w", err)
+	}
+	if c.requestsTotal, err = registerShared(c.reg, c.requestsTotal); err != nil {
+		c.release()
+		return nil, fmt.Errorf("registering collector: %w", err)
+	}
+	if c.errorsTotal, err = registerShared(c.reg, c.errorsTotal); err != nil {
+		c.release()
+		return nil, fmt.Errorf("registering collector: %w", err)
+	}
+	if c.clientRequests, err = registerShared(c.reg, c.clientRequests); err != nil {
+		c.release()
+		return nil, fmt.Errorf("registering collector: %w", err)
 	}
 
-	for _, collector := range collectors {
-		if err := c.reg.Register(collector); err != nil {
-			// Clean up any already registered collectors
-			for _, col := range collectors {
-				c.reg.Unregister(col)
-			}
-			return nil, fmt.Errorf("registering collector: %w", err)
+	if options.RuntimeMetrics {
+		if err := registerRuntimeCollectors(c.reg); err != nil {
+			c.Close()
+			return nil, err
 		}
 	}
 
 	return c, nil
 }
 
+// sharedRefs counts the collectors using each registered metric, so a
+// metric shared by several collectors is unregistered when the last closes
+var sharedRefs = struct {
+	sync.Mutex
+	counts map[prometheus.Collector]int
+}{counts: make(map[prometheus.Collector]int)}
+
+// registerShared registers col, or returns the compatible collector already
+// registered under the same descriptors. Existing collectors registered
+// outside this package are reused but never unregistered.
+func registerShared[T prometheus.Collector](reg prometheus.Registerer, col T) (T, error) {
+	sharedRefs.Lock()
+	defer sharedRefs.Unlock()
+
+	err := reg.Register(col)
+	if err == nil {
+		sharedRefs.counts[col] = 1
+		return col, nil
+	}
+
+	var are prometheus.AlreadyRegisteredError
+	if !errors.As(err, &are) {
+		return col, err
+	}
+	existing, ok := are.ExistingCollector.(T)
+	if !ok {
+		return col, fmt.Errorf("existing collector %T is incompatible: %w", are.ExistingCollector, err)
+	}
+	if _, tracked := sharedRefs.counts[existing]; tracked {
+		sharedRefs.counts[existing]++
+	}
+	return existing, nil
+}
+
+// releaseShared drops a reference taken by registerShared, unregistering
+// col once no collector uses it
+func releaseShared(reg prometheus.Registerer, col prometheus.Collector) {
+	sharedRefs.Lock()
+	defer sharedRefs.Unlock()
+
+	n, tracked := sharedRefs.counts[col]
+	if !tracked {
+		return
+	}
+	if n > 1 {
+		sharedRefs.counts[col] = n - 1
+		return
+	}
+	delete(sharedRefs.counts, col)
+	reg.Unregister(col)
+}
+
+// release drops this collector's references to its HTTP metrics. Fields
+// not yet registered are ignored, as they were never counted.
+func (c *prometheusCollector) release() {
+	releaseShared(c.reg, c.requestDuration)
+	releaseShared(c.reg, c.requestsTotal)
+	releaseShared(c.reg, c.errorsTotal)
+	releaseShared(c.reg, c.clientRequests)
+}
+
+// registerRuntimeCollectors registers the Go runtime and process collectors.
+// These are process-wide, so an existing registration (the default registry
+// has one, as does any registry shared with an earlier collector) is reused.
+// They are left registered on Close for the same reason.
+func registerRuntimeCollectors(reg prometheus.Registerer) error {
+	runtimeCollectors := []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	}
+
+	for _, collector := range runtimeCollectors {
+		if err := reg.Register(collector); err != nil {
+			var are prometheus.AlreadyRegisteredError
+			if !errors.As(err, &are) {
+				return fmt.Errorf("registering runtime collector: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// registries returns the registerer and gatherer collectors should use
+func (f *PrometheusFactory) registries() (prometheus.Registerer, prometheus.Gatherer) {
+	if f.registry != nil {
+		return f.registry, f.registry
+	}
+
+	// Gather from the default registerer when it is also a gatherer,
+	// which keeps the two in step if the default has been replaced
+	if g, ok := prometheus.DefaultRegisterer.(prometheus.Gatherer); ok {
+		return prometheus.DefaultRegisterer, g
+	}
+	return prometheus.DefaultRegisterer, prometheus.DefaultGatherer
+}
+
+// Gatherer returns the gatherer exposing this collector's metrics
+func (c *prometheusCollector) Gatherer() prometheus.Gatherer {
+	return c.gatherer
+}
+
 func (c *prometheusCollector) CollectRequestMetrics(method, path string, status int, duration float64) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -114,6 +263,9 @@ func (c *prometheusCollector) CollectRequestMetrics(method, path string, status
 		"path":   path,
 		"status": fmt.Sprintf("%d", status),
 	}
+	if c.methodClass {
+		labels["method_class"] = methodClass(method)
+	}
 
 	c.requestDuration.With(labels).Observe(duration)
 	c.requestsTotal.With(labels).Inc()
@@ -123,13 +275,36 @@ func (c *prometheusCollector) CollectRequestMetrics(method, path string, status
 	}
 }
 
+// methodClass returns "read" for safe HTTP methods and "write" otherwise
+func methodClass(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return "read"
+	default:
+		return "write"
+	}
+}
+
+// CollectClientRequest implements metrics.ClientCollector
+func (c *prometheusCollector) CollectClientRequest(client string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	c.clientRequests.WithLabelValues(client).Inc()
+}
+
 func (c *prometheusCollector) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.reg.Unregister(c.requestDuration)
-	c.reg.Unregister(c.requestsTotal)
-	c.reg.Unregister(c.errorsTotal)
+	c.release()
+
+	c.customMu.Lock()
+	defer c.customMu.Unlock()
+	for name, m := range c.customs {
+		releaseShared(c.reg, m.collector)
+		delete(c.customs, name)
+	}
 
 	return nil
 }
@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/damianoneill/go-bootstrap/pkg/domain/metrics"
+	domainoptions "github.com/damianoneill/go-bootstrap/pkg/domain/options"
 )
 
 func TestPrometheusFactory(t *testing.T) {
@@ -164,3 +166,163 @@ func TestPrometheusCollectorErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestPrometheusCollectorValidationError(t *testing.T) {
+	_, err := NewMetricsFactoryWithRegistry(prometheus.NewRegistry()).NewCollector(metrics.WithServiceName(""))
+
+	var verr *domainoptions.ValidationError
+	if assert.ErrorAs(t, err, &verr) {
+		assert.Equal(t, "ServiceName", verr.Field)
+	}
+}
+
+// TestPrometheusCollectorRuntimeMetrics tests Go runtime and process metrics
+func TestPrometheusCollectorRuntimeMetrics(t *testing.T) {
+	gathered := func(t *testing.T, registry *prometheus.Registry) map[string]bool {
+		t.Helper()
+		families, err := registry.Gather()
+		assert.NoError(t, err)
+		names := make(map[string]bool)
+		for _, family := range families {
+			names[family.GetName()] = true
+		}
+		return names
+	}
+
+	t.Run("registers runtime metrics when enabled", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		factory := NewMetricsFactoryWithRegistry(registry)
+
+		collector, err := factory.NewCollector(
+			metrics.WithServiceName("test"),
+			metrics.WithRuntimeMetrics(true),
+		)
+		assert.NoError(t, err)
+
+		names := gathered(t, registry)
+		assert.True(t, names["go_goroutines"])
+		assert.True(t, names["go_gc_duration_seconds"])
+
+		// A second collector on the same registry reuses the registration
+		second, err := factory.NewCollector(
+			metrics.WithServiceName("other"),
+			metrics.WithRuntimeMetrics(true),
+		)
+		assert.NoError(t, err)
+
+		assert.NoError(t, collector.Close())
+		assert.True(t, gathered(t, registry)["go_goroutines"],
+			"runtime metrics are process-wide and outlive the collector")
+		assert.NoError(t, second.Close())
+	})
+
+	t.Run("omits runtime metrics by default", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		collector, err := NewMetricsFactoryWithRegistry(registry).NewCollector(
+			metrics.WithServiceName("test"),
+		)
+		assert.NoError(t, err)
+		defer collector.Close()
+
+		assert.False(t, gathered(t, registry)["go_goroutines"])
+	})
+
+	t.Run("tolerates the default registry's runtime collectors", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(collectors.NewGoCollector())
+
+		collector, err := NewMetricsFactoryWithRegistry(registry).NewCollector(
+			metrics.WithServiceName("test"),
+			metrics.WithRuntimeMetrics(true),
+		)
+		assert.NoError(t, err)
+		defer collector.Close()
+
+		assert.True(t, gathered(t, registry)["go_goroutines"])
+	})
+}
+
+// TestPrometheusCollectorSharedRegistration tests collectors with the same
+// service name reusing one registration, as when several services run in
+// one process
+func TestPrometheusCollectorSharedRegistration(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	factory := NewMetricsFactoryWithRegistry(registry)
+
+	first, err := factory.NewCollector(metrics.WithServiceName("test"))
+	assert.NoError(t, err)
+	second, err := factory.NewCollector(metrics.WithServiceName("test"))
+	assert.NoError(t, err, "compatible collectors are reused rather than failing")
+
+	first.CollectRequestMetrics("GET", "/test", 200, 0.1)
+	second.CollectRequestMetrics("GET", "/test", 200, 0.1)
+
+	requests := func() float64 {
+		families, err := registry.Gather()
+		assert.NoError(t, err)
+		for _, family := range families {
+			if family.GetName() == "http_requests_total" {
+				return family.GetMetric()[0].GetCounter().GetValue()
+			}
+		}
+		return 0
+	}
+	assert.Equal(t, float64(2), requests(), "both collectors record to the shared metric")
+
+	// The metric stays registered until the last collector closes
+	assert.NoError(t, first.Close())
+	second.CollectRequestMetrics("GET", "/test", 200, 0.1)
+	assert.Equal(t, float64(3), requests())
+
+	assert.NoError(t, second.Close())
+	assert.Zero(t, requests())
+}
+
+// TestPrometheusCollectorMethodClass tests the read/write method_class label
+func TestPrometheusCollectorMethodClass(t *testing.T) {
+	classes := func(t *testing.T, opts ...metrics.Option) map[string]string {
+		t.Helper()
+		registry := prometheus.NewRegistry()
+		collector, err := NewMetricsFactoryWithRegistry(registry).NewCollector(
+			append([]metrics.Option{metrics.WithServiceName("test")}, opts...)...)
+		assert.NoError(t, err)
+		defer collector.Close()
+
+		collector.CollectRequestMetrics("GET", "/todos", 200, 0.1)
+		collector.CollectRequestMetrics("POST", "/todos", 201, 0.2)
+
+		families, err := registry.Gather()
+		assert.NoError(t, err)
+
+		// Maps each method to its method_class label, empty when absent
+		byMethod := make(map[string]string)
+		for _, family := range families {
+			if family.GetName() != "http_request_duration_seconds" {
+				continue
+			}
+			for _, m := range family.GetMetric() {
+				var method, class string
+				for _, label := range m.GetLabel() {
+					switch label.GetName() {
+					case "method":
+						method = label.GetValue()
+					case "method_class":
+						class = label.GetValue()
+					}
+				}
+				byMethod[method] = class
+			}
+		}
+		return byMethod
+	}
+
+	t.Run("adds method class when enabled", func(t *testing.T) {
+		got := classes(t, metrics.WithMethodClass(true))
+		assert.Equal(t, map[string]string{"GET": "read", "POST": "write"}, got)
+	})
+
+	t.Run("exact method only by default", func(t *testing.T) {
+		got := classes(t)
+		assert.Equal(t, map[string]string{"GET": "", "POST": ""}, got)
+	})
+}
@@ -0,0 +1,108 @@
+// pkg/adapter/tracing/env.go
+
+package tracing
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/damianoneill/go-bootstrap/pkg/domain/tracing"
+)
+
+// Standard OpenTelemetry exporter environment variables. The traces
+// specific variable takes precedence over the general one.
+const (
+	envEndpoint       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	envTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	envHeaders        = "OTEL_EXPORTER_OTLP_HEADERS"
+	envTracesHeaders  = "OTEL_EXPORTER_OTLP_TRACES_HEADERS"
+	envProtocol       = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	envTracesProtocol = "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"
+)
+
+// applyEnvConfig fills the collector endpoint, headers and exporter type
+// from the environment where opts leaves them unset
+func applyEnvConfig(opts *tracing.Options) error {
+	if opts.CollectorEndpoint == "" {
+		if endpoint := lookupEnv(envTracesEndpoint, envEndpoint); endpoint != "" {
+			host, insecure, err := parseEnvEndpoint(endpoint)
+			if err != nil {
+				return err
+			}
+			opts.CollectorEndpoint = host
+			opts.Insecure = opts.Insecure || insecure
+		}
+	}
+
+	if len(opts.Headers) == 0 {
+		if headers := lookupEnv(envTracesHeaders, envHeaders); headers != "" {
+			parsed, err := parseEnvHeaders(headers)
+			if err != nil {
+				return err
+			}
+			opts.Headers = parsed
+		}
+	}
+
+	if opts.ExporterType == "" {
+		switch protocol := lookupEnv(envTracesProtocol, envProtocol); protocol {
+		case "":
+		case "grpc":
+			opts.ExporterType = tracing.GRPCExporter
+		case "http/protobuf":
+			opts.ExporterType = tracing.HTTPExporter
+		default:
+			return fmt.Errorf("unsupported OTLP protocol: %s", protocol)
+		}
+	}
+
+	return nil
+}
+
+// lookupEnv returns the value of the first non-empty variable
+func lookupEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// parseEnvEndpoint returns the host and port of an endpoint URL and
+// whether it uses plaintext http. The exporter's default path is used.
+func parseEnvEndpoint(endpoint string) (host string, insecure bool, err error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return "", false, fmt.Errorf("invalid OTLP endpoint: %s", endpoint)
+	}
+	switch u.Scheme {
+	case "http":
+		return u.Host, true, nil
+	case "https":
+		return u.Host, false, nil
+	default:
+		return "", false, fmt.Errorf("invalid OTLP endpoint scheme: %s", endpoint)
+	}
+}
+
+// parseEnvHeaders parses comma-separated key=value pairs with URL-encoded
+// values, as defined for OTEL_EXPORTER_OTLP_HEADERS
+func parseEnvHeaders(value string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid OTLP header: %s", pair)
+		}
+		decoded, err := url.QueryUnescape(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("invalid OTLP header value for %s: %w", key, err)
+		}
+		headers[key] = decoded
+	}
+	return headers, nil
+}
@@ -0,0 +1,118 @@
+// pkg/adapter/tracing/env_test.go
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/damianoneill/go-bootstrap/pkg/domain/tracing"
+)
+
+func TestApplyEnvConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		opts    tracing.Options
+		want    tracing.Options
+		wantErr string
+	}{
+		{
+			name: "general variables",
+			env: map[string]string{
+				envEndpoint: "http://collector:4318",
+				envHeaders:  "api-key=secret,x-tenant=team%20a",
+				envProtocol: "http/protobuf",
+			},
+			want: tracing.Options{
+				CollectorEndpoint: "collector:4318",
+				Insecure:          true,
+				Headers:           map[string]string{"api-key": "secret", "x-tenant": "team a"},
+				ExporterType:      tracing.HTTPExporter,
+			},
+		},
+		{
+			name: "traces variables take precedence",
+			env: map[string]string{
+				envEndpoint:       "http://collector:4318",
+				envTracesEndpoint: "https://traces.example.com:4317",
+				envProtocol:       "http/protobuf",
+				envTracesProtocol: "grpc",
+			},
+			want: tracing.Options{
+				CollectorEndpoint: "traces.example.com:4317",
+				ExporterType:      tracing.GRPCExporter,
+			},
+		},
+		{
+			name: "explicit options win",
+			env: map[string]string{
+				envEndpoint: "http://collector:4318",
+				envHeaders:  "api-key=from-env",
+				envProtocol: "grpc",
+			},
+			opts: tracing.Options{
+				CollectorEndpoint: "explicit:4318",
+				Headers:           map[string]string{"api-key": "explicit"},
+				ExporterType:      tracing.HTTPExporter,
+			},
+			want: tracing.Options{
+				CollectorEndpoint: "explicit:4318",
+				Headers:           map[string]string{"api-key": "explicit"},
+				ExporterType:      tracing.HTTPExporter,
+			},
+		},
+		{
+			name: "no variables",
+		},
+		{
+			name:    "endpoint without scheme",
+			env:     map[string]string{envEndpoint: "collector:4318"},
+			wantErr: "invalid OTLP endpoint",
+		},
+		{
+			name:    "malformed header",
+			env:     map[string]string{envHeaders: "api-key"},
+			wantErr: "invalid OTLP header: api-key",
+		},
+		{
+			name:    "unsupported protocol",
+			env:     map[string]string{envProtocol: "http/json"},
+			wantErr: "unsupported OTLP protocol: http/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{
+				envEndpoint, envTracesEndpoint, envHeaders, envTracesHeaders, envProtocol, envTracesProtocol,
+			} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			opts := tt.opts
+			err := applyEnvConfig(&opts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, opts)
+		})
+	}
+}
+
+func TestNewProviderEnvConfig(t *testing.T) {
+	t.Setenv(envProtocol, "http/json")
+
+	// The environment is only read when asked for
+	provider, err := NewFactory().NewProvider(tracing.WithServiceName("test"))
+	require.NoError(t, err)
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	_, err = NewFactory().NewProvider(tracing.WithServiceName("test"), tracing.WithEnvConfig())
+	assert.ErrorContains(t, err, "unsupported OTLP protocol")
+}
@@ -7,9 +7,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
@@ -18,14 +20,16 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.opentelemetry.io/otel/trace"
 
+	domainoptions "github.com/damianoneill/go-bootstrap/pkg/domain/options"
 	"github.com/damianoneill/go-bootstrap/pkg/domain/tracing"
 )
 
 // Provider implements the domain Provider interface using OpenTelemetry
 type Provider struct {
-	provider *sdktrace.TracerProvider
-	tracer   trace.Tracer
-	enabled  bool
+	provider   *sdktrace.TracerProvider
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	enabled    bool
 }
 
 // Factory creates OpenTelemetry-based Provider instances
@@ -40,7 +44,6 @@ func NewFactory() tracing.Factory {
 func (f *Factory) NewProvider(opts ...tracing.Option) (tracing.Provider, error) {
 	// Initialize default options
 	options := &tracing.Options{
-		ExporterType: tracing.HTTPExporter,
 		SamplingRate: 1.0,
 	}
 
@@ -51,9 +54,19 @@ func (f *Factory) NewProvider(opts ...tracing.Option) (tracing.Provider, error)
 		}
 	}
 
+	// Environment fills only what options left unset
+	if options.EnvConfig {
+		if err := applyEnvConfig(options); err != nil {
+			return nil, fmt.Errorf("reading environment config: %w", err)
+		}
+	}
+	if options.ExporterType == "" {
+		options.ExporterType = tracing.HTTPExporter
+	}
+
 	// Validate required fields
 	if options.ServiceName == "" {
-		return nil, fmt.Errorf("service name is required")
+		return nil, domainoptions.Invalid("ServiceName", "service name is required")
 	}
 
 	// Return noop provider if using NoopExporter
@@ -84,15 +97,16 @@ func (f *Factory) NewProvider(opts ...tracing.Option) (tracing.Provider, error)
 	otel.SetTracerProvider(tp)
 
 	// Configure propagators
-	f.setupPropagators(options)
+	propagator := f.setupPropagators(options)
 
 	// Create tracer
 	tracer := tp.Tracer(options.ServiceName)
 
 	return &Provider{
-		provider: tp,
-		tracer:   tracer,
-		enabled:  true,
+		provider:   tp,
+		tracer:     tracer,
+		propagator: propagator,
+		enabled:    true,
 	}, nil
 }
 
@@ -111,6 +125,30 @@ func (p *Provider) Shutdown(ctx context.Context) error {
 	return p.provider.Shutdown(ctx)
 }
 
+// ForceFlush implements Provider.ForceFlush
+func (p *Provider) ForceFlush(ctx context.Context) error {
+	if !p.enabled || p.provider == nil {
+		return nil
+	}
+	return p.provider.ForceFlush(ctx)
+}
+
+// Extract implements Provider.Extract
+func (p *Provider) Extract(ctx context.Context, carrier map[string]string) context.Context {
+	if !p.enabled || p.propagator == nil {
+		return ctx
+	}
+	return p.propagator.Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// Inject implements Provider.Inject
+func (p *Provider) Inject(ctx context.Context, carrier map[string]string) {
+	if !p.enabled || p.propagator == nil {
+		return
+	}
+	p.propagator.Inject(ctx, propagation.MapCarrier(carrier))
+}
+
 // IsEnabled implements Provider.IsEnabled
 func (p *Provider) IsEnabled() bool {
 	return p.enabled
@@ -156,18 +194,28 @@ func (f *Factory) createExporter(ctx context.Context, opts *tracing.Options) (sd
 
 // createResource creates a resource with service information
 func (f *Factory) createResource(opts *tracing.Options) (*resource.Resource, error) {
+	attrs := make([]attribute.KeyValue, 0, len(opts.ResourceAttributes)+2)
+	for key, value := range opts.ResourceAttributes {
+		attrs = append(attrs, attribute.String(key, value))
+	}
+	// Last, so custom attributes cannot replace the service identity
+	attrs = append(attrs,
+		semconv.ServiceName(opts.ServiceName),
+		semconv.ServiceVersion(opts.ServiceVersion),
+	)
+
 	return resource.Merge(
 		resource.Default(),
-		resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceName(opts.ServiceName),
-			semconv.ServiceVersion(opts.ServiceVersion),
-		),
+		resource.NewWithAttributes(semconv.SchemaURL, attrs...),
 	)
 }
 
 // createSampler creates a sampler based on the configuration
 func (f *Factory) createSampler(opts *tracing.Options) sdktrace.Sampler {
+	if opts.RateLimitPerSecond > 0 {
+		// Only root spans take tokens, so sampled traces stay complete
+		return sdktrace.ParentBased(newRateLimitSampler(opts.RateLimitPerSecond, time.Now))
+	}
 	if opts.SamplingRate >= 1.0 {
 		return sdktrace.AlwaysSample()
 	}
@@ -177,8 +225,8 @@ func (f *Factory) createSampler(opts *tracing.Options) sdktrace.Sampler {
 	return sdktrace.TraceIDRatioBased(opts.SamplingRate)
 }
 
-// setupPropagators configures the global propagators
-func (f *Factory) setupPropagators(opts *tracing.Options) {
+// setupPropagators configures the global propagators and returns them
+func (f *Factory) setupPropagators(opts *tracing.Options) propagation.TextMapPropagator {
 	// Default propagators if none specified
 	if len(opts.PropagatorTypes) == 0 {
 		opts.PropagatorTypes = []string{
@@ -197,5 +245,7 @@ func (f *Factory) setupPropagators(opts *tracing.Options) {
 		}
 	}
 
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagators...))
+	propagator := propagation.NewCompositeTextMapPropagator(propagators...)
+	otel.SetTextMapPropagator(propagator)
+	return propagator
 }
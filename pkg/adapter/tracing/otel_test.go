@@ -11,7 +11,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 
+	domainoptions "github.com/damianoneill/go-bootstrap/pkg/domain/options"
 	"github.com/damianoneill/go-bootstrap/pkg/domain/tracing"
 )
 
@@ -129,6 +135,14 @@ func TestNewProvider(t *testing.T) {
 	}
 }
 
+func TestNewProviderValidationError(t *testing.T) {
+	_, err := NewFactory().NewProvider(tracing.WithExporterType(tracing.NoopExporter))
+
+	var verr *domainoptions.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "ServiceName", verr.Field)
+}
+
 func TestProvider_Shutdown(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -166,6 +180,117 @@ func TestProvider_Shutdown(t *testing.T) {
 	}
 }
 
+func TestProvider_ForceFlush(t *testing.T) {
+	t.Run("disabled provider", func(t *testing.T) {
+		provider := &Provider{enabled: false}
+		assert.NoError(t, provider.ForceFlush(context.Background()))
+	})
+
+	t.Run("exports buffered spans", func(t *testing.T) {
+		exporter := tracetest.NewInMemoryExporter()
+		tp := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(time.Hour)),
+		)
+		provider := &Provider{provider: tp, tracer: tp.Tracer("test"), enabled: true}
+		defer provider.Shutdown(context.Background())
+
+		_, span := provider.tracer.Start(context.Background(), "operation")
+		span.End()
+		assert.Empty(t, exporter.GetSpans(), "span is buffered until the batch times out")
+
+		require.NoError(t, provider.ForceFlush(context.Background()))
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "operation", spans[0].Name)
+
+		// The provider keeps recording after a flush
+		_, span = provider.tracer.Start(context.Background(), "after flush")
+		span.End()
+		require.NoError(t, provider.ForceFlush(context.Background()))
+		assert.Len(t, exporter.GetSpans(), 2)
+	})
+}
+
+func TestFactory_createResource(t *testing.T) {
+	res, err := NewFactory().(*Factory).createResource(&tracing.Options{
+		ServiceName:    "test-service",
+		ServiceVersion: "1.0.0",
+		ResourceAttributes: map[string]string{
+			"deployment.environment": "staging",
+			"host.name":              "node-1",
+			"team":                   "payments",
+			"service.name":           "impostor",
+		},
+	})
+	require.NoError(t, err)
+
+	attrs := res.Set()
+	for key, want := range map[string]string{
+		"deployment.environment": "staging",
+		"host.name":              "node-1",
+		"team":                   "payments",
+		"service.name":           "test-service",
+		"service.version":        "1.0.0",
+	} {
+		value, ok := attrs.Value(attribute.Key(key))
+		assert.True(t, ok, key)
+		assert.Equal(t, want, value.AsString(), key)
+	}
+}
+
+func TestProvider_ExtractInject(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		tp := sdktrace.NewTracerProvider()
+		defer tp.Shutdown(context.Background())
+		provider := &Provider{
+			provider:   tp,
+			tracer:     tp.Tracer("test"),
+			propagator: NewFactory().(*Factory).setupPropagators(&tracing.Options{}),
+			enabled:    true,
+		}
+
+		member, err := baggage.NewMember("tenant", "acme")
+		require.NoError(t, err)
+		bag, err := baggage.New(member)
+		require.NoError(t, err)
+		ctx := baggage.ContextWithBaggage(context.Background(), bag)
+		ctx, span := provider.tracer.Start(ctx, "publish")
+		defer span.End()
+
+		// Producer side writes message headers
+		headers := map[string]string{}
+		provider.Inject(ctx, headers)
+		assert.Contains(t, headers, "traceparent")
+		assert.Equal(t, "tenant=acme", headers["baggage"])
+
+		// Consumer side continues the trace
+		extracted := provider.Extract(context.Background(), headers)
+		remote := trace.SpanContextFromContext(extracted)
+		assert.True(t, remote.IsRemote())
+		assert.Equal(t, span.SpanContext().TraceID(), remote.TraceID())
+		assert.Equal(t, span.SpanContext().SpanID(), remote.SpanID())
+		assert.Equal(t, "acme", baggage.FromContext(extracted).Member("tenant").Value())
+
+		_, child := provider.tracer.Start(extracted, "consume")
+		defer child.End()
+		assert.Equal(t, span.SpanContext().TraceID(), child.SpanContext().TraceID())
+	})
+
+	t.Run("disabled provider", func(t *testing.T) {
+		provider := &Provider{enabled: false}
+		headers := map[string]string{
+			"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		}
+
+		ctx := context.Background()
+		assert.Equal(t, ctx, provider.Extract(ctx, headers))
+
+		carrier := map[string]string{}
+		provider.Inject(ctx, carrier)
+		assert.Empty(t, carrier)
+	})
+}
+
 func TestProvider_IsEnabled(t *testing.T) {
 	tests := []struct {
 		name     string
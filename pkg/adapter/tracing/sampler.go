@@ -0,0 +1,80 @@
+// pkg/adapter/tracing/sampler.go
+
+package tracing
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// rateLimitSampler samples up to a fixed number of spans per second using a
+// token bucket. The bucket holds one second's worth of tokens, so a burst
+// after a quiet period is capped at the per-second rate.
+type rateLimitSampler struct {
+	perSecond float64
+	now       func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimitSampler creates a sampler allowing perSecond samples per
+// second, starting with a full bucket
+func newRateLimitSampler(perSecond float64, now func() time.Time) *rateLimitSampler {
+	return &rateLimitSampler{
+		perSecond: perSecond,
+		now:       now,
+		tokens:    bucketSize(perSecond),
+		last:      now(),
+	}
+}
+
+// bucketSize is the bucket capacity, at least one token so rates below one
+// per second still sample
+func bucketSize(perSecond float64) float64 {
+	if perSecond < 1 {
+		return 1
+	}
+	return perSecond
+}
+
+// ShouldSample implements sdktrace.Sampler
+func (s *rateLimitSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	decision := sdktrace.Drop
+	if s.take() {
+		decision = sdktrace.RecordAndSample
+	}
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+// Description implements sdktrace.Sampler
+func (s *rateLimitSampler) Description() string {
+	return fmt.Sprintf("RateLimitSampler{%g}", s.perSecond)
+}
+
+// take refills the bucket for the time elapsed and consumes a token if one
+// is available
+func (s *rateLimitSampler) take() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if elapsed := now.Sub(s.last).Seconds(); elapsed > 0 {
+		s.tokens = min(s.tokens+elapsed*s.perSecond, bucketSize(s.perSecond))
+		s.last = now
+	}
+
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
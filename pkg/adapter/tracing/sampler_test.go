@@ -0,0 +1,101 @@
+// pkg/adapter/tracing/sampler_test.go
+
+package tracing
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/damianoneill/go-bootstrap/pkg/domain/tracing"
+)
+
+// testClock is a manually advanced clock safe for concurrent reads
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// sampleBurst makes n concurrent sampling decisions and returns how many
+// were sampled
+func sampleBurst(sampler sdktrace.Sampler, n int) int {
+	var sampled atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: context.Background(),
+				Name:          "request",
+			})
+			if result.Decision == sdktrace.RecordAndSample {
+				sampled.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	return int(sampled.Load())
+}
+
+func TestRateLimitSampler(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	sampler := newRateLimitSampler(10, clock.Now)
+
+	assert.Equal(t, 10, sampleBurst(sampler, 500), "burst is capped at the per-second rate")
+	assert.Zero(t, sampleBurst(sampler, 100), "no tokens left within the same instant")
+
+	clock.Advance(500 * time.Millisecond)
+	assert.Equal(t, 5, sampleBurst(sampler, 100), "tokens refill in proportion to elapsed time")
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, 10, sampleBurst(sampler, 100), "idle time does not grow the bucket beyond one second")
+}
+
+func TestRateLimitSamplerFractionalRate(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	sampler := newRateLimitSampler(0.5, clock.Now)
+
+	assert.Equal(t, 1, sampleBurst(sampler, 10))
+	clock.Advance(time.Second)
+	assert.Zero(t, sampleBurst(sampler, 10))
+	clock.Advance(time.Second)
+	assert.Equal(t, 1, sampleBurst(sampler, 10))
+}
+
+func TestFactory_createSamplerRateLimit(t *testing.T) {
+	sampler := NewFactory().(*Factory).createSampler(&tracing.Options{
+		SamplingRate:       1.0,
+		RateLimitPerSecond: 1,
+	})
+	assert.Contains(t, sampler.Description(), "RateLimitSampler{1}", "rate limit takes precedence over the sampling rate")
+
+	assert.Equal(t, 1, sampleBurst(sampler, 10))
+
+	// Spans in a sampled trace are kept even once the limit is reached
+	parent := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	result := sampler.ShouldSample(sdktrace.SamplingParameters{ParentContext: parent, Name: "child"})
+	assert.Equal(t, sdktrace.RecordAndSample, result.Decision)
+}
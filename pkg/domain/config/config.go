@@ -5,6 +5,8 @@
 package config
 
 import (
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
@@ -40,6 +42,22 @@ type Store interface {
 	// Returns the value and true if found, nil and false if not found.
 	GetStringSlice(key string) ([]string, bool)
 
+	// GetIntSlice retrieves an int slice value by key.
+	// Returns the value and true if found, nil and false if not found.
+	GetIntSlice(key string) ([]int, bool)
+
+	// GetFloat64Slice retrieves a float64 slice value by key.
+	// Returns the value and true if found, nil and false if not found.
+	GetFloat64Slice(key string) ([]float64, bool)
+
+	// GetTime retrieves a time value by key, parsed as RFC3339.
+	// Returns the value and true if found and valid, zero time and false otherwise.
+	GetTime(key string) (time.Time, bool)
+
+	// GetTimeWithLayout retrieves a time value by key, parsed with layout.
+	// Returns the value and true if found and valid, zero time and false otherwise.
+	GetTimeWithLayout(key, layout string) (time.Time, bool)
+
 	// Set stores a value for the given key.
 	// The value must be of a supported type.
 	Set(key string, value interface{}) error
@@ -51,6 +69,25 @@ type Store interface {
 	// This should be called after initial setup to load values.
 	ReadConfig() error
 
+	// WriteConfig writes the current configuration, including values
+	// changed with Set, to the configured file, replacing its contents.
+	// Returns an error if no config file was configured.
+	WriteConfig() error
+
+	// SafeWriteConfig is like WriteConfig but fails if the configured
+	// file already exists.
+	SafeWriteConfig() error
+
+	// Snapshot returns a read-only, point-in-time copy of the config so
+	// several keys can be read consistently. It does not reflect later
+	// reloads or changes, and its Set and ReadConfig return errors.
+	Snapshot() Store
+
+	// Sub returns a read-only copy of the config rooted at prefix, so
+	// Sub("database").GetString("host") reads database.host. An unset or
+	// non-map prefix returns an empty store.
+	Sub(prefix string) Store
+
 	// UnmarshalKey decodes a specific config key into a struct.
 	// The target must be a pointer to a struct.
 	UnmarshalKey(key string, target interface{}) error
@@ -70,6 +107,34 @@ type StoreOptions struct {
 
 	// Defaults holds default values for configuration keys
 	Defaults map[string]interface{}
+
+	// LenientBooleans accepts common truthy/falsy spellings such as
+	// "on"/"off" and "yes"/"no" when reading boolean values
+	LenientBooleans bool
+
+	// EnvKeyReplacer maps config keys to environment variable names.
+	// If not set, dots are replaced with underscores.
+	EnvKeyReplacer *strings.Replacer
+
+	// KeyDelimiter separates nested key segments.
+	// If not set, defaults to ".".
+	KeyDelimiter string
+
+	// Schema is a struct prototype the loaded config must decode into
+	// without unknown keys, with fields tagged required:"true" set
+	Schema interface{}
+
+	// EnvExpansion expands ${VAR} and $VAR references in string values
+	// from the environment when they are read or unmarshalled
+	EnvExpansion bool
+
+	// KeepUnsetEnv leaves references to unset environment variables in
+	// place as ${VAR} instead of expanding them to an empty string
+	KeepUnsetEnv bool
+
+	// DurationBounds maps duration keys to their inclusive [min, max]
+	// range, checked when the store is created
+	DurationBounds map[string][2]time.Duration
 }
 
 // Option is a function that modifies StoreOptions
@@ -103,6 +168,101 @@ func WithDefaults(defaults map[string]interface{}) Option {
 	})
 }
 
+// WithLenientBooleans enables flexible parsing of boolean values.
+// When enabled, "on", "yes", "y" and "enabled" are read as true and
+// "off", "no", "n" and "disabled" as false, in addition to the values
+// accepted by strconv.ParseBool. Matching is case-insensitive.
+func WithLenientBooleans(enabled bool) Option {
+	return options.OptionFunc[StoreOptions](func(o *StoreOptions) error {
+		o.LenientBooleans = enabled
+		return nil
+	})
+}
+
+// WithSchema validates the loaded config against prototype, a struct or
+// pointer to struct, when the store is created. Decoding errors, keys with
+// no matching field, such as the misspelled server.htp.port, and unset
+// fields tagged required:"true" fail store creation. Fields are matched
+// using mapstructure tags, as with Unmarshal.
+func WithSchema(prototype interface{}) Option {
+	return options.OptionFunc[StoreOptions](func(o *StoreOptions) error {
+		t := reflect.TypeOf(prototype)
+		if t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			return options.Invalid("Schema", "schema must be a struct or pointer to struct, got %T", prototype)
+		}
+		o.Schema = prototype
+		return nil
+	})
+}
+
+// WithEnvKeyReplacer sets how config keys are mapped to environment
+// variable names, after the prefix is applied as PREFIX_key. For example,
+// strings.NewReplacer(".", "__") with prefix "SVC_" maps server.port to
+// SVC__SERVER__PORT.
+func WithEnvKeyReplacer(replacer *strings.Replacer) Option {
+	return options.OptionFunc[StoreOptions](func(o *StoreOptions) error {
+		if replacer == nil {
+			return options.Invalid("EnvKeyReplacer", "env key replacer cannot be nil")
+		}
+		o.EnvKeyReplacer = replacer
+		return nil
+	})
+}
+
+// WithKeyDelimiter sets the separator between nested key segments, for
+// keys that themselves contain dots. The env key replacer should map the
+// same delimiter.
+func WithKeyDelimiter(delimiter string) Option {
+	return options.OptionFunc[StoreOptions](func(o *StoreOptions) error {
+		if delimiter == "" {
+			return options.Invalid("KeyDelimiter", "key delimiter cannot be empty")
+		}
+		o.KeyDelimiter = delimiter
+		return nil
+	})
+}
+
+// WithEnvExpansion enables expansion of ${VAR} and $VAR environment
+// variable references in string values returned by GetString and decoded
+// by Unmarshal and UnmarshalKey, so a value such as ${HOME}/data resolves
+// to the user's home directory. Unset variables expand to an empty string
+// unless WithKeepUnsetEnv is set.
+func WithEnvExpansion(enabled bool) Option {
+	return options.OptionFunc[StoreOptions](func(o *StoreOptions) error {
+		o.EnvExpansion = enabled
+		return nil
+	})
+}
+
+// WithKeepUnsetEnv keeps references to unset environment variables as
+// ${VAR} during env expansion, making missing variables visible instead of
+// silently producing empty values.
+func WithKeepUnsetEnv(keep bool) Option {
+	return options.OptionFunc[StoreOptions](func(o *StoreOptions) error {
+		o.KeepUnsetEnv = keep
+		return nil
+	})
+}
+
+// WithDurationBounds requires each listed duration key, when set, to lie
+// within its inclusive [min, max] range, so misconfigurations such as
+// read_timeout: 1ms are caught when the store is created. Unset keys are
+// not checked.
+func WithDurationBounds(bounds map[string][2]time.Duration) Option {
+	return options.OptionFunc[StoreOptions](func(o *StoreOptions) error {
+		for key, b := range bounds {
+			if b[0] > b[1] {
+				return options.Invalid("DurationBounds", "duration bounds for %s: min %s exceeds max %s", key, b[0], b[1])
+			}
+		}
+		o.DurationBounds = bounds
+		return nil
+	})
+}
+
 // Factory creates new store instances
 type Factory interface {
 	// NewStore creates a new configuration store with the given options.
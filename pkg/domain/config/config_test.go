@@ -2,7 +2,12 @@
 package config
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
 )
 
 func TestWithConfigFile(t *testing.T) {
@@ -120,3 +125,114 @@ func TestWithDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestWithLenientBooleans(t *testing.T) {
+	opts := StoreOptions{}
+	if err := WithLenientBooleans(true).ApplyOption(&opts); err != nil {
+		t.Errorf("WithLenientBooleans() error = %v", err)
+	}
+
+	if !opts.LenientBooleans {
+		t.Errorf("WithLenientBooleans() got = %v, want %v", opts.LenientBooleans, true)
+	}
+}
+
+func TestWithDurationBounds(t *testing.T) {
+	bounds := map[string][2]time.Duration{
+		"read_timeout": {time.Second, time.Minute},
+	}
+
+	opts := StoreOptions{}
+	if err := WithDurationBounds(bounds).ApplyOption(&opts); err != nil {
+		t.Errorf("WithDurationBounds() error = %v", err)
+	}
+	if opts.DurationBounds["read_timeout"] != bounds["read_timeout"] {
+		t.Errorf("WithDurationBounds() got = %v, want %v", opts.DurationBounds, bounds)
+	}
+
+	inverted := map[string][2]time.Duration{
+		"read_timeout": {time.Minute, time.Second},
+	}
+	if err := WithDurationBounds(inverted).ApplyOption(&StoreOptions{}); err == nil {
+		t.Error("WithDurationBounds() expected error for min greater than max")
+	}
+}
+
+func TestWithEnvKeyReplacer(t *testing.T) {
+	replacer := strings.NewReplacer(".", "__")
+
+	opts := StoreOptions{}
+	if err := WithEnvKeyReplacer(replacer).ApplyOption(&opts); err != nil {
+		t.Errorf("WithEnvKeyReplacer() error = %v", err)
+	}
+	if opts.EnvKeyReplacer != replacer {
+		t.Errorf("WithEnvKeyReplacer() got = %v, want %v", opts.EnvKeyReplacer, replacer)
+	}
+
+	if err := WithEnvKeyReplacer(nil).ApplyOption(&StoreOptions{}); err == nil {
+		t.Error("WithEnvKeyReplacer() expected error for nil replacer")
+	}
+}
+
+func TestWithKeyDelimiter(t *testing.T) {
+	opts := StoreOptions{}
+	if err := WithKeyDelimiter("::").ApplyOption(&opts); err != nil {
+		t.Errorf("WithKeyDelimiter() error = %v", err)
+	}
+	if opts.KeyDelimiter != "::" {
+		t.Errorf("WithKeyDelimiter() got = %v, want %v", opts.KeyDelimiter, "::")
+	}
+
+	if err := WithKeyDelimiter("").ApplyOption(&StoreOptions{}); err == nil {
+		t.Error("WithKeyDelimiter() expected error for empty delimiter")
+	}
+}
+
+func TestWithSchema(t *testing.T) {
+	type schema struct {
+		Port int
+	}
+
+	for _, prototype := range []interface{}{schema{}, &schema{}} {
+		opts := StoreOptions{}
+		if err := WithSchema(prototype).ApplyOption(&opts); err != nil {
+			t.Errorf("WithSchema(%T) error = %v", prototype, err)
+		}
+		if opts.Schema != prototype {
+			t.Errorf("WithSchema() got = %v, want %v", opts.Schema, prototype)
+		}
+	}
+
+	for _, prototype := range []interface{}{nil, 42, map[string]interface{}{}} {
+		if err := WithSchema(prototype).ApplyOption(&StoreOptions{}); err == nil {
+			t.Errorf("WithSchema(%T) expected error", prototype)
+		}
+	}
+}
+
+func TestStoreOptionValidationErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		option    Option
+		wantField string
+	}{
+		{"schema", WithSchema(42), "Schema"},
+		{"env key replacer", WithEnvKeyReplacer(nil), "EnvKeyReplacer"},
+		{"key delimiter", WithKeyDelimiter(""), "KeyDelimiter"},
+		{"duration bounds", WithDurationBounds(map[string][2]time.Duration{"timeout": {time.Minute, time.Second}}), "DurationBounds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.option.ApplyOption(&StoreOptions{})
+
+			var verr *options.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("ApplyOption() error = %v, want *options.ValidationError", err)
+			}
+			if verr.Field != tt.wantField {
+				t.Errorf("Field = %v, want %v", verr.Field, tt.wantField)
+			}
+		})
+	}
+}
@@ -14,9 +14,8 @@ import (
 	reflect "reflect"
 	time "time"
 
-	gomock "go.uber.org/mock/gomock"
-
 	config "github.com/damianoneill/go-bootstrap/pkg/domain/config"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockStore is a mock of Store interface.
@@ -88,6 +87,21 @@ func (mr *MockStoreMockRecorder) GetFloat64(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFloat64", reflect.TypeOf((*MockStore)(nil).GetFloat64), key)
 }
 
+// GetFloat64Slice mocks base method.
+func (m *MockStore) GetFloat64Slice(key string) ([]float64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFloat64Slice", key)
+	ret0, _ := ret[0].([]float64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetFloat64Slice indicates an expected call of GetFloat64Slice.
+func (mr *MockStoreMockRecorder) GetFloat64Slice(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFloat64Slice", reflect.TypeOf((*MockStore)(nil).GetFloat64Slice), key)
+}
+
 // GetInt mocks base method.
 func (m *MockStore) GetInt(key string) (int, bool) {
 	m.ctrl.T.Helper()
@@ -103,6 +117,21 @@ func (mr *MockStoreMockRecorder) GetInt(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInt", reflect.TypeOf((*MockStore)(nil).GetInt), key)
 }
 
+// GetIntSlice mocks base method.
+func (m *MockStore) GetIntSlice(key string) ([]int, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIntSlice", key)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetIntSlice indicates an expected call of GetIntSlice.
+func (mr *MockStoreMockRecorder) GetIntSlice(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIntSlice", reflect.TypeOf((*MockStore)(nil).GetIntSlice), key)
+}
+
 // GetString mocks base method.
 func (m *MockStore) GetString(key string) (string, bool) {
 	m.ctrl.T.Helper()
@@ -133,6 +162,36 @@ func (mr *MockStoreMockRecorder) GetStringSlice(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStringSlice", reflect.TypeOf((*MockStore)(nil).GetStringSlice), key)
 }
 
+// GetTime mocks base method.
+func (m *MockStore) GetTime(key string) (time.Time, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTime", key)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetTime indicates an expected call of GetTime.
+func (mr *MockStoreMockRecorder) GetTime(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTime", reflect.TypeOf((*MockStore)(nil).GetTime), key)
+}
+
+// GetTimeWithLayout mocks base method.
+func (m *MockStore) GetTimeWithLayout(key, layout string) (time.Time, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeWithLayout", key, layout)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetTimeWithLayout indicates an expected call of GetTimeWithLayout.
+func (mr *MockStoreMockRecorder) GetTimeWithLayout(key, layout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeWithLayout", reflect.TypeOf((*MockStore)(nil).GetTimeWithLayout), key, layout)
+}
+
 // IsSet mocks base method.
 func (m *MockStore) IsSet(key string) bool {
 	m.ctrl.T.Helper()
@@ -161,6 +220,20 @@ func (mr *MockStoreMockRecorder) ReadConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadConfig", reflect.TypeOf((*MockStore)(nil).ReadConfig))
 }
 
+// SafeWriteConfig mocks base method.
+func (m *MockStore) SafeWriteConfig() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SafeWriteConfig")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SafeWriteConfig indicates an expected call of SafeWriteConfig.
+func (mr *MockStoreMockRecorder) SafeWriteConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SafeWriteConfig", reflect.TypeOf((*MockStore)(nil).SafeWriteConfig))
+}
+
 // Set mocks base method.
 func (m *MockStore) Set(key string, value any) error {
 	m.ctrl.T.Helper()
@@ -175,6 +248,34 @@ func (mr *MockStoreMockRecorder) Set(key, value any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockStore)(nil).Set), key, value)
 }
 
+// Snapshot mocks base method.
+func (m *MockStore) Snapshot() config.Store {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(config.Store)
+	return ret0
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockStoreMockRecorder) Snapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockStore)(nil).Snapshot))
+}
+
+// Sub mocks base method.
+func (m *MockStore) Sub(prefix string) config.Store {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sub", prefix)
+	ret0, _ := ret[0].(config.Store)
+	return ret0
+}
+
+// Sub indicates an expected call of Sub.
+func (mr *MockStoreMockRecorder) Sub(prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sub", reflect.TypeOf((*MockStore)(nil).Sub), prefix)
+}
+
 // Unmarshal mocks base method.
 func (m *MockStore) Unmarshal(target any) error {
 	m.ctrl.T.Helper()
@@ -203,6 +304,20 @@ func (mr *MockStoreMockRecorder) UnmarshalKey(key, target any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarshalKey", reflect.TypeOf((*MockStore)(nil).UnmarshalKey), key, target)
 }
 
+// WriteConfig mocks base method.
+func (m *MockStore) WriteConfig() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteConfig")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteConfig indicates an expected call of WriteConfig.
+func (mr *MockStoreMockRecorder) WriteConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteConfig", reflect.TypeOf((*MockStore)(nil).WriteConfig))
+}
+
 // MockMaskedStore is a mock of MaskedStore interface.
 type MockMaskedStore struct {
 	ctrl     *gomock.Controller
@@ -242,6 +357,21 @@ func (mr *MockMaskedStoreMockRecorder) GetBool(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBool", reflect.TypeOf((*MockMaskedStore)(nil).GetBool), key)
 }
 
+// GetConfigDiff mocks base method.
+func (m *MockMaskedStore) GetConfigDiff(maskStrategy config.MaskStrategy) (map[string]config.ConfigDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigDiff", maskStrategy)
+	ret0, _ := ret[0].(map[string]config.ConfigDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigDiff indicates an expected call of GetConfigDiff.
+func (mr *MockMaskedStoreMockRecorder) GetConfigDiff(maskStrategy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigDiff", reflect.TypeOf((*MockMaskedStore)(nil).GetConfigDiff), maskStrategy)
+}
+
 // GetConfigHandler mocks base method.
 func (m *MockMaskedStore) GetConfigHandler(maskStrategy config.MaskStrategy) http.Handler {
 	m.ctrl.T.Helper()
@@ -256,6 +386,20 @@ func (mr *MockMaskedStoreMockRecorder) GetConfigHandler(maskStrategy any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigHandler", reflect.TypeOf((*MockMaskedStore)(nil).GetConfigHandler), maskStrategy)
 }
 
+// GetDiffHandler mocks base method.
+func (m *MockMaskedStore) GetDiffHandler(maskStrategy config.MaskStrategy) http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiffHandler", maskStrategy)
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// GetDiffHandler indicates an expected call of GetDiffHandler.
+func (mr *MockMaskedStoreMockRecorder) GetDiffHandler(maskStrategy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiffHandler", reflect.TypeOf((*MockMaskedStore)(nil).GetDiffHandler), maskStrategy)
+}
+
 // GetDuration mocks base method.
 func (m *MockMaskedStore) GetDuration(key string) (time.Duration, bool) {
 	m.ctrl.T.Helper()
@@ -271,6 +415,21 @@ func (mr *MockMaskedStoreMockRecorder) GetDuration(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuration", reflect.TypeOf((*MockMaskedStore)(nil).GetDuration), key)
 }
 
+// GetFlattenedConfig mocks base method.
+func (m *MockMaskedStore) GetFlattenedConfig(maskStrategy config.MaskStrategy) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlattenedConfig", maskStrategy)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlattenedConfig indicates an expected call of GetFlattenedConfig.
+func (mr *MockMaskedStoreMockRecorder) GetFlattenedConfig(maskStrategy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlattenedConfig", reflect.TypeOf((*MockMaskedStore)(nil).GetFlattenedConfig), maskStrategy)
+}
+
 // GetFloat64 mocks base method.
 func (m *MockMaskedStore) GetFloat64(key string) (float64, bool) {
 	m.ctrl.T.Helper()
@@ -286,6 +445,21 @@ func (mr *MockMaskedStoreMockRecorder) GetFloat64(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFloat64", reflect.TypeOf((*MockMaskedStore)(nil).GetFloat64), key)
 }
 
+// GetFloat64Slice mocks base method.
+func (m *MockMaskedStore) GetFloat64Slice(key string) ([]float64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFloat64Slice", key)
+	ret0, _ := ret[0].([]float64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetFloat64Slice indicates an expected call of GetFloat64Slice.
+func (mr *MockMaskedStoreMockRecorder) GetFloat64Slice(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFloat64Slice", reflect.TypeOf((*MockMaskedStore)(nil).GetFloat64Slice), key)
+}
+
 // GetInt mocks base method.
 func (m *MockMaskedStore) GetInt(key string) (int, bool) {
 	m.ctrl.T.Helper()
@@ -301,6 +475,21 @@ func (mr *MockMaskedStoreMockRecorder) GetInt(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInt", reflect.TypeOf((*MockMaskedStore)(nil).GetInt), key)
 }
 
+// GetIntSlice mocks base method.
+func (m *MockMaskedStore) GetIntSlice(key string) ([]int, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIntSlice", key)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetIntSlice indicates an expected call of GetIntSlice.
+func (mr *MockMaskedStoreMockRecorder) GetIntSlice(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIntSlice", reflect.TypeOf((*MockMaskedStore)(nil).GetIntSlice), key)
+}
+
 // GetMaskedConfig mocks base method.
 func (m *MockMaskedStore) GetMaskedConfig(maskStrategy config.MaskStrategy) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -316,6 +505,20 @@ func (mr *MockMaskedStoreMockRecorder) GetMaskedConfig(maskStrategy any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaskedConfig", reflect.TypeOf((*MockMaskedStore)(nil).GetMaskedConfig), maskStrategy)
 }
 
+// GetOriginHandler mocks base method.
+func (m *MockMaskedStore) GetOriginHandler() http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOriginHandler")
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// GetOriginHandler indicates an expected call of GetOriginHandler.
+func (mr *MockMaskedStoreMockRecorder) GetOriginHandler() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOriginHandler", reflect.TypeOf((*MockMaskedStore)(nil).GetOriginHandler))
+}
+
 // GetString mocks base method.
 func (m *MockMaskedStore) GetString(key string) (string, bool) {
 	m.ctrl.T.Helper()
@@ -346,6 +549,36 @@ func (mr *MockMaskedStoreMockRecorder) GetStringSlice(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStringSlice", reflect.TypeOf((*MockMaskedStore)(nil).GetStringSlice), key)
 }
 
+// GetTime mocks base method.
+func (m *MockMaskedStore) GetTime(key string) (time.Time, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTime", key)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetTime indicates an expected call of GetTime.
+func (mr *MockMaskedStoreMockRecorder) GetTime(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTime", reflect.TypeOf((*MockMaskedStore)(nil).GetTime), key)
+}
+
+// GetTimeWithLayout mocks base method.
+func (m *MockMaskedStore) GetTimeWithLayout(key, layout string) (time.Time, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeWithLayout", key, layout)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetTimeWithLayout indicates an expected call of GetTimeWithLayout.
+func (mr *MockMaskedStoreMockRecorder) GetTimeWithLayout(key, layout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeWithLayout", reflect.TypeOf((*MockMaskedStore)(nil).GetTimeWithLayout), key, layout)
+}
+
 // IsSet mocks base method.
 func (m *MockMaskedStore) IsSet(key string) bool {
 	m.ctrl.T.Helper()
@@ -360,6 +593,20 @@ func (mr *MockMaskedStoreMockRecorder) IsSet(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSet", reflect.TypeOf((*MockMaskedStore)(nil).IsSet), key)
 }
 
+// Origin mocks base method.
+func (m *MockMaskedStore) Origin(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Origin", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Origin indicates an expected call of Origin.
+func (mr *MockMaskedStoreMockRecorder) Origin(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Origin", reflect.TypeOf((*MockMaskedStore)(nil).Origin), key)
+}
+
 // ReadConfig mocks base method.
 func (m *MockMaskedStore) ReadConfig() error {
 	m.ctrl.T.Helper()
@@ -374,6 +621,20 @@ func (mr *MockMaskedStoreMockRecorder) ReadConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadConfig", reflect.TypeOf((*MockMaskedStore)(nil).ReadConfig))
 }
 
+// SafeWriteConfig mocks base method.
+func (m *MockMaskedStore) SafeWriteConfig() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SafeWriteConfig")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SafeWriteConfig indicates an expected call of SafeWriteConfig.
+func (mr *MockMaskedStoreMockRecorder) SafeWriteConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SafeWriteConfig", reflect.TypeOf((*MockMaskedStore)(nil).SafeWriteConfig))
+}
+
 // Set mocks base method.
 func (m *MockMaskedStore) Set(key string, value any) error {
 	m.ctrl.T.Helper()
@@ -388,6 +649,34 @@ func (mr *MockMaskedStoreMockRecorder) Set(key, value any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockMaskedStore)(nil).Set), key, value)
 }
 
+// Snapshot mocks base method.
+func (m *MockMaskedStore) Snapshot() config.Store {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(config.Store)
+	return ret0
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockMaskedStoreMockRecorder) Snapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockMaskedStore)(nil).Snapshot))
+}
+
+// Sub mocks base method.
+func (m *MockMaskedStore) Sub(prefix string) config.Store {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sub", prefix)
+	ret0, _ := ret[0].(config.Store)
+	return ret0
+}
+
+// Sub indicates an expected call of Sub.
+func (mr *MockMaskedStoreMockRecorder) Sub(prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sub", reflect.TypeOf((*MockMaskedStore)(nil).Sub), prefix)
+}
+
 // Unmarshal mocks base method.
 func (m *MockMaskedStore) Unmarshal(target any) error {
 	m.ctrl.T.Helper()
@@ -416,6 +705,20 @@ func (mr *MockMaskedStoreMockRecorder) UnmarshalKey(key, target any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarshalKey", reflect.TypeOf((*MockMaskedStore)(nil).UnmarshalKey), key, target)
 }
 
+// WriteConfig mocks base method.
+func (m *MockMaskedStore) WriteConfig() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteConfig")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteConfig indicates an expected call of WriteConfig.
+func (mr *MockMaskedStoreMockRecorder) WriteConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteConfig", reflect.TypeOf((*MockMaskedStore)(nil).WriteConfig))
+}
+
 // MockFactory is a mock of Factory interface.
 type MockFactory struct {
 	ctrl     *gomock.Controller
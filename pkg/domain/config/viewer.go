@@ -43,9 +43,35 @@ func containsInsensitive(str, substr string) bool {
 	return strings.Contains(str, substr)
 }
 
+// ConfigDiff describes a key whose effective value differs from the config file
+type ConfigDiff struct {
+	// File is the value read from the config file
+	File interface{} `json:"file"`
+	// Effective is the value after env vars and overrides are applied
+	Effective interface{} `json:"effective"`
+}
+
+// Sources a config value can originate from, in descending precedence
+const (
+	OriginOverride = "override" // Set at runtime
+	OriginEnv      = "env"      // Environment variable
+	OriginFile     = "file"     // Config file
+	OriginDefault  = "default"  // Default value
+	OriginUnset    = "unset"    // Not set by any source
+)
+
 // MaskedStore represents a config store that can expose masked config via HTTP
 type MaskedStore interface {
 	Store
 	GetConfigHandler(maskStrategy MaskStrategy) http.Handler
 	GetMaskedConfig(maskStrategy MaskStrategy) (map[string]interface{}, error)
+	// GetFlattenedConfig is like GetMaskedConfig but keys every value by
+	// its full dotted path, such as server.http.port
+	GetFlattenedConfig(maskStrategy MaskStrategy) (map[string]interface{}, error)
+	GetDiffHandler(maskStrategy MaskStrategy) http.Handler
+	GetConfigDiff(maskStrategy MaskStrategy) (map[string]ConfigDiff, error)
+	// Origin reports which source provides the effective value of key,
+	// one of the Origin constants
+	Origin(key string) string
+	GetOriginHandler() http.Handler
 }
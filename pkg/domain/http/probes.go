@@ -1,6 +1,8 @@
 // Package http provides domain interfaces for HTTP routing and service health probes.
 package http
 
+import "context"
+
 // ProbeResponse represents the result of a health check probe.
 // It follows Kubernetes probe conventions while allowing additional
 // details to be included in the response.
@@ -19,6 +21,10 @@ type ProbeResponse struct {
 // health state of a specific aspect of the service.
 type ProbeCheck func() ProbeResponse
 
+// ContextProbeCheck is a ProbeCheck that receives the probe request's
+// context, so checks of remote dependencies can honor its cancellation.
+type ContextProbeCheck func(ctx context.Context) ProbeResponse
+
 // ProbeHandlers contains the health check functions for Kubernetes probes.
 // Each probe type serves a different purpose in determining service health
 // and availability.
@@ -35,6 +41,10 @@ type ProbeHandlers struct {
 	// This should verify all required dependencies are available.
 	ReadinessCheck ProbeCheck
 
+	// ReadinessContextCheck is a context-aware alternative to ReadinessCheck.
+	// If set, it is used instead of ReadinessCheck to serve readiness probes.
+	ReadinessContextCheck ContextProbeCheck
+
 	// StartupCheck determines if the application has completed startup.
 	// Kubernetes uses this to know when a container has finished initialization.
 	// A failed startup check prevents the service from receiving traffic
@@ -59,6 +69,34 @@ func DefaultProbeHandlers() *ProbeHandlers {
 	}
 }
 
+// ProbePaths are the paths serving the Kubernetes probes.
+// An empty path disables that probe.
+type ProbePaths struct {
+	Liveness  string
+	Readiness string
+	Startup   string
+}
+
+// DefaultProbePaths returns the probe paths under /internal.
+func DefaultProbePaths() *ProbePaths {
+	return &ProbePaths{
+		Liveness:  "/internal/health",
+		Readiness: "/internal/ready",
+		Startup:   "/internal/startup",
+	}
+}
+
+// All returns the enabled probe paths.
+func (p *ProbePaths) All() []string {
+	var paths []string
+	for _, path := range []string{p.Liveness, p.Readiness, p.Startup} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
 // NewProbeResponse creates a ProbeResponse with the given values.
 // This is a convenience function for creating consistent probe responses.
 //
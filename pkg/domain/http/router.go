@@ -5,11 +5,18 @@
 package http
 
 import (
-	"fmt"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"net"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/net/http/httpguts"
 
 	"github.com/damianoneill/go-bootstrap/pkg/domain/logging"
 	"github.com/damianoneill/go-bootstrap/pkg/domain/metrics"
@@ -47,6 +54,12 @@ type MiddlewareOrdering struct {
 	Order []MiddlewareCategory
 	// CustomMiddleware allows adding middleware to specific categories
 	CustomMiddleware map[MiddlewareCategory][]func(http.Handler) http.Handler
+	// CategoryTimeouts bounds the request from the start of a category
+	// onwards. The core timeout replaces the default 30s request timeout;
+	// other categories add a timeout before their middleware. Deadlines
+	// nest, so the shortest applicable timeout wins, and
+	// WithMaxRequestDuration still caps the whole request.
+	CategoryTimeouts map[MiddlewareCategory]time.Duration
 }
 
 // requiredCategories defines the middleware categories that must be present
@@ -56,6 +69,120 @@ var requiredCategories = map[MiddlewareCategory]struct{}{
 	ObservabilityMiddleware: {},
 }
 
+// HTTPSMode determines how plaintext requests are handled when HTTPS is required
+type HTTPSMode string
+
+const (
+	// HTTPSRedirect redirects plaintext requests to HTTPS with 308 Permanent Redirect
+	HTTPSRedirect HTTPSMode = "redirect"
+
+	// HTTPSReject rejects plaintext requests with 400 Bad Request
+	HTTPSReject HTTPSMode = "reject"
+)
+
+// CSRFOptions configures double-submit cookie CSRF protection
+type CSRFOptions struct {
+	// Secret signs issued tokens so forged cookies are rejected.
+	// It must be at least 32 bytes and shared by all replicas.
+	Secret []byte
+
+	// CookieName is the cookie holding the token.
+	// If empty, defaults to "csrf_token".
+	CookieName string
+
+	// HeaderName is the request header API clients send the token in.
+	// If empty, defaults to "X-CSRF-Token".
+	HeaderName string
+
+	// FieldName is the form field HTML forms send the token in.
+	// If empty, defaults to "csrf_token".
+	FieldName string
+
+	// Secure restricts the cookie to HTTPS connections
+	Secure bool
+}
+
+// SecurityHeaderOptions configures the security headers set on every
+// response. Empty fields take hardened defaults.
+type SecurityHeaderOptions struct {
+	// FrameOptions is the X-Frame-Options value, "DENY" or "SAMEORIGIN".
+	// If empty, defaults to "DENY".
+	FrameOptions string
+
+	// HSTSMaxAge is the Strict-Transport-Security max-age, sent only on
+	// requests received over HTTPS. If zero, defaults to one year.
+	HSTSMaxAge time.Duration
+
+	// HSTSIncludeSubdomains extends HSTS to all subdomains
+	HSTSIncludeSubdomains bool
+
+	// ContentSecurityPolicy is the Content-Security-Policy value.
+	// If empty, defaults to "default-src 'self'; frame-ancestors 'none'".
+	ContentSecurityPolicy string
+
+	// ReferrerPolicy is the Referrer-Policy value.
+	// If empty, defaults to "no-referrer".
+	ReferrerPolicy string
+}
+
+// BasicAuthOptions configures HTTP basic authentication for a set of paths
+type BasicAuthOptions struct {
+	// Realm is reported in the WWW-Authenticate challenge
+	Realm string
+
+	// Credentials maps usernames to passwords
+	Credentials map[string]string
+
+	// Paths are the path prefixes requiring authentication.
+	// If empty, defaults to the internal endpoints and the metrics path.
+	Paths []string
+}
+
+// JWTOptions configures bearer token authentication
+type JWTOptions struct {
+	// JWKSURL is the identity provider's JSON Web Key Set endpoint.
+	// Exactly one of JWKSURL and Key must be set.
+	JWKSURL string
+
+	// Key is a static verification key: []byte for HS256, HS384 and HS512,
+	// *rsa.PublicKey for RS256, RS384 and RS512, or *ecdsa.PublicKey for
+	// ES256, ES384 and ES512.
+	Key interface{}
+
+	// Issuer is the required iss claim. If empty, it is not checked.
+	Issuer string
+
+	// Audience must appear in the aud claim. If empty, it is not checked.
+	Audience string
+
+	// ClaimsToContext maps claim names to request context keys.
+	// Claims present in a valid token are stored under their keys.
+	ClaimsToContext map[string]interface{}
+
+	// Leeway allows for clock skew when checking exp and nbf
+	Leeway time.Duration
+
+	// AllowMissingExpiry accepts tokens without an exp claim. By default
+	// such tokens are rejected, since they never expire.
+	AllowMissingExpiry bool
+}
+
+// ResponseInfo describes the outcome of a handled request.
+// It is passed to access log field functions once the handler has completed.
+type ResponseInfo struct {
+	// Status is the HTTP status code written by the handler
+	Status int
+
+	// BytesWritten is the number of response body bytes written
+	BytesWritten int
+
+	// Duration is the total time taken to handle the request
+	Duration time.Duration
+}
+
+// AccessLogFieldsFunc builds the structured fields logged for a request.
+type AccessLogFieldsFunc func(req *http.Request, resp ResponseInfo) logging.Fields
+
 // Router extends chi.Router to provide additional service capabilities.
 // It inherits all standard HTTP routing functionality from chi while allowing
 // implementations to add service-specific features like health probes and
@@ -64,6 +191,15 @@ type Router interface {
 	chi.Router
 }
 
+// MetricsProvider is implemented by routers that expose the metrics
+// collector they created, so handlers can record custom metrics through
+// the same instance.
+type MetricsProvider interface {
+	// Metrics returns the router's metrics collector, or nil if metrics
+	// are not configured
+	Metrics() metrics.Collector
+}
+
 // RouterOptions configures router behavior and service capabilities.
 // It focuses on service-level configuration rather than HTTP-specific settings
 // which are handled directly by chi.Router.
@@ -88,10 +224,18 @@ type RouterOptions struct {
 	// If not set, metrics will be disabled
 	MetricsFactory metrics.Factory
 
+	// MetricsOptions are passed to MetricsFactory when creating the
+	// collector, after the service name and version labels
+	MetricsOptions []metrics.Option
+
 	// ProbeHandlers configures Kubernetes probe endpoints.
 	// If not set, default handlers returning healthy will be used.
 	ProbeHandlers *ProbeHandlers
 
+	// ProbePaths sets where the probes are served.
+	// If not set, defaults to /internal/health, /internal/ready and /internal/startup.
+	ProbePaths *ProbePaths
+
 	// ExcludeFromLogging lists paths that should not be logged.
 	// Typically used for high-volume health check endpoints to reduce noise.
 	// Paths should be exact matches like "/internal/health".
@@ -105,6 +249,121 @@ type RouterOptions struct {
 	// MiddlewareOrdering configures middleware ordering
 	// If not set, defaults to [Core, Security, Application, Observability]
 	MiddlewareOrdering *MiddlewareOrdering
+
+	// MaxRequestBodySize limits the number of bytes read from a request body.
+	// Requests exceeding the limit are rejected with 413 Request Entity Too Large.
+	// If zero, request bodies are not limited.
+	MaxRequestBodySize int64
+
+	// MaxRequestDuration caps the total time a handler may run. The request
+	// context is cancelled when the cap is reached so downstream calls abort.
+	// If zero, no cap is applied beyond the router's base timeout.
+	MaxRequestDuration time.Duration
+
+	// AccessLogFields builds the fields logged for each request.
+	// If not set, method, path, status, duration, size and request_id are logged.
+	AccessLogFields AccessLogFieldsFunc
+
+	// LoggedContextKeys maps log field names to request context keys.
+	// Values found in the request context are added to the access log.
+	LoggedContextKeys map[string]interface{}
+
+	// ContextValues are added to every request context, making constant
+	// values such as region or build info available to all handlers.
+	// Keys should be unexported typed values, not strings, to avoid
+	// collisions between packages.
+	ContextValues map[interface{}]interface{}
+
+	// EnablePprof mounts net/http/pprof handlers under /internal/debug/pprof.
+	// Profiling data is sensitive, so this is disabled unless explicitly enabled.
+	EnablePprof bool
+
+	// EnableRouteViewer mounts /internal/routes, listing the registered
+	// method and pattern pairs as JSON.
+	EnableRouteViewer bool
+
+	// RequireHTTPS redirects or rejects requests not made over HTTPS.
+	// If empty, plaintext requests are served normally.
+	RequireHTTPS HTTPSMode
+
+	// TrustedProxies lists the IPs or CIDRs allowed to set X-Forwarded-Proto.
+	// If empty, the header is honored from any peer.
+	TrustedProxies []string
+
+	// AllowedHosts lists the Host header values accepted, optionally with a
+	// leading "*." wildcard. Other hosts are rejected with 400.
+	// If empty, any host is accepted.
+	AllowedHosts []string
+
+	// ResponseHeaders are set on every response unless already present,
+	// for example Server or X-Service-Version.
+	ResponseHeaders map[string]string
+
+	// SecurityHeaders configures the security headers set on every response.
+	// If not set, only X-Content-Type-Options, X-Frame-Options and
+	// X-XSS-Protection are set.
+	SecurityHeaders *SecurityHeaderOptions
+
+	// BasicAuth requires HTTP basic authentication for matching paths.
+	// Health probes are always exempt.
+	// If not set, no paths require authentication.
+	BasicAuth *BasicAuthOptions
+
+	// JWTAuth requires a valid bearer token on application routes.
+	// Internal endpoints and the metrics path are exempt.
+	// If not set, bearer tokens are not checked.
+	JWTAuth *JWTOptions
+
+	// CSRF rejects unsafe requests that do not echo the CSRF cookie's
+	// token in a header or form field.
+	// Internal endpoints and the metrics path are exempt.
+	// If not set, CSRF protection is disabled.
+	CSRF *CSRFOptions
+
+	// InternalRouter receives the internal endpoints (probes, metrics and
+	// diagnostics) instead of the main router, so they can be served on a
+	// separate listener. If not set, they are mounted on the main router.
+	InternalRouter chi.Router
+
+	// RequestIDHeader is the header an incoming request ID is read from,
+	// for example one set by a load balancer, and echoed on the response.
+	// A request without a usable ID gets a generated one.
+	// If not set, chi's X-Request-Id handling is used and IDs are not echoed.
+	RequestIDHeader string
+
+	// MetricsPath is the path serving Prometheus metrics.
+	// If not set, defaults to "/metrics".
+	MetricsPath string
+
+	// DisableOpenMetrics serves the metrics path in the classic Prometheus
+	// text format only. By default, scrapers that negotiate OpenMetrics
+	// receive it, which is needed for exemplars.
+	DisableOpenMetrics bool
+
+	// UnmatchedPathLabel is the metrics path label used for requests that
+	// match no route, keeping label cardinality bounded when scanners probe
+	// random URLs. If not set, defaults to "unmatched".
+	UnmatchedPathLabel string
+
+	// SpanNameFormatter names the server span of each request. It is called
+	// once routing has completed, so the chi route pattern is available.
+	// If not set, spans are named "METHOD /pattern".
+	SpanNameFormatter func(req *http.Request) string
+
+	// SpanAttributes returns application-specific attributes added to the
+	// server span of each request, after the handler has run.
+	// If not set, only the route, request ID and response size are added.
+	SpanAttributes func(req *http.Request) []attribute.KeyValue
+
+	// UserAgentClassifier maps a User-Agent header to a client class
+	// counted in the http_requests_by_client metric.
+	// If not set, requests are not counted by client.
+	UserAgentClassifier func(userAgent string) string
+
+	// ReadinessInitialDelay is the window after router creation during which
+	// readiness reports "starting" without running checks.
+	// If zero, checks run immediately.
+	ReadinessInitialDelay time.Duration
 }
 
 // Option is a function that modifies RouterOptions following the
@@ -119,7 +378,7 @@ type Option = options.Option[RouterOptions]
 func WithService(name, version string) Option {
 	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
 		if name == "" {
-			return fmt.Errorf("service name cannot be empty")
+			return options.Invalid("ServiceName", "service name cannot be empty")
 		}
 		o.ServiceName = name
 		o.ServiceVersion = version
@@ -145,10 +404,12 @@ func WithTracingProvider(provider tracing.Provider) Option {
 	})
 }
 
-// WithMetricsFactory sets the metrics factory for creating collectors
-func WithMetricsFactory(factory metrics.Factory) Option {
+// WithMetricsFactory sets the metrics factory for creating collectors.
+// Any metrics options, such as custom buckets, are applied to the collector.
+func WithMetricsFactory(factory metrics.Factory, opts ...metrics.Option) Option {
 	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
 		o.MetricsFactory = factory
+		o.MetricsOptions = opts
 		return nil
 	})
 }
@@ -162,6 +423,28 @@ func WithProbeHandlers(handlers *ProbeHandlers) Option {
 	})
 }
 
+// WithProbePaths serves the liveness, readiness and startup probes at the
+// given paths instead of under /internal, for example "/healthz". An empty
+// path disables that probe. Health probes at these paths remain exempt from
+// request-rejecting security middleware.
+func WithProbePaths(liveness, readiness, startup string) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		paths := &ProbePaths{Liveness: liveness, Readiness: readiness, Startup: startup}
+		seen := make(map[string]bool)
+		for _, path := range paths.All() {
+			if !strings.HasPrefix(path, "/") {
+				return options.Invalid("ProbePaths", "probe path must start with /: %s", path)
+			}
+			if seen[path] {
+				return options.Invalid("ProbePaths", "duplicate probe path: %s", path)
+			}
+			seen[path] = true
+		}
+		o.ProbePaths = paths
+		return nil
+	})
+}
+
 // WithObservabilityExclusions sets paths to exclude from both
 // logging and tracing. This is typically used for health check
 // endpoints to reduce observability noise.
@@ -173,10 +456,10 @@ func WithObservabilityExclusions(loggingPaths []string, tracingPaths []string) O
 		seen := make(map[string]bool)
 		for _, path := range loggingPaths {
 			if !strings.HasPrefix(path, "/") {
-				return fmt.Errorf("path must start with /: %s", path)
+				return options.Invalid("ExcludeFromLogging", "path must start with /: %s", path)
 			}
 			if seen[path] {
-				return fmt.Errorf("duplicate logging path: %s", path)
+				return options.Invalid("ExcludeFromLogging", "duplicate logging path: %s", path)
 			}
 			seen[path] = true
 		}
@@ -185,10 +468,10 @@ func WithObservabilityExclusions(loggingPaths []string, tracingPaths []string) O
 		seen = make(map[string]bool)
 		for _, path := range tracingPaths {
 			if !strings.HasPrefix(path, "/") {
-				return fmt.Errorf("path must start with /: %s", path)
+				return options.Invalid("ExcludeFromTracing", "path must start with /: %s", path)
 			}
 			if seen[path] {
-				return fmt.Errorf("duplicate tracing path: %s", path)
+				return options.Invalid("ExcludeFromTracing", "duplicate tracing path: %s", path)
 			}
 			seen[path] = true
 		}
@@ -199,10 +482,414 @@ func WithObservabilityExclusions(loggingPaths []string, tracingPaths []string) O
 	})
 }
 
+// WithMaxRequestBodySize limits request bodies to the given number of bytes.
+// This complements the server's MaxHeaderSize by protecting handlers from
+// clients sending arbitrarily large payloads.
+func WithMaxRequestBodySize(size int64) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if size <= 0 {
+			return options.Invalid("MaxRequestBodySize", "max request body size must be positive")
+		}
+		o.MaxRequestBodySize = size
+		return nil
+	})
+}
+
+// WithMaxRequestDuration sets a hard cap on handler execution time.
+// When the cap is reached the request context is cancelled, the timeout is
+// logged, and a 504 Gateway Timeout is returned if the handler has not
+// already written a response. Handlers must respect context cancellation
+// for the cap to take effect.
+func WithMaxRequestDuration(d time.Duration) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if d <= 0 {
+			return options.Invalid("MaxRequestDuration", "max request duration must be positive")
+		}
+		o.MaxRequestDuration = d
+		return nil
+	})
+}
+
+// WithAccessLogFields sets the function used to build access log fields,
+// giving callers full control over the logged schema. For example, to add
+// the user agent and drop the response size:
+//
+//	WithAccessLogFields(func(r *http.Request, resp ResponseInfo) logging.Fields {
+//	    return logging.Fields{
+//	        "method":     r.Method,
+//	        "path":       r.URL.Path,
+//	        "status":     resp.Status,
+//	        "user_agent": r.UserAgent(),
+//	    }
+//	})
+func WithAccessLogFields(fn AccessLogFieldsFunc) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if fn == nil {
+			return options.Invalid("AccessLogFields", "access log fields function cannot be nil")
+		}
+		o.AccessLogFields = fn
+		return nil
+	})
+}
+
+// WithLoggedContextKeys logs request context values set by upstream
+// middleware, such as an auth subject or tenant. Keys are log field names
+// and values are the context keys to read; absent values are not logged.
+func WithLoggedContextKeys(keys map[string]interface{}) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		logged := make(map[string]interface{}, len(keys))
+		for field, key := range keys {
+			if key == nil {
+				return options.Invalid("LoggedContextKeys", "context key for field %s cannot be nil", field)
+			}
+			logged[field] = key
+		}
+		o.LoggedContextKeys = logged
+		return nil
+	})
+}
+
+// WithContextValues adds each key/value pair to every request context,
+// read back in handlers with r.Context().Value(key). As with
+// context.WithValue, keys should be of an unexported type rather than a
+// string or other built-in type, for example:
+//
+//	type regionKey struct{}
+//	WithContextValues(map[interface{}]interface{}{regionKey{}: "eu-west-1"})
+//
+// Calling it more than once adds to the values already set.
+func WithContextValues(values map[interface{}]interface{}) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if o.ContextValues == nil {
+			o.ContextValues = make(map[interface{}]interface{}, len(values))
+		}
+		for key, value := range values {
+			if key == nil {
+				return options.Invalid("ContextValues", "context value key cannot be nil")
+			}
+			o.ContextValues[key] = value
+		}
+		return nil
+	})
+}
+
+// WithPprof enables or disables the pprof profiling endpoints
+// mounted under /internal/debug/pprof.
+func WithPprof(enabled bool) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		o.EnablePprof = enabled
+		return nil
+	})
+}
+
+// WithRouteViewer enables or disables the route table endpoint mounted
+// at /internal/routes. Routes are listed when the endpoint is requested,
+// so routes registered after the router is created are included.
+func WithRouteViewer(enabled bool) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		o.EnableRouteViewer = enabled
+		return nil
+	})
+}
+
+// WithRequireHTTPS enforces HTTPS for all requests except health probes.
+// A request is considered secure when it arrived over TLS or when a trusted
+// proxy set X-Forwarded-Proto to "https". Trusted proxies are given as IPs
+// or CIDRs; if none are given the header is honored from any peer, which is
+// only appropriate when the service is reachable exclusively via the proxy.
+// Redirects target the request's Host, so combine redirect mode with
+// WithAllowedHosts, which is checked first, unless a proxy validates it.
+func WithRequireHTTPS(mode HTTPSMode, trustedProxies ...string) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if mode != HTTPSRedirect && mode != HTTPSReject {
+			return options.Invalid("RequireHTTPS", "invalid HTTPS mode: %s", mode)
+		}
+		for _, proxy := range trustedProxies {
+			if _, _, err := net.ParseCIDR(proxy); err == nil {
+				continue
+			}
+			if net.ParseIP(proxy) == nil {
+				return options.Invalid("TrustedProxies", "invalid trusted proxy: %s", proxy)
+			}
+		}
+		o.RequireHTTPS = mode
+		o.TrustedProxies = trustedProxies
+		return nil
+	})
+}
+
+// WithAllowedHosts rejects requests whose Host header is not in the list,
+// protecting against host header attacks. Hosts are matched without the
+// port and case-insensitively. A leading "*." matches any subdomain, so
+// "*.example.com" matches "api.example.com" but not "example.com".
+// Health probes are exempt so Kubernetes can reach them by pod IP.
+func WithAllowedHosts(hosts []string) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if len(hosts) == 0 {
+			return options.Invalid("AllowedHosts", "allowed hosts cannot be empty")
+		}
+		for _, host := range hosts {
+			if host == "" || strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+				return options.Invalid("AllowedHosts", "invalid allowed host: %q", host)
+			}
+		}
+		o.AllowedHosts = hosts
+		return nil
+	})
+}
+
+// WithResponseHeaders sets static headers on every response. Headers are
+// set before the handler runs, so a value set by the handler replaces the
+// static one, and headers already set by earlier middleware are kept.
+func WithResponseHeaders(headers map[string]string) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if len(headers) == 0 {
+			return options.Invalid("ResponseHeaders", "response headers cannot be empty")
+		}
+		for name := range headers {
+			if name == "" {
+				return options.Invalid("ResponseHeaders", "response header name cannot be empty")
+			}
+		}
+		o.ResponseHeaders = headers
+		return nil
+	})
+}
+
+// WithSecurityHeaders sets a hardened baseline of security headers on
+// every response: X-Content-Type-Options, X-Frame-Options, X-XSS-Protection,
+// Content-Security-Policy and Referrer-Policy, plus Strict-Transport-Security
+// on requests received over HTTPS. Empty fields take their defaults.
+func WithSecurityHeaders(headers SecurityHeaderOptions) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		switch strings.ToUpper(headers.FrameOptions) {
+		case "":
+			headers.FrameOptions = "DENY"
+		case "DENY", "SAMEORIGIN":
+			headers.FrameOptions = strings.ToUpper(headers.FrameOptions)
+		default:
+			return options.Invalid("SecurityHeaders", "invalid frame options: %s", headers.FrameOptions)
+		}
+		if headers.HSTSMaxAge < 0 {
+			return options.Invalid("SecurityHeaders", "HSTS max age cannot be negative")
+		}
+		if headers.HSTSMaxAge == 0 {
+			headers.HSTSMaxAge = 365 * 24 * time.Hour
+		}
+		if headers.ContentSecurityPolicy == "" {
+			headers.ContentSecurityPolicy = "default-src 'self'; frame-ancestors 'none'"
+		}
+		if headers.ReferrerPolicy == "" {
+			headers.ReferrerPolicy = "no-referrer"
+		}
+		o.SecurityHeaders = &headers
+		return nil
+	})
+}
+
+// WithBasicAuth requires HTTP basic authentication for requests whose path
+// starts with one of the given prefixes, defaulting to the internal
+// endpoints and the metrics path. Health probes are exempt so Kubernetes can
+// reach them. Requests without valid credentials receive 401 with a
+// WWW-Authenticate challenge for realm.
+func WithBasicAuth(realm string, creds map[string]string, paths ...string) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if len(creds) == 0 {
+			return options.Invalid("BasicAuth", "basic auth credentials cannot be empty")
+		}
+		for user := range creds {
+			if user == "" || strings.Contains(user, ":") {
+				return options.Invalid("BasicAuth", "invalid basic auth username: %q", user)
+			}
+		}
+		for _, p := range paths {
+			if !strings.HasPrefix(p, "/") {
+				return options.Invalid("BasicAuth", "basic auth path must start with /: %s", p)
+			}
+		}
+		o.BasicAuth = &BasicAuthOptions{
+			Realm:       realm,
+			Credentials: creds,
+			Paths:       paths,
+		}
+		return nil
+	})
+}
+
+// WithJWTAuth requires requests to application routes to carry a valid
+// JWT in the Authorization: Bearer header, verified against a JWKS endpoint
+// or a static key. Expired tokens, tokens not yet valid and tokens from
+// another issuer or for another audience are rejected with 401.
+func WithJWTAuth(jwt JWTOptions) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if (jwt.JWKSURL == "") == (jwt.Key == nil) {
+			return options.Invalid("JWTAuth", "JWT auth requires exactly one of JWKS URL and key")
+		}
+		if jwt.JWKSURL != "" {
+			if u, err := url.Parse(jwt.JWKSURL); err != nil || !u.IsAbs() {
+				return options.Invalid("JWTAuth", "invalid JWKS URL: %s", jwt.JWKSURL)
+			}
+		}
+		switch key := jwt.Key.(type) {
+		case nil, *rsa.PublicKey, *ecdsa.PublicKey:
+		case []byte:
+			if len(key) == 0 {
+				return options.Invalid("JWTAuth", "JWT key cannot be empty")
+			}
+		default:
+			return options.Invalid("JWTAuth", "unsupported JWT key type: %T", jwt.Key)
+		}
+		if jwt.Leeway < 0 {
+			return options.Invalid("JWTAuth", "JWT leeway cannot be negative")
+		}
+		o.JWTAuth = &jwt
+		return nil
+	})
+}
+
+// WithCSRF enables double-submit cookie CSRF protection in the security
+// middleware. Safe methods (GET, HEAD, OPTIONS and TRACE) are exempt and
+// are issued a signed token cookie. Other methods must send the same token
+// in the configured header or form field, or are rejected with 403.
+func WithCSRF(csrf CSRFOptions) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if len(csrf.Secret) < 32 {
+			return options.Invalid("CSRF", "CSRF secret must be at least 32 bytes")
+		}
+		if csrf.CookieName == "" {
+			csrf.CookieName = "csrf_token"
+		}
+		if csrf.HeaderName == "" {
+			csrf.HeaderName = "X-CSRF-Token"
+		}
+		if csrf.FieldName == "" {
+			csrf.FieldName = "csrf_token"
+		}
+		o.CSRF = &csrf
+		return nil
+	})
+}
+
+// WithReadinessInitialDelay makes readiness report "starting" with a 503
+// for the given window after startup, without running the readiness check,
+// so slow-starting dependencies are not probed before they can connect.
+func WithReadinessInitialDelay(delay time.Duration) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if delay <= 0 {
+			return options.Invalid("ReadinessInitialDelay", "readiness initial delay must be positive")
+		}
+		o.ReadinessInitialDelay = delay
+		return nil
+	})
+}
+
+// WithMetricsPath sets the path serving Prometheus metrics.
+// The path is automatically excluded from logging and tracing.
+func WithMetricsPath(path string) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if !strings.HasPrefix(path, "/") {
+			return options.Invalid("MetricsPath", "path must start with /: %s", path)
+		}
+		o.MetricsPath = path
+		return nil
+	})
+}
+
+// WithRequestIDHeader reads the request ID from header, generating one when
+// the request carries none, and echoes it on the response under the same
+// header. The ID is the request_id logged for the request.
+func WithRequestIDHeader(header string) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if header == "" {
+			return options.Invalid("RequestIDHeader", "request ID header cannot be empty")
+		}
+		if !httpguts.ValidHeaderFieldName(header) {
+			return options.Invalid("RequestIDHeader", "invalid request ID header: %s", header)
+		}
+		o.RequestIDHeader = header
+		return nil
+	})
+}
+
+// WithOpenMetrics enables or disables the OpenMetrics exposition format on
+// the metrics path. It is enabled by default and negotiated through the
+// Accept header, so scrapers that do not ask for it get the classic format.
+func WithOpenMetrics(enabled bool) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		o.DisableOpenMetrics = !enabled
+		return nil
+	})
+}
+
+// WithUnmatchedPathLabel sets the metrics path label recorded for requests
+// that match no route.
+func WithUnmatchedPathLabel(label string) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if label == "" {
+			return options.Invalid("UnmatchedPathLabel", "unmatched path label cannot be empty")
+		}
+		o.UnmatchedPathLabel = label
+		return nil
+	})
+}
+
+// WithSpanNameFormatter sets how request spans are named. The formatter
+// should use the route pattern rather than the raw path, for example via
+// chi.RouteContext, to keep span names low cardinality.
+func WithSpanNameFormatter(formatter func(req *http.Request) string) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if formatter == nil {
+			return options.Invalid("SpanNameFormatter", "span name formatter cannot be nil")
+		}
+		o.SpanNameFormatter = formatter
+		return nil
+	})
+}
+
+// WithSpanAttributes adds the attributes returned by fn to the server span
+// of each request. It is called after the handler has run, so the route
+// pattern and any values the handler stored on the request are available.
+func WithSpanAttributes(fn func(req *http.Request) []attribute.KeyValue) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if fn == nil {
+			return options.Invalid("SpanAttributes", "span attributes function cannot be nil")
+		}
+		o.SpanAttributes = fn
+		return nil
+	})
+}
+
+// WithUserAgentMetrics counts requests by client class, as returned by
+// classifier for the request's User-Agent. The classifier must return a
+// small fixed set of values to keep metric cardinality low. If classifier
+// is nil, ClassifyUserAgent is used. Requires a metrics factory.
+func WithUserAgentMetrics(classifier func(userAgent string) string) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if classifier == nil {
+			classifier = ClassifyUserAgent
+		}
+		o.UserAgentClassifier = classifier
+		return nil
+	})
+}
+
+// WithInternalRouter mounts the internal endpoints on the given router
+// rather than on the main router. This allows probes and metrics to be
+// served from a dedicated admin listener, away from business traffic.
+func WithInternalRouter(router chi.Router) Option {
+	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
+		if router == nil {
+			return options.Invalid("InternalRouter", "internal router cannot be nil")
+		}
+		o.InternalRouter = router
+		return nil
+	})
+}
+
 // validateMiddlewareOrdering ensures all required categories are present
 func validateMiddlewareOrdering(order []MiddlewareCategory) error {
 	if len(order) == 0 {
-		return fmt.Errorf("middleware order cannot be empty")
+		return options.Invalid("MiddlewareOrdering", "middleware order cannot be empty")
 	}
 
 	// Track which categories we've seen
@@ -211,7 +898,7 @@ func validateMiddlewareOrdering(order []MiddlewareCategory) error {
 	// Check for duplicates and build seen set
 	for _, category := range order {
 		if seen[category] {
-			return fmt.Errorf("duplicate middleware category: %s", category)
+			return options.Invalid("MiddlewareOrdering", "duplicate middleware category: %s", category)
 		}
 		seen[category] = true
 	}
@@ -225,7 +912,7 @@ func validateMiddlewareOrdering(order []MiddlewareCategory) error {
 	}
 
 	if len(missing) > 0 {
-		return fmt.Errorf("missing required middleware categories: %s", strings.Join(missing, ", "))
+		return options.Invalid("MiddlewareOrdering", "missing required middleware categories: %s", strings.Join(missing, ", "))
 	}
 
 	return nil
@@ -235,12 +922,21 @@ func validateMiddlewareOrdering(order []MiddlewareCategory) error {
 func WithMiddlewareOrdering(ordering *MiddlewareOrdering) Option {
 	return options.OptionFunc[RouterOptions](func(o *RouterOptions) error {
 		if ordering == nil {
-			return fmt.Errorf("middleware ordering cannot be nil")
+			return options.Invalid("MiddlewareOrdering", "middleware ordering cannot be nil")
 		}
 
 		// Validate the ordering includes all required categories
 		if err := validateMiddlewareOrdering(ordering.Order); err != nil {
-			return fmt.Errorf("invalid middleware ordering: %w", err)
+			return options.Invalid("MiddlewareOrdering", "invalid middleware ordering: %v", err)
+		}
+
+		for category, timeout := range ordering.CategoryTimeouts {
+			if !slices.Contains(ordering.Order, category) {
+				return options.Invalid("MiddlewareOrdering", "timeout for unknown middleware category: %s", category)
+			}
+			if timeout <= 0 {
+				return options.Invalid("MiddlewareOrdering", "timeout for %s middleware must be positive", category)
+			}
 		}
 
 		// Validate custom middleware if provided
@@ -249,7 +945,7 @@ func WithMiddlewareOrdering(ordering *MiddlewareOrdering) Option {
 				// Check that custom middleware is only added to valid categories
 				if _, validCategory := requiredCategories[category]; !validCategory &&
 					category != ApplicationMiddleware {
-					return fmt.Errorf("invalid middleware category for custom middleware: %s", category)
+					return options.Invalid("MiddlewareOrdering", "invalid middleware category for custom middleware: %s", category)
 				}
 			}
 		}
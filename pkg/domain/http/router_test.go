@@ -2,12 +2,16 @@
 package http
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
 	mocklog "github.com/damianoneill/go-bootstrap/pkg/domain/logging/mocks"
+	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
 	mocktracing "github.com/damianoneill/go-bootstrap/pkg/domain/tracing/mocks"
 )
 
@@ -76,6 +80,89 @@ func TestRouterOptions(t *testing.T) {
 				assert.NotNil(t, got.ProbeHandlers)
 			},
 		},
+		{
+			name: "with max request body size",
+			options: []Option{
+				WithMaxRequestBodySize(1024),
+			},
+			validate: func(t *testing.T, got RouterOptions) {
+				assert.Equal(t, int64(1024), got.MaxRequestBodySize)
+			},
+		},
+		{
+			name: "with max request duration",
+			options: []Option{
+				WithMaxRequestDuration(5 * time.Second),
+			},
+			validate: func(t *testing.T, got RouterOptions) {
+				assert.Equal(t, 5*time.Second, got.MaxRequestDuration)
+			},
+		},
+		{
+			name: "with require https",
+			options: []Option{
+				WithRequireHTTPS(HTTPSReject, "10.0.0.0/8", "192.168.1.1"),
+			},
+			validate: func(t *testing.T, got RouterOptions) {
+				assert.Equal(t, HTTPSReject, got.RequireHTTPS)
+				assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.1"}, got.TrustedProxies)
+			},
+		},
+		{
+			name: "with user agent metrics defaults classifier",
+			options: []Option{
+				WithUserAgentMetrics(nil),
+			},
+			validate: func(t *testing.T, got RouterOptions) {
+				assert.NotNil(t, got.UserAgentClassifier)
+				assert.Equal(t, ClientCLI, got.UserAgentClassifier("curl/8.4.0"))
+			},
+		},
+		{
+			name: "with unmatched path label",
+			options: []Option{
+				WithUnmatchedPathLabel("other"),
+			},
+			validate: func(t *testing.T, got RouterOptions) {
+				assert.Equal(t, "other", got.UnmatchedPathLabel)
+			},
+		},
+		{
+			name: "with allowed hosts",
+			options: []Option{
+				WithAllowedHosts([]string{"example.com", "*.example.org"}),
+			},
+			validate: func(t *testing.T, got RouterOptions) {
+				assert.Equal(t, []string{"example.com", "*.example.org"}, got.AllowedHosts)
+			},
+		},
+		{
+			name: "with logged context keys",
+			options: []Option{
+				WithLoggedContextKeys(map[string]interface{}{"tenant": "tenant-key"}),
+			},
+			validate: func(t *testing.T, got RouterOptions) {
+				assert.Equal(t, map[string]interface{}{"tenant": "tenant-key"}, got.LoggedContextKeys)
+			},
+		},
+		{
+			name: "with readiness initial delay",
+			options: []Option{
+				WithReadinessInitialDelay(10 * time.Second),
+			},
+			validate: func(t *testing.T, got RouterOptions) {
+				assert.Equal(t, 10*time.Second, got.ReadinessInitialDelay)
+			},
+		},
+		{
+			name: "with metrics path",
+			options: []Option{
+				WithMetricsPath("/internal/metrics"),
+			},
+			validate: func(t *testing.T, got RouterOptions) {
+				assert.Equal(t, "/internal/metrics", got.MetricsPath)
+			},
+		},
 		{
 			name: "with multiple options",
 			options: []Option{
@@ -127,6 +214,301 @@ func TestRouterOptionsValidation(t *testing.T) {
 			},
 			wantErr: "service name cannot be empty",
 		},
+		{
+			name: "non-positive max request body size",
+			options: []Option{
+				WithMaxRequestBodySize(0),
+			},
+			wantErr: "max request body size must be positive",
+		},
+		{
+			name: "non-positive max request duration",
+			options: []Option{
+				WithMaxRequestDuration(0),
+			},
+			wantErr: "max request duration must be positive",
+		},
+		{
+			name: "nil access log fields function",
+			options: []Option{
+				WithAccessLogFields(nil),
+			},
+			wantErr: "access log fields function cannot be nil",
+		},
+		{
+			name: "invalid https mode",
+			options: []Option{
+				WithRequireHTTPS("upgrade"),
+			},
+			wantErr: "invalid HTTPS mode: upgrade",
+		},
+		{
+			name: "invalid trusted proxy",
+			options: []Option{
+				WithRequireHTTPS(HTTPSRedirect, "not-an-ip"),
+			},
+			wantErr: "invalid trusted proxy: not-an-ip",
+		},
+		{
+			name: "empty unmatched path label",
+			options: []Option{
+				WithUnmatchedPathLabel(""),
+			},
+			wantErr: "unmatched path label cannot be empty",
+		},
+		{
+			name: "empty allowed hosts",
+			options: []Option{
+				WithAllowedHosts(nil),
+			},
+			wantErr: "allowed hosts cannot be empty",
+		},
+		{
+			name: "invalid allowed host wildcard",
+			options: []Option{
+				WithAllowedHosts([]string{"api.*.com"}),
+			},
+			wantErr: `invalid allowed host: "api.*.com"`,
+		},
+		{
+			name: "nil internal router",
+			options: []Option{
+				WithInternalRouter(nil),
+			},
+			wantErr: "internal router cannot be nil",
+		},
+		{
+			name: "nil logged context key",
+			options: []Option{
+				WithLoggedContextKeys(map[string]interface{}{"tenant": nil}),
+			},
+			wantErr: "context key for field tenant cannot be nil",
+		},
+		{
+			name: "zero readiness initial delay",
+			options: []Option{
+				WithReadinessInitialDelay(0),
+			},
+			wantErr: "readiness initial delay must be positive",
+		},
+		{
+			name: "invalid metrics path",
+			options: []Option{
+				WithMetricsPath("metrics"),
+			},
+			wantErr: "path must start with /: metrics",
+		},
+		{
+			name: "valid response headers",
+			options: []Option{
+				WithResponseHeaders(map[string]string{"Server": "ordersvc"}),
+			},
+		},
+		{
+			name: "empty response headers",
+			options: []Option{
+				WithResponseHeaders(nil),
+			},
+			wantErr: "response headers cannot be empty",
+		},
+		{
+			name: "valid category timeouts",
+			options: []Option{
+				WithMiddlewareOrdering(&MiddlewareOrdering{
+					Order: []MiddlewareCategory{CoreMiddleware, SecurityMiddleware, ApplicationMiddleware, ObservabilityMiddleware},
+					CategoryTimeouts: map[MiddlewareCategory]time.Duration{
+						CoreMiddleware:        time.Minute,
+						ApplicationMiddleware: time.Second,
+					},
+				}),
+			},
+		},
+		{
+			name: "category timeout for category not in order",
+			options: []Option{
+				WithMiddlewareOrdering(&MiddlewareOrdering{
+					Order: []MiddlewareCategory{CoreMiddleware, SecurityMiddleware, ObservabilityMiddleware},
+					CategoryTimeouts: map[MiddlewareCategory]time.Duration{
+						ApplicationMiddleware: time.Second,
+					},
+				}),
+			},
+			wantErr: "timeout for unknown middleware category: application",
+		},
+		{
+			name: "non-positive category timeout",
+			options: []Option{
+				WithMiddlewareOrdering(&MiddlewareOrdering{
+					Order: []MiddlewareCategory{CoreMiddleware, SecurityMiddleware, ObservabilityMiddleware},
+					CategoryTimeouts: map[MiddlewareCategory]time.Duration{
+						CoreMiddleware: 0,
+					},
+				}),
+			},
+			wantErr: "timeout for core middleware must be positive",
+		},
+		{
+			name: "empty response header name",
+			options: []Option{
+				WithResponseHeaders(map[string]string{"": "value"}),
+			},
+			wantErr: "response header name cannot be empty",
+		},
+		{
+			name: "valid security headers",
+			options: []Option{
+				WithSecurityHeaders(SecurityHeaderOptions{FrameOptions: "sameorigin"}),
+			},
+		},
+		{
+			name: "invalid frame options",
+			options: []Option{
+				WithSecurityHeaders(SecurityHeaderOptions{FrameOptions: "ALLOW-FROM x"}),
+			},
+			wantErr: "invalid frame options: ALLOW-FROM x",
+		},
+		{
+			name: "negative HSTS max age",
+			options: []Option{
+				WithSecurityHeaders(SecurityHeaderOptions{HSTSMaxAge: -time.Second}),
+			},
+			wantErr: "HSTS max age cannot be negative",
+		},
+		{
+			name: "valid basic auth",
+			options: []Option{
+				WithBasicAuth("internal", map[string]string{"admin": "secret"}, "/internal", "/metrics"),
+			},
+		},
+		{
+			name: "empty basic auth credentials",
+			options: []Option{
+				WithBasicAuth("internal", nil),
+			},
+			wantErr: "basic auth credentials cannot be empty",
+		},
+		{
+			name: "basic auth username with colon",
+			options: []Option{
+				WithBasicAuth("internal", map[string]string{"ad:min": "secret"}),
+			},
+			wantErr: "invalid basic auth username",
+		},
+		{
+			name: "relative basic auth path",
+			options: []Option{
+				WithBasicAuth("internal", map[string]string{"admin": "secret"}, "internal"),
+			},
+			wantErr: "basic auth path must start with /: internal",
+		},
+		{
+			name: "valid JWT auth with JWKS",
+			options: []Option{
+				WithJWTAuth(JWTOptions{JWKSURL: "https://idp.example.com/.well-known/jwks.json"}),
+			},
+		},
+		{
+			name: "valid JWT auth with key",
+			options: []Option{
+				WithJWTAuth(JWTOptions{Key: []byte("secret"), Issuer: "https://idp.example.com"}),
+			},
+		},
+		{
+			name: "JWT auth without key source",
+			options: []Option{
+				WithJWTAuth(JWTOptions{}),
+			},
+			wantErr: "JWT auth requires exactly one of JWKS URL and key",
+		},
+		{
+			name: "JWT auth with both key sources",
+			options: []Option{
+				WithJWTAuth(JWTOptions{JWKSURL: "https://idp.example.com/jwks", Key: []byte("secret")}),
+			},
+			wantErr: "JWT auth requires exactly one of JWKS URL and key",
+		},
+		{
+			name: "relative JWKS URL",
+			options: []Option{
+				WithJWTAuth(JWTOptions{JWKSURL: "/jwks"}),
+			},
+			wantErr: "invalid JWKS URL: /jwks",
+		},
+		{
+			name: "unsupported JWT key type",
+			options: []Option{
+				WithJWTAuth(JWTOptions{Key: "secret"}),
+			},
+			wantErr: "unsupported JWT key type: string",
+		},
+		{
+			name: "valid probe paths",
+			options: []Option{
+				WithProbePaths("/healthz", "/readyz", ""),
+			},
+		},
+		{
+			name: "relative probe path",
+			options: []Option{
+				WithProbePaths("healthz", "/readyz", "/startupz"),
+			},
+			wantErr: "probe path must start with /: healthz",
+		},
+		{
+			name: "duplicate probe path",
+			options: []Option{
+				WithProbePaths("/healthz", "/healthz", ""),
+			},
+			wantErr: "duplicate probe path: /healthz",
+		},
+		{
+			name: "empty request ID header",
+			options: []Option{
+				WithRequestIDHeader(""),
+			},
+			wantErr: "request ID header cannot be empty",
+		},
+		{
+			name: "invalid request ID header",
+			options: []Option{
+				WithRequestIDHeader("X Correlation Id"),
+			},
+			wantErr: "invalid request ID header: X Correlation Id",
+		},
+		{
+			name: "nil context value key",
+			options: []Option{
+				WithContextValues(map[interface{}]interface{}{nil: "eu-west-1"}),
+			},
+			wantErr: "context value key cannot be nil",
+		},
+		{
+			name: "nil span name formatter",
+			options: []Option{
+				WithSpanNameFormatter(nil),
+			},
+			wantErr: "span name formatter cannot be nil",
+		},
+		{
+			name: "nil span attributes function",
+			options: []Option{
+				WithSpanAttributes(nil),
+			},
+			wantErr: "span attributes function cannot be nil",
+		},
+		{
+			name: "valid CSRF",
+			options: []Option{
+				WithCSRF(CSRFOptions{Secret: []byte(strings.Repeat("s", 32))}),
+			},
+		},
+		{
+			name: "short CSRF secret",
+			options: []Option{
+				WithCSRF(CSRFOptions{Secret: []byte("short")}),
+			},
+			wantErr: "CSRF secret must be at least 32 bytes",
+		},
 	}
 
 	for _, tt := range tests {
@@ -148,6 +530,16 @@ func TestRouterOptionsValidation(t *testing.T) {
 	}
 }
 
+func TestWithServiceValidationError(t *testing.T) {
+	err := WithService("", "1.0.0").ApplyOption(&RouterOptions{})
+
+	var verr *options.ValidationError
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, "ServiceName", verr.Field)
+		assert.Equal(t, "service name cannot be empty", verr.Reason)
+	}
+}
+
 func TestObservabilityExclusions(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -155,6 +547,7 @@ func TestObservabilityExclusions(t *testing.T) {
 		tracingPaths []string
 		validate     func(*testing.T, RouterOptions)
 		wantErr      string
+		wantField    string
 	}{
 		{
 			name:         "valid single path exclusions",
@@ -203,21 +596,25 @@ func TestObservabilityExclusions(t *testing.T) {
 			name:         "duplicate in logging paths",
 			loggingPaths: []string{"/health", "/health"},
 			wantErr:      "duplicate logging path: /health",
+			wantField:    "ExcludeFromLogging",
 		},
 		{
 			name:         "duplicate in tracing paths",
 			tracingPaths: []string{"/metrics", "/metrics"},
 			wantErr:      "duplicate tracing path: /metrics",
+			wantField:    "ExcludeFromTracing",
 		},
 		{
 			name:         "invalid logging path format",
 			loggingPaths: []string{"health"},
 			wantErr:      "path must start with /: health",
+			wantField:    "ExcludeFromLogging",
 		},
 		{
 			name:         "invalid tracing path format",
 			tracingPaths: []string{"metrics"},
 			wantErr:      "path must start with /: metrics",
+			wantField:    "ExcludeFromTracing",
 		},
 	}
 
@@ -229,6 +626,10 @@ func TestObservabilityExclusions(t *testing.T) {
 			if tt.wantErr != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.wantErr)
+				var verr *options.ValidationError
+				if assert.True(t, errors.As(err, &verr)) {
+					assert.Equal(t, tt.wantField, verr.Field)
+				}
 				return
 			}
 
@@ -239,3 +640,55 @@ func TestObservabilityExclusions(t *testing.T) {
 		})
 	}
 }
+
+func TestWithCSRFDefaults(t *testing.T) {
+	opts := RouterOptions{}
+	err := WithCSRF(CSRFOptions{Secret: []byte(strings.Repeat("s", 32)), Secure: true}).ApplyOption(&opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "csrf_token", opts.CSRF.CookieName)
+	assert.Equal(t, "X-CSRF-Token", opts.CSRF.HeaderName)
+	assert.Equal(t, "csrf_token", opts.CSRF.FieldName)
+	assert.True(t, opts.CSRF.Secure)
+}
+
+func TestWithSecurityHeadersDefaults(t *testing.T) {
+	opts := RouterOptions{}
+	err := WithSecurityHeaders(SecurityHeaderOptions{}).ApplyOption(&opts)
+	assert.NoError(t, err)
+	assert.Equal(t, &SecurityHeaderOptions{
+		FrameOptions:          "DENY",
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		ContentSecurityPolicy: "default-src 'self'; frame-ancestors 'none'",
+		ReferrerPolicy:        "no-referrer",
+	}, opts.SecurityHeaders)
+}
+
+func TestRouterOptionValidationErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		option    Option
+		wantField string
+	}{
+		{"probe paths", WithProbePaths("health", "/ready", "/startup"), "ProbePaths"},
+		{"max request body size", WithMaxRequestBodySize(0), "MaxRequestBodySize"},
+		{"https mode", WithRequireHTTPS("sometimes"), "RequireHTTPS"},
+		{"trusted proxies", WithRequireHTTPS(HTTPSRedirect, "not-an-ip"), "TrustedProxies"},
+		{"allowed hosts", WithAllowedHosts(nil), "AllowedHosts"},
+		{"security headers", WithSecurityHeaders(SecurityHeaderOptions{HSTSMaxAge: -time.Second}), "SecurityHeaders"},
+		{"jwt auth", WithJWTAuth(JWTOptions{}), "JWTAuth"},
+		{"csrf", WithCSRF(CSRFOptions{Secret: []byte("short")}), "CSRF"},
+		{"metrics path", WithMetricsPath("metrics"), "MetricsPath"},
+		{"middleware ordering", WithMiddlewareOrdering(&MiddlewareOrdering{}), "MiddlewareOrdering"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.option.ApplyOption(&RouterOptions{})
+
+			var verr *options.ValidationError
+			if assert.True(t, errors.As(err, &verr)) {
+				assert.Equal(t, tt.wantField, verr.Field)
+			}
+		})
+	}
+}
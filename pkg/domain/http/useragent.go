@@ -0,0 +1,49 @@
+package http
+
+import "strings"
+
+// Client classes returned by ClassifyUserAgent
+const (
+	ClientBrowser = "browser"
+	ClientBot     = "bot"
+	ClientCLI     = "cli"
+	ClientUnknown = "unknown"
+)
+
+// botMarkers identify crawlers and monitoring agents. They are checked
+// before browsers because most bots also claim to be Mozilla.
+var botMarkers = []string{"bot", "crawler", "spider", "slurp", "monitor"}
+
+// cliMarkers identify command line tools and HTTP client libraries
+var cliMarkers = []string{
+	"curl/", "wget/", "httpie/", "go-http-client/", "python-requests/",
+	"python-urllib/", "okhttp/", "java/", "node-fetch/", "axios/",
+}
+
+// ClassifyUserAgent buckets a User-Agent header into one of ClientBrowser,
+// ClientBot, ClientCLI or ClientUnknown.
+func ClassifyUserAgent(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return ClientUnknown
+	case containsAny(ua, botMarkers):
+		return ClientBot
+	case containsAny(ua, cliMarkers):
+		return ClientCLI
+	case strings.HasPrefix(ua, "mozilla/"):
+		return ClientBrowser
+	default:
+		return ClientUnknown
+	}
+}
+
+// containsAny reports whether s contains any of the substrings
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyUserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{
+			name:      "browser",
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Safari/605.1.15",
+			want:      ClientBrowser,
+		},
+		{
+			name:      "search engine bot claiming mozilla",
+			userAgent: "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			want:      ClientBot,
+		},
+		{
+			name:      "curl",
+			userAgent: "curl/8.4.0",
+			want:      ClientCLI,
+		},
+		{
+			name:      "go http client",
+			userAgent: "Go-http-client/1.1",
+			want:      ClientCLI,
+		},
+		{
+			name:      "empty",
+			userAgent: "",
+			want:      ClientUnknown,
+		},
+		{
+			name:      "unrecognised",
+			userAgent: "custom-agent",
+			want:      ClientUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyUserAgent(tt.userAgent))
+		})
+	}
+}
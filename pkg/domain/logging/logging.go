@@ -11,7 +11,7 @@ import (
 	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
 )
 
-//go:generate mockgen -destination=mocks/mock_logger.go -package=mocks github.com/damianoneill/go-bootstrap/pkg/domain/logging Logger,LeveledLogger,RuntimeConfigurable,Factory
+//go:generate mockgen -destination=mocks/mock_logger.go -package=mocks github.com/damianoneill/go-bootstrap/pkg/domain/logging Logger,LeveledLogger,RuntimeConfigurable,Buffered,Inspectable,Factory
 
 // Level represents logging severity levels.
 type Level string
@@ -28,12 +28,34 @@ const (
 
 	// ErrorLevel logs error conditions
 	ErrorLevel Level = "error"
+
+	// FatalLevel logs unrecoverable conditions, then terminates the process
+	FatalLevel Level = "fatal"
 )
 
 // Fields represents structured logging key-value pairs.
 // Keys should be strings, values can be any type.
 type Fields map[string]interface{}
 
+// CorrelationIDFunc extracts a correlation ID from a context.
+// It returns an empty string when no ID is available.
+type CorrelationIDFunc func(ctx context.Context) string
+
+// correlationIDKey is the context key for correlation IDs
+type correlationIDKey struct{}
+
+// ContextWithCorrelationID returns a copy of ctx carrying the correlation ID.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID stored by
+// ContextWithCorrelationID, or an empty string if none is set.
+func CorrelationIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
 // LoggerOptions holds configuration for logger implementations.
 type LoggerOptions struct {
 	// Level sets the minimum logging level
@@ -44,6 +66,14 @@ type LoggerOptions struct {
 
 	// Fields contains default fields added to all log entries
 	Fields Fields
+
+	// CorrelationID extracts a correlation ID used when no trace is active.
+	// If not set, CorrelationIDFromContext is used.
+	CorrelationID CorrelationIDFunc
+
+	// BufferSize is the number of recent entries retained in memory.
+	// Zero disables the buffer.
+	BufferSize int
 }
 
 // Option is a function that modifies LoggerOptions
@@ -90,6 +120,28 @@ func WithFields(fields Fields) Option {
 	})
 }
 
+// WithCorrelationIDFunc sets the function used to find a correlation ID
+// in a context. WithContext logs it as "correlation_id" when the context
+// has no active span, so logs stay correlated even with tracing disabled.
+func WithCorrelationIDFunc(fn CorrelationIDFunc) Option {
+	return options.OptionFunc[LoggerOptions](func(o *LoggerOptions) error {
+		o.CorrelationID = fn
+		return nil
+	})
+}
+
+// WithLogBuffer retains the most recent size entries in memory so they
+// can be served over HTTP without a log aggregator.
+func WithLogBuffer(size int) Option {
+	return options.OptionFunc[LoggerOptions](func(o *LoggerOptions) error {
+		if size <= 0 {
+			return options.Invalid("BufferSize", "log buffer size must be positive")
+		}
+		o.BufferSize = size
+		return nil
+	})
+}
+
 // Logger defines the core logging interface.
 // It provides both simple logging methods and methods that accept
 // additional structured fields.
@@ -108,6 +160,10 @@ type Logger interface {
 	// Error logs a message at error level
 	Error(msg string)
 
+	// Fatal logs a message at fatal level, then terminates the process
+	// with os.Exit(1). Deferred functions are not run.
+	Fatal(msg string)
+
 	// Structured logging methods with additional fields
 
 	// DebugWith logs a message at debug level with additional fields
@@ -122,13 +178,22 @@ type Logger interface {
 	// ErrorWith logs a message at error level with additional fields
 	ErrorWith(msg string, fields Fields)
 
+	// FatalWith logs a message at fatal level with additional fields, then
+	// terminates the process with os.Exit(1). Deferred functions are not run.
+	FatalWith(msg string, fields Fields)
+
 	// Context methods for creating derived loggers
 
 	// With returns a new Logger with additional default fields
 	With(fields Fields) Logger
 
+	// WithError returns a new Logger with err attached as the "error" field.
+	// Implementations may also attach the detailed %+v form of the error.
+	WithError(err error) Logger
+
 	// WithContext returns a new Logger with context information
-	// This typically adds trace IDs and other context metadata
+	// This typically adds trace IDs and other context metadata, falling
+	// back to a correlation ID when the context has no active span
 	WithContext(ctx context.Context) Logger
 }
 
@@ -151,6 +216,30 @@ type RuntimeConfigurable interface {
 	GetConfigHandler() http.Handler
 }
 
+// Buffered represents a logger that retains recent entries in memory
+// and can serve them through an HTTP endpoint.
+type Buffered interface {
+	// GetLogsHandler returns an http.Handler serving the buffered entries
+	// as JSON, oldest first
+	GetLogsHandler() http.Handler
+}
+
+// Inspectable represents a logger that can report its effective
+// configuration through an HTTP endpoint.
+type Inspectable interface {
+	// GetEffectiveConfigHandler returns an http.Handler serving the level,
+	// service name, default fields and encoding as JSON
+	GetEffectiveConfigHandler() http.Handler
+}
+
+// Exporting represents a logger that ships entries to an external
+// backend, such as an OpenTelemetry collector, and must be shut down
+// to flush entries still pending export.
+type Exporting interface {
+	// Shutdown flushes pending entries and stops the export
+	Shutdown(ctx context.Context) error
+}
+
 // Factory creates new logger instances
 type Factory interface {
 	// NewLogger creates a new LeveledLogger with the given options
@@ -2,7 +2,11 @@
 package logging
 
 import (
+	"context"
+	"errors"
 	"testing"
+
+	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
 )
 
 func defaultOptions() LoggerOptions {
@@ -97,3 +101,34 @@ func TestLoggerOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestCorrelationIDContext(t *testing.T) {
+	ctx := context.Background()
+	if got := CorrelationIDFromContext(ctx); got != "" {
+		t.Errorf("CorrelationIDFromContext() = %v, want empty", got)
+	}
+
+	ctx = ContextWithCorrelationID(ctx, "corr-123")
+	if got := CorrelationIDFromContext(ctx); got != "corr-123" {
+		t.Errorf("CorrelationIDFromContext() = %v, want %v", got, "corr-123")
+	}
+}
+
+func TestWithLogBuffer(t *testing.T) {
+	opts := defaultOptions()
+	if err := WithLogBuffer(100).ApplyOption(&opts); err != nil {
+		t.Fatalf("ApplyOption() error = %v", err)
+	}
+	if opts.BufferSize != 100 {
+		t.Errorf("BufferSize = %v, want %v", opts.BufferSize, 100)
+	}
+
+	err := WithLogBuffer(0).ApplyOption(&opts)
+	var verr *options.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("ApplyOption() error = %v, want *options.ValidationError", err)
+	}
+	if verr.Field != "BufferSize" {
+		t.Errorf("Field = %v, want %v", verr.Field, "BufferSize")
+	}
+}
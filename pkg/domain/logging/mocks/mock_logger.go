@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/damianoneill/go-bootstrap/pkg/domain/logging (interfaces: Logger,LeveledLogger,RuntimeConfigurable,Factory)
+// Source: github.com/damianoneill/go-bootstrap/pkg/domain/logging (interfaces: Logger,LeveledLogger,RuntimeConfigurable,Buffered,Inspectable,Factory)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks/mock_logger.go -package=mocks github.com/damianoneill/go-bootstrap/pkg/domain/logging Logger,LeveledLogger,RuntimeConfigurable,Factory
+//	mockgen -destination=mocks/mock_logger.go -package=mocks github.com/damianoneill/go-bootstrap/pkg/domain/logging Logger,LeveledLogger,RuntimeConfigurable,Buffered,Inspectable,Factory
 //
 
 // Package mocks is a generated GoMock package.
@@ -14,9 +14,8 @@ import (
 	http "net/http"
 	reflect "reflect"
 
-	gomock "go.uber.org/mock/gomock"
-
 	logging "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockLogger is a mock of Logger interface.
@@ -91,6 +90,30 @@ func (mr *MockLoggerMockRecorder) ErrorWith(msg, fields any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ErrorWith", reflect.TypeOf((*MockLogger)(nil).ErrorWith), msg, fields)
 }
 
+// Fatal mocks base method.
+func (m *MockLogger) Fatal(msg string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Fatal", msg)
+}
+
+// Fatal indicates an expected call of Fatal.
+func (mr *MockLoggerMockRecorder) Fatal(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatal", reflect.TypeOf((*MockLogger)(nil).Fatal), msg)
+}
+
+// FatalWith mocks base method.
+func (m *MockLogger) FatalWith(msg string, fields logging.Fields) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "FatalWith", msg, fields)
+}
+
+// FatalWith indicates an expected call of FatalWith.
+func (mr *MockLoggerMockRecorder) FatalWith(msg, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FatalWith", reflect.TypeOf((*MockLogger)(nil).FatalWith), msg, fields)
+}
+
 // Info mocks base method.
 func (m *MockLogger) Info(msg string) {
 	m.ctrl.T.Helper()
@@ -167,6 +190,20 @@ func (mr *MockLoggerMockRecorder) WithContext(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockLogger)(nil).WithContext), ctx)
 }
 
+// WithError mocks base method.
+func (m *MockLogger) WithError(err error) logging.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithError", err)
+	ret0, _ := ret[0].(logging.Logger)
+	return ret0
+}
+
+// WithError indicates an expected call of WithError.
+func (mr *MockLoggerMockRecorder) WithError(err any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithError", reflect.TypeOf((*MockLogger)(nil).WithError), err)
+}
+
 // MockLeveledLogger is a mock of LeveledLogger interface.
 type MockLeveledLogger struct {
 	ctrl     *gomock.Controller
@@ -239,6 +276,30 @@ func (mr *MockLeveledLoggerMockRecorder) ErrorWith(msg, fields any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ErrorWith", reflect.TypeOf((*MockLeveledLogger)(nil).ErrorWith), msg, fields)
 }
 
+// Fatal mocks base method.
+func (m *MockLeveledLogger) Fatal(msg string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Fatal", msg)
+}
+
+// Fatal indicates an expected call of Fatal.
+func (mr *MockLeveledLoggerMockRecorder) Fatal(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatal", reflect.TypeOf((*MockLeveledLogger)(nil).Fatal), msg)
+}
+
+// FatalWith mocks base method.
+func (m *MockLeveledLogger) FatalWith(msg string, fields logging.Fields) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "FatalWith", msg, fields)
+}
+
+// FatalWith indicates an expected call of FatalWith.
+func (mr *MockLeveledLoggerMockRecorder) FatalWith(msg, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FatalWith", reflect.TypeOf((*MockLeveledLogger)(nil).FatalWith), msg, fields)
+}
+
 // GetLevel mocks base method.
 func (m *MockLeveledLogger) GetLevel() logging.Level {
 	m.ctrl.T.Helper()
@@ -341,6 +402,20 @@ func (mr *MockLeveledLoggerMockRecorder) WithContext(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockLeveledLogger)(nil).WithContext), ctx)
 }
 
+// WithError mocks base method.
+func (m *MockLeveledLogger) WithError(err error) logging.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithError", err)
+	ret0, _ := ret[0].(logging.Logger)
+	return ret0
+}
+
+// WithError indicates an expected call of WithError.
+func (mr *MockLeveledLoggerMockRecorder) WithError(err any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithError", reflect.TypeOf((*MockLeveledLogger)(nil).WithError), err)
+}
+
 // MockRuntimeConfigurable is a mock of RuntimeConfigurable interface.
 type MockRuntimeConfigurable struct {
 	ctrl     *gomock.Controller
@@ -379,6 +454,82 @@ func (mr *MockRuntimeConfigurableMockRecorder) GetConfigHandler() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigHandler", reflect.TypeOf((*MockRuntimeConfigurable)(nil).GetConfigHandler))
 }
 
+// MockBuffered is a mock of Buffered interface.
+type MockBuffered struct {
+	ctrl     *gomock.Controller
+	recorder *MockBufferedMockRecorder
+	isgomock struct{}
+}
+
+// MockBufferedMockRecorder is the mock recorder for MockBuffered.
+type MockBufferedMockRecorder struct {
+	mock *MockBuffered
+}
+
+// NewMockBuffered creates a new mock instance.
+func NewMockBuffered(ctrl *gomock.Controller) *MockBuffered {
+	mock := &MockBuffered{ctrl: ctrl}
+	mock.recorder = &MockBufferedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBuffered) EXPECT() *MockBufferedMockRecorder {
+	return m.recorder
+}
+
+// GetLogsHandler mocks base method.
+func (m *MockBuffered) GetLogsHandler() http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogsHandler")
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// GetLogsHandler indicates an expected call of GetLogsHandler.
+func (mr *MockBufferedMockRecorder) GetLogsHandler() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogsHandler", reflect.TypeOf((*MockBuffered)(nil).GetLogsHandler))
+}
+
+// MockInspectable is a mock of Inspectable interface.
+type MockInspectable struct {
+	ctrl     *gomock.Controller
+	recorder *MockInspectableMockRecorder
+	isgomock struct{}
+}
+
+// MockInspectableMockRecorder is the mock recorder for MockInspectable.
+type MockInspectableMockRecorder struct {
+	mock *MockInspectable
+}
+
+// NewMockInspectable creates a new mock instance.
+func NewMockInspectable(ctrl *gomock.Controller) *MockInspectable {
+	mock := &MockInspectable{ctrl: ctrl}
+	mock.recorder = &MockInspectableMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInspectable) EXPECT() *MockInspectableMockRecorder {
+	return m.recorder
+}
+
+// GetEffectiveConfigHandler mocks base method.
+func (m *MockInspectable) GetEffectiveConfigHandler() http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectiveConfigHandler")
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// GetEffectiveConfigHandler indicates an expected call of GetEffectiveConfigHandler.
+func (mr *MockInspectableMockRecorder) GetEffectiveConfigHandler() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectiveConfigHandler", reflect.TypeOf((*MockInspectable)(nil).GetEffectiveConfigHandler))
+}
+
 // MockFactory is a mock of Factory interface.
 type MockFactory struct {
 	ctrl     *gomock.Controller
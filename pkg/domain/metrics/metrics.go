@@ -5,7 +5,7 @@ import (
 	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
 )
 
-//go:generate mockgen -destination=mocks/mock_metrics.go -package=mocks github.com/damianoneill/go-bootstrap/pkg/domain/metrics Collector,Factory
+//go:generate mockgen -destination=mocks/mock_metrics.go -package=mocks github.com/damianoneill/go-bootstrap/pkg/domain/metrics Collector,ClientCollector,CounterHandle,GaugeHandle,HistogramHandle,Factory
 
 // Collector handles metrics recording for HTTP requests
 type Collector interface {
@@ -14,6 +14,52 @@ type Collector interface {
 
 	// Close performs any cleanup of the metrics collector
 	Close() error
+
+	// Counter returns a counter with the given label names, registering it
+	// on first use. Later calls with the same name return the same counter.
+	Counter(name string, labels ...string) CounterHandle
+
+	// Gauge returns a gauge with the given label names, registering it on
+	// first use. Later calls with the same name return the same gauge.
+	Gauge(name string, labels ...string) GaugeHandle
+
+	// Histogram returns a histogram with the given label names using the
+	// collector's buckets, registering it on first use. Later calls with
+	// the same name return the same histogram.
+	Histogram(name string, labels ...string) HistogramHandle
+}
+
+// CounterHandle records a monotonically increasing value.
+// Label values are given in the order the label names were declared.
+type CounterHandle interface {
+	// Inc increments the counter by 1
+	Inc(labelValues ...string)
+	// Add increments the counter by a non-negative value
+	Add(value float64, labelValues ...string)
+}
+
+// GaugeHandle records a value that can go up and down.
+// Label values are given in the order the label names were declared.
+type GaugeHandle interface {
+	// Set sets the gauge to value
+	Set(value float64, labelValues ...string)
+	// Add adds value, which may be negative, to the gauge
+	Add(value float64, labelValues ...string)
+}
+
+// HistogramHandle records observations in buckets.
+// Label values are given in the order the label names were declared.
+type HistogramHandle interface {
+	// Observe records a single observation
+	Observe(value float64, labelValues ...string)
+}
+
+// ClientCollector is implemented by collectors that can count requests
+// by client type
+type ClientCollector interface {
+	// CollectClientRequest records a request from the given client class.
+	// Classes should come from a small fixed set to bound cardinality.
+	CollectClientRequest(client string)
 }
 
 // Options configures the behavior of a metrics collector
@@ -31,6 +77,15 @@ type Options struct {
 	// Subsystem is an optional name added after the metrics namespace
 	// For example: namespace_subsystem_metric_name
 	Subsystem string
+
+	// RuntimeMetrics registers Go runtime and process metrics, such as
+	// go_goroutines and process_resident_memory_bytes, alongside the
+	// HTTP metrics
+	RuntimeMetrics bool
+
+	// MethodClass adds a method_class label to request metrics, "read"
+	// for safe methods such as GET and HEAD and "write" otherwise
+	MethodClass bool
 }
 
 // Option is a function that modifies Options
@@ -60,9 +115,14 @@ func WithServiceName(name string) Option {
 }
 
 // WithBuckets sets custom histogram buckets for latency metrics.
-// The buckets should be in ascending order.
+// The buckets must be in strictly ascending order.
 func WithBuckets(buckets []float64) Option {
 	return options.OptionFunc[Options](func(o *Options) error {
+		for i := 1; i < len(buckets); i++ {
+			if buckets[i] <= buckets[i-1] {
+				return options.Invalid("Buckets", "buckets must be in increasing order: %v", buckets)
+			}
+		}
 		o.Buckets = buckets
 		return nil
 	})
@@ -86,6 +146,26 @@ func WithSubsystem(subsystem string) Option {
 	})
 }
 
+// WithRuntimeMetrics enables Go runtime and process metrics.
+// They describe the whole process, so collectors sharing a registry
+// expose a single set.
+func WithRuntimeMetrics(enabled bool) Option {
+	return options.OptionFunc[Options](func(o *Options) error {
+		o.RuntimeMetrics = enabled
+		return nil
+	})
+}
+
+// WithMethodClass adds a method_class label of "read" or "write" to
+// request metrics, so read and write latency can be tracked against
+// separate SLOs. The exact method label is kept.
+func WithMethodClass(enabled bool) Option {
+	return options.OptionFunc[Options](func(o *Options) error {
+		o.MethodClass = enabled
+		return nil
+	})
+}
+
 // Factory creates new metrics collector instances
 type Factory interface {
 	// NewCollector creates a new metrics collector with the given options
@@ -2,7 +2,10 @@
 package metrics
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
 )
 
 func defaultMetricsOptions() Options {
@@ -42,6 +45,16 @@ func TestMetricsOptions(t *testing.T) {
 				Buckets:     []float64{0.1, 0.5, 1.0, 2.5},
 			},
 		},
+		{
+			name: "reject unordered buckets",
+			options: []Option{
+				WithBuckets([]float64{0.5, 0.1}),
+			},
+			expected: Options{
+				ServiceName: "unknown",
+			},
+			wantErr: true,
+		},
 		{
 			name: "set additional labels",
 			options: []Option{
@@ -62,6 +75,26 @@ func TestMetricsOptions(t *testing.T) {
 				Subsystem:   "auth",
 			},
 		},
+		{
+			name: "enable runtime metrics",
+			options: []Option{
+				WithRuntimeMetrics(true),
+			},
+			expected: Options{
+				ServiceName:    "unknown",
+				RuntimeMetrics: true,
+			},
+		},
+		{
+			name: "enable method class",
+			options: []Option{
+				WithMethodClass(true),
+			},
+			expected: Options{
+				ServiceName: "unknown",
+				MethodClass: true,
+			},
+		},
 		{
 			name: "set multiple options",
 			options: []Option{
@@ -112,6 +145,24 @@ func TestMetricsOptions(t *testing.T) {
 			if opts.Subsystem != tt.expected.Subsystem {
 				t.Errorf("Subsystem = %v, want %v", opts.Subsystem, tt.expected.Subsystem)
 			}
+			if opts.RuntimeMetrics != tt.expected.RuntimeMetrics {
+				t.Errorf("RuntimeMetrics = %v, want %v", opts.RuntimeMetrics, tt.expected.RuntimeMetrics)
+			}
+			if opts.MethodClass != tt.expected.MethodClass {
+				t.Errorf("MethodClass = %v, want %v", opts.MethodClass, tt.expected.MethodClass)
+			}
 		})
 	}
 }
+
+func TestWithBucketsValidationError(t *testing.T) {
+	err := WithBuckets([]float64{0.5, 0.1}).ApplyOption(&Options{})
+
+	var verr *options.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("WithBuckets() error = %v, want *options.ValidationError", err)
+	}
+	if verr.Field != "Buckets" {
+		t.Errorf("Field = %v, want %v", verr.Field, "Buckets")
+	}
+}
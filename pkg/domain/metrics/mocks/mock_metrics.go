@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/damianoneill/go-bootstrap/pkg/domain/metrics (interfaces: Collector,Factory)
+// Source: github.com/damianoneill/go-bootstrap/pkg/domain/metrics (interfaces: Collector,ClientCollector,CounterHandle,GaugeHandle,HistogramHandle,Factory)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks/mock_metrics.go -package=mocks github.com/damianoneill/go-bootstrap/pkg/domain/metrics Collector,Factory
+//	mockgen -destination=mocks/mock_metrics.go -package=mocks github.com/damianoneill/go-bootstrap/pkg/domain/metrics Collector,ClientCollector,CounterHandle,GaugeHandle,HistogramHandle,Factory
 //
 
 // Package mocks is a generated GoMock package.
@@ -12,9 +12,8 @@ package mocks
 import (
 	reflect "reflect"
 
-	gomock "go.uber.org/mock/gomock"
-
 	metrics "github.com/damianoneill/go-bootstrap/pkg/domain/metrics"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockCollector is a mock of Collector interface.
@@ -67,6 +66,255 @@ func (mr *MockCollectorMockRecorder) CollectRequestMetrics(method, path, status,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectRequestMetrics", reflect.TypeOf((*MockCollector)(nil).CollectRequestMetrics), method, path, status, duration)
 }
 
+// Counter mocks base method.
+func (m *MockCollector) Counter(name string, labels ...string) metrics.CounterHandle {
+	m.ctrl.T.Helper()
+	varargs := []any{name}
+	for _, a := range labels {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Counter", varargs...)
+	ret0, _ := ret[0].(metrics.CounterHandle)
+	return ret0
+}
+
+// Counter indicates an expected call of Counter.
+func (mr *MockCollectorMockRecorder) Counter(name any, labels ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name}, labels...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Counter", reflect.TypeOf((*MockCollector)(nil).Counter), varargs...)
+}
+
+// Gauge mocks base method.
+func (m *MockCollector) Gauge(name string, labels ...string) metrics.GaugeHandle {
+	m.ctrl.T.Helper()
+	varargs := []any{name}
+	for _, a := range labels {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Gauge", varargs...)
+	ret0, _ := ret[0].(metrics.GaugeHandle)
+	return ret0
+}
+
+// Gauge indicates an expected call of Gauge.
+func (mr *MockCollectorMockRecorder) Gauge(name any, labels ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name}, labels...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gauge", reflect.TypeOf((*MockCollector)(nil).Gauge), varargs...)
+}
+
+// Histogram mocks base method.
+func (m *MockCollector) Histogram(name string, labels ...string) metrics.HistogramHandle {
+	m.ctrl.T.Helper()
+	varargs := []any{name}
+	for _, a := range labels {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Histogram", varargs...)
+	ret0, _ := ret[0].(metrics.HistogramHandle)
+	return ret0
+}
+
+// Histogram indicates an expected call of Histogram.
+func (mr *MockCollectorMockRecorder) Histogram(name any, labels ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name}, labels...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Histogram", reflect.TypeOf((*MockCollector)(nil).Histogram), varargs...)
+}
+
+// MockClientCollector is a mock of ClientCollector interface.
+type MockClientCollector struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientCollectorMockRecorder
+	isgomock struct{}
+}
+
+// MockClientCollectorMockRecorder is the mock recorder for MockClientCollector.
+type MockClientCollectorMockRecorder struct {
+	mock *MockClientCollector
+}
+
+// NewMockClientCollector creates a new mock instance.
+func NewMockClientCollector(ctrl *gomock.Controller) *MockClientCollector {
+	mock := &MockClientCollector{ctrl: ctrl}
+	mock.recorder = &MockClientCollectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientCollector) EXPECT() *MockClientCollectorMockRecorder {
+	return m.recorder
+}
+
+// CollectClientRequest mocks base method.
+func (m *MockClientCollector) CollectClientRequest(client string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CollectClientRequest", client)
+}
+
+// CollectClientRequest indicates an expected call of CollectClientRequest.
+func (mr *MockClientCollectorMockRecorder) CollectClientRequest(client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectClientRequest", reflect.TypeOf((*MockClientCollector)(nil).CollectClientRequest), client)
+}
+
+// MockCounterHandle is a mock of CounterHandle interface.
+type MockCounterHandle struct {
+	ctrl     *gomock.Controller
+	recorder *MockCounterHandleMockRecorder
+	isgomock struct{}
+}
+
+// MockCounterHandleMockRecorder is the mock recorder for MockCounterHandle.
+type MockCounterHandleMockRecorder struct {
+	mock *MockCounterHandle
+}
+
+// NewMockCounterHandle creates a new mock instance.
+func NewMockCounterHandle(ctrl *gomock.Controller) *MockCounterHandle {
+	mock := &MockCounterHandle{ctrl: ctrl}
+	mock.recorder = &MockCounterHandleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCounterHandle) EXPECT() *MockCounterHandleMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockCounterHandle) Add(value float64, labelValues ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{value}
+	for _, a := range labelValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Add", varargs...)
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockCounterHandleMockRecorder) Add(value any, labelValues ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{value}, labelValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCounterHandle)(nil).Add), varargs...)
+}
+
+// Inc mocks base method.
+func (m *MockCounterHandle) Inc(labelValues ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range labelValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Inc", varargs...)
+}
+
+// Inc indicates an expected call of Inc.
+func (mr *MockCounterHandleMockRecorder) Inc(labelValues ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inc", reflect.TypeOf((*MockCounterHandle)(nil).Inc), labelValues...)
+}
+
+// MockGaugeHandle is a mock of GaugeHandle interface.
+type MockGaugeHandle struct {
+	ctrl     *gomock.Controller
+	recorder *MockGaugeHandleMockRecorder
+	isgomock struct{}
+}
+
+// MockGaugeHandleMockRecorder is the mock recorder for MockGaugeHandle.
+type MockGaugeHandleMockRecorder struct {
+	mock *MockGaugeHandle
+}
+
+// NewMockGaugeHandle creates a new mock instance.
+func NewMockGaugeHandle(ctrl *gomock.Controller) *MockGaugeHandle {
+	mock := &MockGaugeHandle{ctrl: ctrl}
+	mock.recorder = &MockGaugeHandleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGaugeHandle) EXPECT() *MockGaugeHandleMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockGaugeHandle) Add(value float64, labelValues ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{value}
+	for _, a := range labelValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Add", varargs...)
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockGaugeHandleMockRecorder) Add(value any, labelValues ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{value}, labelValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockGaugeHandle)(nil).Add), varargs...)
+}
+
+// Set mocks base method.
+func (m *MockGaugeHandle) Set(value float64, labelValues ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{value}
+	for _, a := range labelValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Set", varargs...)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockGaugeHandleMockRecorder) Set(value any, labelValues ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{value}, labelValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockGaugeHandle)(nil).Set), varargs...)
+}
+
+// MockHistogramHandle is a mock of HistogramHandle interface.
+type MockHistogramHandle struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistogramHandleMockRecorder
+	isgomock struct{}
+}
+
+// MockHistogramHandleMockRecorder is the mock recorder for MockHistogramHandle.
+type MockHistogramHandleMockRecorder struct {
+	mock *MockHistogramHandle
+}
+
+// NewMockHistogramHandle creates a new mock instance.
+func NewMockHistogramHandle(ctrl *gomock.Controller) *MockHistogramHandle {
+	mock := &MockHistogramHandle{ctrl: ctrl}
+	mock.recorder = &MockHistogramHandleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistogramHandle) EXPECT() *MockHistogramHandleMockRecorder {
+	return m.recorder
+}
+
+// Observe mocks base method.
+func (m *MockHistogramHandle) Observe(value float64, labelValues ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{value}
+	for _, a := range labelValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Observe", varargs...)
+}
+
+// Observe indicates an expected call of Observe.
+func (mr *MockHistogramHandleMockRecorder) Observe(value any, labelValues ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{value}, labelValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Observe", reflect.TypeOf((*MockHistogramHandle)(nil).Observe), varargs...)
+}
+
 // MockFactory is a mock of Factory interface.
 type MockFactory struct {
 	ctrl     *gomock.Controller
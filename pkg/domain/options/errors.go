@@ -0,0 +1,30 @@
+// pkg/domain/options/errors.go
+
+package options
+
+import "fmt"
+
+// ValidationError reports an option value that failed validation.
+// Callers can use errors.As to find which field was rejected:
+//
+//	var verr *options.ValidationError
+//	if errors.As(err, &verr) && verr.Field == "SamplingRate" {
+//	    // fall back to a default rate
+//	}
+type ValidationError struct {
+	// Field is the name of the configuration field being set
+	Field string
+	// Reason describes why the value was rejected
+	Reason string
+}
+
+// Error returns the reason, which reads as a complete message
+func (e *ValidationError) Error() string {
+	return e.Reason
+}
+
+// Invalid returns a ValidationError for field with a reason formatted
+// according to format.
+func Invalid(field, format string, args ...interface{}) error {
+	return &ValidationError{Field: field, Reason: fmt.Sprintf(format, args...)}
+}
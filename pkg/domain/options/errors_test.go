@@ -0,0 +1,27 @@
+// pkg/domain/options/errors_test.go
+package options
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationError(t *testing.T) {
+	opt := OptionFunc[testConfig](func(c *testConfig) error {
+		return Invalid("Port", "port must be positive: %d", -1)
+	})
+
+	err := Apply(&testConfig{}, opt)
+	assert.EqualError(t, err, "port must be positive: -1")
+
+	// The field survives wrapping by callers
+	wrapped := fmt.Errorf("applying option: %w", err)
+	var verr *ValidationError
+	if assert.True(t, errors.As(wrapped, &verr)) {
+		assert.Equal(t, "Port", verr.Field)
+		assert.Equal(t, "port must be positive: -1", verr.Reason)
+	}
+}
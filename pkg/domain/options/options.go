@@ -56,3 +56,60 @@ func Apply[T any](target *T, opts ...Option[T]) error {
 	}
 	return nil
 }
+
+// Group bundles a sequence of options into a single option that applies
+// them in order, returning on the first error. Nil options are skipped.
+// It lets commonly repeated option sets be shared as presets.
+//
+// Example usage:
+//
+//	func LocalDev() Option[Config] {
+//	    return Group(WithHost("localhost"), WithPort(8080))
+//	}
+func Group[T any](opts ...Option[T]) Option[T] {
+	return OptionFunc[T](func(o *T) error {
+		return Apply(o, opts...)
+	})
+}
+
+// Validator checks invariants across the fields of a configuration type T
+// that no single option can enforce on its own, for example that a TLS key
+// is set whenever a TLS certificate is.
+type Validator[T any] interface {
+	// Validate returns an error if the configuration object is invalid.
+	Validate(*T) error
+}
+
+// ValidatorFunc is a helper type that converts simple functions into
+// Validator instances.
+type ValidatorFunc[T any] func(*T) error
+
+// Validate implements the Validator interface for ValidatorFunc.
+func (f ValidatorFunc[T]) Validate(o *T) error {
+	if f == nil {
+		return nil // Treat nil function as a no-op
+	}
+	return f(o)
+}
+
+// ApplyAndValidate applies a sequence of options to a target configuration
+// object and then validates the result. Validation runs only once every
+// option has applied successfully; a nil validate skips it.
+//
+// Example usage:
+//
+//	err := ApplyAndValidate(config, func(c *Config) error {
+//	    if c.CertFile != "" && c.KeyFile == "" {
+//	        return Invalid("KeyFile", "key file is required with a cert file")
+//	    }
+//	    return nil
+//	}, WithPort(8080))
+func ApplyAndValidate[T any](target *T, validate func(*T) error, opts ...Option[T]) error {
+	if err := Apply(target, opts...); err != nil {
+		return err
+	}
+	if validate == nil {
+		return nil
+	}
+	return validate(target)
+}
@@ -282,3 +282,161 @@ func TestServerConfig(t *testing.T) {
 		})
 	}
 }
+
+// tlsConfig is used to test invariants spanning several options
+type tlsConfig struct {
+	CertFile string
+	KeyFile  string
+}
+
+func withCertFile(path string) Option[tlsConfig] {
+	return OptionFunc[tlsConfig](func(c *tlsConfig) error {
+		c.CertFile = path
+		return nil
+	})
+}
+
+func withKeyFile(path string) Option[tlsConfig] {
+	return OptionFunc[tlsConfig](func(c *tlsConfig) error {
+		c.KeyFile = path
+		return nil
+	})
+}
+
+// validateTLS requires a key whenever a certificate is set
+func validateTLS(c *tlsConfig) error {
+	if c.CertFile != "" && c.KeyFile == "" {
+		return Invalid("KeyFile", "key file is required when cert file is set")
+	}
+	return nil
+}
+
+func TestApplyAndValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		validate  func(*tlsConfig) error
+		opts      []Option[tlsConfig]
+		expected  tlsConfig
+		wantError string
+		wantField string
+	}{
+		{
+			name:     "no options",
+			validate: validateTLS,
+			expected: tlsConfig{},
+		},
+		{
+			name:     "cert and key",
+			validate: validateTLS,
+			opts:     []Option[tlsConfig]{withCertFile("cert.pem"), withKeyFile("key.pem")},
+			expected: tlsConfig{CertFile: "cert.pem", KeyFile: "key.pem"},
+		},
+		{
+			name:     "key applied after cert",
+			validate: validateTLS,
+			opts:     []Option[tlsConfig]{withKeyFile("key.pem"), withCertFile("cert.pem")},
+			expected: tlsConfig{CertFile: "cert.pem", KeyFile: "key.pem"},
+		},
+		{
+			name:      "cert without key",
+			validate:  validateTLS,
+			opts:      []Option[tlsConfig]{withCertFile("cert.pem")},
+			wantError: "key file is required when cert file is set",
+			wantField: "KeyFile",
+		},
+		{
+			name:     "nil validator",
+			validate: nil,
+			opts:     []Option[tlsConfig]{withCertFile("cert.pem")},
+			expected: tlsConfig{CertFile: "cert.pem"},
+		},
+		{
+			name:     "validator func",
+			validate: ValidatorFunc[tlsConfig](validateTLS),
+			opts:     []Option[tlsConfig]{withCertFile("cert.pem"), withKeyFile("key.pem")},
+			expected: tlsConfig{CertFile: "cert.pem", KeyFile: "key.pem"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &tlsConfig{}
+			err := ApplyAndValidate(cfg, tt.validate, tt.opts...)
+
+			if tt.wantError != "" {
+				assert.EqualError(t, err, tt.wantError)
+				var verr *ValidationError
+				if assert.ErrorAs(t, err, &verr) {
+					assert.Equal(t, tt.wantField, verr.Field)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, *cfg)
+		})
+	}
+}
+
+func TestApplyAndValidate_OptionErrorSkipsValidation(t *testing.T) {
+	validated := false
+	cfg := &testConfig{}
+	err := ApplyAndValidate(cfg, func(*testConfig) error {
+		validated = true
+		return nil
+	}, createOption("test", 8080, true, true))
+
+	assert.EqualError(t, err, "option error")
+	assert.False(t, validated)
+}
+
+func TestValidatorFunc_Validate(t *testing.T) {
+	t.Run("nil function", func(t *testing.T) {
+		var v Validator[tlsConfig] = ValidatorFunc[tlsConfig](nil)
+		assert.NoError(t, v.Validate(&tlsConfig{CertFile: "cert.pem"}))
+	})
+
+	t.Run("method value", func(t *testing.T) {
+		var v Validator[tlsConfig] = ValidatorFunc[tlsConfig](validateTLS)
+		err := ApplyAndValidate(&tlsConfig{}, v.Validate, withCertFile("cert.pem"))
+		assert.EqualError(t, err, "key file is required when cert file is set")
+	})
+}
+
+func TestGroup(t *testing.T) {
+	t.Run("applies every option in order", func(t *testing.T) {
+		cfg := &serverConfig{}
+		err := Apply(cfg, Group(withHost("localhost"), nil, withPort(8080)), withPort(9090))
+
+		assert.NoError(t, err)
+		assert.Equal(t, serverConfig{Host: "localhost", Port: 9090}, *cfg)
+	})
+
+	t.Run("short-circuits on error", func(t *testing.T) {
+		applied := false
+		after := OptionFunc[serverConfig](func(*serverConfig) error {
+			applied = true
+			return nil
+		})
+
+		cfg := &serverConfig{}
+		err := Apply(cfg, Group(withHost("localhost"), withPort(0), after))
+
+		assert.EqualError(t, err, "port must be between 1 and 65535")
+		assert.False(t, applied)
+		assert.Equal(t, "localhost", cfg.Host)
+	})
+
+	t.Run("nested groups", func(t *testing.T) {
+		cfg := &serverConfig{}
+		err := Apply(cfg, Group(Group(withHost("localhost")), Group(withPort(8080))))
+
+		assert.NoError(t, err)
+		assert.Equal(t, serverConfig{Host: "localhost", Port: 8080}, *cfg)
+	})
+
+	t.Run("empty group", func(t *testing.T) {
+		cfg := &serverConfig{}
+		assert.NoError(t, Apply(cfg, Group[serverConfig]()))
+		assert.Equal(t, serverConfig{}, *cfg)
+	})
+}
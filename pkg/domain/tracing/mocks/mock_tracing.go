@@ -43,6 +43,46 @@ func (m *MockProvider) EXPECT() *MockProviderMockRecorder {
 	return m.recorder
 }
 
+// Extract mocks base method.
+func (m *MockProvider) Extract(ctx context.Context, carrier map[string]string) context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extract", ctx, carrier)
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Extract indicates an expected call of Extract.
+func (mr *MockProviderMockRecorder) Extract(ctx, carrier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extract", reflect.TypeOf((*MockProvider)(nil).Extract), ctx, carrier)
+}
+
+// ForceFlush mocks base method.
+func (m *MockProvider) ForceFlush(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceFlush", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceFlush indicates an expected call of ForceFlush.
+func (mr *MockProviderMockRecorder) ForceFlush(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceFlush", reflect.TypeOf((*MockProvider)(nil).ForceFlush), ctx)
+}
+
+// Inject mocks base method.
+func (m *MockProvider) Inject(ctx context.Context, carrier map[string]string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Inject", ctx, carrier)
+}
+
+// Inject indicates an expected call of Inject.
+func (mr *MockProviderMockRecorder) Inject(ctx, carrier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inject", reflect.TypeOf((*MockProvider)(nil).Inject), ctx, carrier)
+}
+
 // IsEnabled mocks base method.
 func (m *MockProvider) IsEnabled() bool {
 	m.ctrl.T.Helper()
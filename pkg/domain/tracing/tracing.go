@@ -6,7 +6,6 @@ package tracing
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
@@ -19,6 +18,22 @@ type Provider interface {
 	// The context controls how long to wait for export completion.
 	Shutdown(ctx context.Context) error
 
+	// ForceFlush exports all spans that have ended but are still buffered,
+	// without stopping the provider. It is a no-op when tracing is disabled.
+	// The context controls how long to wait for export completion.
+	ForceFlush(ctx context.Context) error
+
+	// Extract returns a copy of ctx carrying the trace context and baggage
+	// found in carrier by the configured propagators, so work triggered by
+	// a message, for example from a queue, continues the sender's trace.
+	// It returns ctx unchanged when tracing is disabled.
+	Extract(ctx context.Context, carrier map[string]string) context.Context
+
+	// Inject writes the trace context and baggage of ctx into carrier
+	// using the configured propagators, for example as message headers.
+	// It does nothing when tracing is disabled.
+	Inject(ctx context.Context, carrier map[string]string)
+
 	// IsEnabled returns whether tracing is currently active.
 	// This can be used to conditionally add spans or attributes.
 	IsEnabled() bool
@@ -86,6 +101,22 @@ type Options struct {
 	// SamplingRate sets the probability of trace sampling (0.0-1.0)
 	// Default is 1.0 (sample everything)
 	SamplingRate float64
+
+	// EnvConfig fills CollectorEndpoint, Headers and ExporterType from the
+	// standard OTEL_EXPORTER_OTLP_* environment variables when they are
+	// not set by other options
+	// Default is false (environment ignored)
+	EnvConfig bool
+
+	// ResourceAttributes are added to the resource describing this
+	// service, so they appear on every span
+	// Example: {"deployment.environment": "prod", "team": "payments"}
+	ResourceAttributes map[string]string
+
+	// RateLimitPerSecond caps the number of traces started per second,
+	// taking precedence over SamplingRate when set
+	// Default is 0 (no cap)
+	RateLimitPerSecond float64
 }
 
 // Option is a function that modifies Options
@@ -149,6 +180,42 @@ func WithInsecure(insecure bool) Option {
 	})
 }
 
+// WithEnvConfig reads exporter settings the OpenTelemetry specification
+// defines as environment variables, for settings not given by other
+// options regardless of option order:
+//
+//   - OTEL_EXPORTER_OTLP_TRACES_ENDPOINT or OTEL_EXPORTER_OTLP_ENDPOINT
+//     sets CollectorEndpoint; an http:// URL also enables Insecure
+//   - OTEL_EXPORTER_OTLP_TRACES_HEADERS or OTEL_EXPORTER_OTLP_HEADERS
+//     sets Headers from comma-separated key=value pairs
+//   - OTEL_EXPORTER_OTLP_TRACES_PROTOCOL or OTEL_EXPORTER_OTLP_PROTOCOL
+//     sets ExporterType, "grpc" or "http/protobuf"
+func WithEnvConfig() Option {
+	return options.OptionFunc[Options](func(o *Options) error {
+		o.EnvConfig = true
+		return nil
+	})
+}
+
+// WithResourceAttributes adds attributes to the resource describing this
+// service, such as deployment.environment or host.name. Repeated calls add
+// to the attributes already set. Service name and version are set by
+// their own options and are not overridden.
+func WithResourceAttributes(attrs map[string]string) Option {
+	return options.OptionFunc[Options](func(o *Options) error {
+		if o.ResourceAttributes == nil {
+			o.ResourceAttributes = make(map[string]string, len(attrs))
+		}
+		for key, value := range attrs {
+			if key == "" {
+				return options.Invalid("ResourceAttributes", "resource attribute key cannot be empty")
+			}
+			o.ResourceAttributes[key] = value
+		}
+		return nil
+	})
+}
+
 // WithPropagatorTypes sets the context propagation formats to support
 func WithPropagatorTypes(types []string) Option {
 	return options.OptionFunc[Options](func(o *Options) error {
@@ -162,13 +229,26 @@ func WithPropagatorTypes(types []string) Option {
 func WithSamplingRate(rate float64) Option {
 	return options.OptionFunc[Options](func(o *Options) error {
 		if rate < 0.0 || rate > 1.0 {
-			return fmt.Errorf("sampling rate must be between 0.0 and 1.0")
+			return options.Invalid("SamplingRate", "sampling rate must be between 0.0 and 1.0")
 		}
 		o.SamplingRate = rate
 		return nil
 	})
 }
 
+// WithRateLimitSampling samples at most perSecond new traces per second,
+// allowing bursts of up to one second's worth. Spans in an existing trace
+// follow their parent's decision. It takes precedence over SamplingRate.
+func WithRateLimitSampling(perSecond float64) Option {
+	return options.OptionFunc[Options](func(o *Options) error {
+		if perSecond <= 0 {
+			return options.Invalid("RateLimitPerSecond", "rate limit must be greater than 0")
+		}
+		o.RateLimitPerSecond = perSecond
+		return nil
+	})
+}
+
 // WithDefaultPropagators configures standard W3C propagation
 func WithDefaultPropagators() Option {
 	return WithPropagatorTypes([]string{
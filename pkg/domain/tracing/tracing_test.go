@@ -2,10 +2,13 @@
 package tracing
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
 )
 
 func TestWithServiceName(t *testing.T) {
@@ -76,7 +79,10 @@ func TestWithSamplingRate(t *testing.T) {
 			opts := &Options{}
 			err := opt.ApplyOption(opts)
 			if tt.wantErr {
-				assert.Error(t, err)
+				var verr *options.ValidationError
+				if assert.True(t, errors.As(err, &verr)) {
+					assert.Equal(t, "SamplingRate", verr.Field)
+				}
 				return
 			}
 			assert.NoError(t, err)
@@ -85,6 +91,60 @@ func TestWithSamplingRate(t *testing.T) {
 	}
 }
 
+func TestWithRateLimitSampling(t *testing.T) {
+	tests := []struct {
+		name      string
+		perSecond float64
+		wantErr   bool
+	}{
+		{
+			name:      "valid rate",
+			perSecond: 100,
+		},
+		{
+			name:      "fractional rate",
+			perSecond: 0.5,
+		},
+		{
+			name:      "invalid rate - zero",
+			perSecond: 0,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid rate - negative",
+			perSecond: -1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := WithRateLimitSampling(tt.perSecond)
+			opts := &Options{}
+			err := opt.ApplyOption(opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.perSecond, opts.RateLimitPerSecond)
+		})
+	}
+}
+
+func TestWithResourceAttributes(t *testing.T) {
+	opts := &Options{}
+	assert.NoError(t, WithResourceAttributes(map[string]string{"deployment.environment": "prod"}).ApplyOption(opts))
+	assert.NoError(t, WithResourceAttributes(map[string]string{"team": "payments"}).ApplyOption(opts))
+	assert.Equal(t, map[string]string{
+		"deployment.environment": "prod",
+		"team":                   "payments",
+	}, opts.ResourceAttributes)
+
+	err := WithResourceAttributes(map[string]string{"": "value"}).ApplyOption(&Options{})
+	assert.EqualError(t, err, "resource attribute key cannot be empty")
+}
+
 func TestWithPropagatorTypes(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -241,3 +301,24 @@ func TestWithHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestTracingOptionValidationErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		option    Option
+		wantField string
+	}{
+		{"rate limit", WithRateLimitSampling(0), "RateLimitPerSecond"},
+		{"resource attributes", WithResourceAttributes(map[string]string{"": "value"}), "ResourceAttributes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.option.ApplyOption(&Options{})
+
+			var verr *options.ValidationError
+			require.True(t, errors.As(err, &verr))
+			assert.Equal(t, tt.wantField, verr.Field)
+		})
+	}
+}
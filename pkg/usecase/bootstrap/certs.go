@@ -0,0 +1,140 @@
+// pkg/usecase/bootstrap/certs.go
+
+package bootstrap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+// certReloader serves a TLS certificate that is re-read from disk on SIGHUP,
+// so rotated certificates take effect without a restart
+type certReloader struct {
+	certFile string
+	keyFile  string
+	cert     atomic.Pointer[tls.Certificate]
+	logger   domainlog.Logger
+}
+
+// newCertReloader creates a reloader serving cert until the next reload
+func newCertReloader(certFile, keyFile string, cert tls.Certificate, logger domainlog.Logger) *certReloader {
+	r := &certReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		logger:   logger,
+	}
+	r.cert.Store(&cert)
+	return r
+}
+
+// getCertificate implements tls.Config.GetCertificate
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.cert.Load(), nil
+}
+
+// reload re-reads the certificate and key. The pair is swapped in only once
+// both have loaded, and the current certificate is kept on failure.
+func (r *certReloader) reload() error {
+	fields := domainlog.Fields{
+		"cert_file": r.certFile,
+		"key_file":  r.keyFile,
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		fields["error"] = err.Error()
+		r.logger.ErrorWith("Failed to reload TLS certificate, keeping current", fields)
+		return fmt.Errorf("reloading TLS cert/key: %w", err)
+	}
+
+	r.cert.Store(&cert)
+	r.logger.InfoWith("Reloaded TLS certificate", fields)
+	return nil
+}
+
+// watch reloads the certificate on each SIGHUP until the returned stop
+// function is called
+func (r *certReloader) watch() (stop func()) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-sigChan:
+				_ = r.reload()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigChan)
+		close(done)
+	}
+}
+
+// clientSubjectKey is the context key for the verified client subject
+type clientSubjectKey struct{}
+
+// ClientSubject returns the subject of the verified client certificate for
+// a request served with ClientCAFile set. It reports false when the client
+// presented no certificate.
+func ClientSubject(ctx context.Context) (pkix.Name, bool) {
+	subject, ok := ctx.Value(clientSubjectKey{}).(pkix.Name)
+	return subject, ok
+}
+
+// withClientSubject stores the subject of the verified client certificate
+// in the request context
+func withClientSubject(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// VerifiedChains is only populated once the chain checks out against
+		// ClientCAs, unlike PeerCertificates
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+			subject := r.TLS.VerifiedChains[0][0].Subject
+			r = r.WithContext(context.WithValue(r.Context(), clientSubjectKey{}, subject))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// configureClientAuth loads the client CA pool and sets the client
+// certificate policy
+func (s *Service) configureClientAuth(tlsConfig *tls.Config) error {
+	caFile := s.opts.Server.ClientCAFile
+	if caFile == "" {
+		if s.opts.Server.RequireClientCert {
+			return fmt.Errorf("client certificates required but no client CA file configured")
+		}
+		return nil
+	}
+
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return fmt.Errorf("reading client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return fmt.Errorf("no certificates found in client CA file %s", caFile)
+	}
+
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	if s.opts.Server.RequireClientCert {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return nil
+}
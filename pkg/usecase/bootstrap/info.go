@@ -0,0 +1,103 @@
+// pkg/usecase/bootstrap/info.go
+
+package bootstrap
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+)
+
+// infoPath serves the aggregated service information
+const infoPath = "/internal/info"
+
+// featuresKey is the config section holding feature flags
+const featuresKey = "features"
+
+// serviceInfo is the payload served at /internal/info
+type serviceInfo struct {
+	Service        serviceIdentity        `json:"service"`
+	Build          buildInfo              `json:"build"`
+	StartTime      string                 `json:"start_time"`
+	Uptime         string                 `json:"uptime"`
+	LogLevel       string                 `json:"log_level,omitempty"`
+	TracingEnabled bool                   `json:"tracing_enabled"`
+	Features       map[string]interface{} `json:"features,omitempty"`
+}
+
+type serviceIdentity struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Environment string `json:"environment,omitempty"`
+}
+
+type buildInfo struct {
+	GoVersion string `json:"go_version"`
+	Revision  string `json:"revision,omitempty"`
+	Time      string `json:"time,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+}
+
+// infoHandler serves identity, build, uptime, log level, tracing state and
+// feature flags, read from the components on each request
+func (s *Service) infoHandler() http.HandlerFunc {
+	build := readBuildInfo()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		info := serviceInfo{
+			Service: serviceIdentity{
+				Name:        s.opts.ServiceName,
+				Version:     s.opts.Version,
+				Environment: s.opts.Environment,
+			},
+			Build:          build,
+			StartTime:      s.startTime.Format(time.RFC3339),
+			Uptime:         time.Since(s.startTime).String(),
+			TracingEnabled: s.TracingEnabled(),
+		}
+
+		if leveled, ok := s.logger.(domainlog.LeveledLogger); ok {
+			info.LogLevel = string(leveled.GetLevel())
+		}
+
+		if s.config.IsSet(featuresKey) {
+			var features map[string]interface{}
+			if err := s.config.UnmarshalKey(featuresKey, &features); err != nil {
+				http.Error(w, "reading feature flags", http.StatusInternalServerError)
+				return
+			}
+			info.Features = features
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(info); err != nil {
+			http.Error(w, "encoding service info", http.StatusInternalServerError)
+		}
+	}
+}
+
+// readBuildInfo returns the Go version and, when the binary was built from
+// a VCS checkout, the revision it was built from
+func readBuildInfo() buildInfo {
+	info := buildInfo{GoVersion: runtime.Version()}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.Time = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+	return info
+}
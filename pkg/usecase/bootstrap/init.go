@@ -5,12 +5,21 @@ package bootstrap
 import (
 	"fmt"
 
+	"github.com/go-chi/chi/v5"
+
 	domainconfig "github.com/damianoneill/go-bootstrap/pkg/domain/config"
 	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
 	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+	domainmetrics "github.com/damianoneill/go-bootstrap/pkg/domain/metrics"
 	domaintracing "github.com/damianoneill/go-bootstrap/pkg/domain/tracing"
 )
 
+// pprofPath matches all profiling endpoints mounted by the router
+const pprofPath = "/internal/debug/pprof/*"
+
+// routesPath is the route table endpoint mounted by the router
+const routesPath = "/internal/routes"
+
 func (s *Service) initConfig(opts Options) error {
 	cfgOpts := []domainconfig.Option{
 		domainconfig.WithEnvPrefix(opts.EnvPrefix),
@@ -20,7 +29,9 @@ func (s *Service) initConfig(opts Options) error {
 			"server.http.write_timeout":   opts.Server.WriteTimeout,
 			"server.http.idle_timeout":    opts.Server.IdleTimeout,
 			"server.http.max_header_size": opts.Server.MaxHeaderSize,
-			"server.tls.enabled":          opts.Server.TLSConfig != nil,
+			"server.http.admin_port":      opts.Server.AdminPort,
+			"server.http.drain_delay":     opts.Server.DrainDelay,
+			"server.tls.enabled":          opts.Server.TLSConfig != nil || len(opts.Server.TLSCertPEM) > 0,
 			"server.tls.cert_file":        opts.Server.TLSCertFile,
 			"server.tls.key_file":         opts.Server.TLSKeyFile,
 		}),
@@ -43,6 +54,10 @@ func (s *Service) initLogger(opts Options) error {
 		"version": opts.Version,
 	}
 
+	for k, v := range opts.ResourceAttributes {
+		fields[k] = v
+	}
+
 	// Merge user-provided fields if present
 	if opts.LogFields != nil {
 		for k, v := range opts.LogFields {
@@ -50,11 +65,16 @@ func (s *Service) initLogger(opts Options) error {
 		}
 	}
 
-	logger, err := s.deps.LoggerFactory.NewLogger(
+	logOpts := []domainlog.Option{
 		domainlog.WithLevel(opts.LogLevel),
 		domainlog.WithServiceName(opts.ServiceName),
 		domainlog.WithFields(fields),
-	)
+	}
+	if opts.LogBufferSize > 0 {
+		logOpts = append(logOpts, domainlog.WithLogBuffer(opts.LogBufferSize))
+	}
+
+	logger, err := s.deps.LoggerFactory.NewLogger(logOpts...)
 	if err != nil {
 		return fmt.Errorf("creating logger: %w", err)
 	}
@@ -72,10 +92,20 @@ func (s *Service) initTracing(opts Options) error {
 		domaintracing.WithServiceName(opts.ServiceName),
 		domaintracing.WithServiceVersion(opts.Version),
 		domaintracing.WithCollectorEndpoint(opts.TracingEndpoint),
-		domaintracing.WithSamplingRate(opts.TracingSampleRate),
+		domaintracing.WithSamplingRate(*opts.TracingSampleRate),
 		domaintracing.WithInsecure(true),
 	}
 
+	if len(opts.ResourceAttributes) > 0 {
+		tracingOpts = append(tracingOpts,
+			domaintracing.WithResourceAttributes(opts.ResourceAttributes))
+	}
+
+	if opts.TracingRateLimit > 0 {
+		tracingOpts = append(tracingOpts,
+			domaintracing.WithRateLimitSampling(opts.TracingRateLimit))
+	}
+
 	if len(opts.TracingPropagators) > 0 {
 		tracingOpts = append(tracingOpts,
 			domaintracing.WithPropagatorTypes(opts.TracingPropagators))
@@ -117,6 +147,34 @@ func (s *Service) initRouter(opts Options) error {
 		excludeFromTracing = opts.ExcludeFromTracing
 	}
 
+	// The info endpoint is polled by tooling, so it is never logged or traced
+	if opts.EnableInfo {
+		excludeFromLogging = appendMissing(excludeFromLogging, infoPath)
+		excludeFromTracing = appendMissing(excludeFromTracing, infoPath)
+	}
+
+	// Profiling endpoints are never logged or traced
+	if opts.EnablePprof {
+		excludeFromLogging = appendMissing(excludeFromLogging, pprofPath)
+		excludeFromTracing = appendMissing(excludeFromTracing, pprofPath)
+		routerOpts = append(routerOpts, domainhttp.WithPprof(true))
+	}
+
+	// Probes polled by Kubernetes are never logged or traced
+	if probePaths := opts.Router.ProbePaths; probePaths != nil {
+		for _, path := range probePaths.All() {
+			excludeFromLogging = appendMissing(excludeFromLogging, path)
+			excludeFromTracing = appendMissing(excludeFromTracing, path)
+		}
+	}
+
+	// The route table is a debugging aid, so it is never logged or traced
+	if opts.EnableRouteViewer {
+		excludeFromLogging = appendMissing(excludeFromLogging, routesPath)
+		excludeFromTracing = appendMissing(excludeFromTracing, routesPath)
+		routerOpts = append(routerOpts, domainhttp.WithRouteViewer(true))
+	}
+
 	routerOpts = append(routerOpts,
 		domainhttp.WithObservabilityExclusions(
 			excludeFromLogging,
@@ -125,8 +183,14 @@ func (s *Service) initRouter(opts Options) error {
 
 	// Add metrics factory if configured
 	if s.deps.MetricsFactory != nil {
+		metricsOpts := []domainmetrics.Option{
+			domainmetrics.WithRuntimeMetrics(opts.EnableStandardMetrics || !opts.DisableRuntimeMetrics),
+		}
+		if len(opts.MetricsBuckets) > 0 {
+			metricsOpts = append(metricsOpts, domainmetrics.WithBuckets(opts.MetricsBuckets))
+		}
 		routerOpts = append(routerOpts,
-			domainhttp.WithMetricsFactory(s.deps.MetricsFactory))
+			domainhttp.WithMetricsFactory(s.deps.MetricsFactory, metricsOpts...))
 	}
 
 	if s.tracer != nil {
@@ -134,25 +198,68 @@ func (s *Service) initRouter(opts Options) error {
 			domainhttp.WithTracingProvider(s.tracer))
 	}
 
-	// If user provided middleware ordering, add it
-	if opts.Router.MiddlewareOrdering != nil {
+	// Serve internal endpoints from a dedicated router when an admin port is set
+	if adminPort, _ := s.config.GetInt("server.http.admin_port"); adminPort != 0 {
+		s.adminRouter = chi.NewRouter()
 		routerOpts = append(routerOpts,
-			domainhttp.WithMiddlewareOrdering(opts.Router.MiddlewareOrdering))
+			domainhttp.WithInternalRouter(s.adminRouter))
 	}
 
+	routerOpts = append(routerOpts, routerOptions(opts.Router)...)
+
 	router, err := s.deps.RouterFactory.NewRouter(routerOpts...)
 	if err != nil {
 		return fmt.Errorf("creating router: %w", err)
 	}
 	s.router = router
 
+	if opts.EnableStandardMetrics {
+		if err := s.registerStandardMetrics(); err != nil {
+			return err
+		}
+	}
+
+	if opts.EnablePprof {
+		s.logger.InfoWith("Registered pprof endpoints",
+			domainlog.Fields{"path": "/internal/debug/pprof"})
+	}
+
+	if opts.EnableRouteViewer {
+		s.logger.InfoWith("Registered route viewer endpoint",
+			domainlog.Fields{"path": routesPath})
+	}
+
+	// Diagnostics endpoints live alongside the other internal endpoints
+	internal := s.internalRouter()
+
 	// Add logger config endpoint if enabled
 	if opts.EnableLogConfig {
 		if configurable, ok := s.logger.(domainlog.RuntimeConfigurable); ok {
-			router.Mount("/internal/logging", configurable.GetConfigHandler())
+			internal.Mount("/internal/logging", configurable.GetConfigHandler())
 			s.logger.InfoWith("Registered logger config endpoint",
 				domainlog.Fields{"path": "/internal/logging"})
 		}
+		if inspectable, ok := s.logger.(domainlog.Inspectable); ok {
+			internal.Mount("/internal/logging/config", inspectable.GetEffectiveConfigHandler())
+			s.logger.InfoWith("Registered logger effective config endpoint",
+				domainlog.Fields{"path": "/internal/logging/config"})
+		}
+	}
+
+	// Add service info endpoint if enabled
+	if opts.EnableInfo {
+		internal.Mount(infoPath, s.infoHandler())
+		s.logger.InfoWith("Registered service info endpoint",
+			domainlog.Fields{"path": infoPath})
+	}
+
+	// Add recent logs endpoint if enabled and the logger buffers entries
+	if opts.EnableLogViewer && opts.LogBufferSize > 0 {
+		if buffered, ok := s.logger.(domainlog.Buffered); ok {
+			internal.Mount("/internal/logs", buffered.GetLogsHandler())
+			s.logger.InfoWith("Registered log viewer endpoint",
+				domainlog.Fields{"path": "/internal/logs"})
+		}
 	}
 
 	// Add config endpoint if enabled and store supports masking
@@ -163,11 +270,125 @@ func (s *Service) initRouter(opts Options) error {
 				SensitiveKeys: []string{"password", "secret", "key", "token", "credential"},
 				MaskPattern:   "******",
 			}
-			router.Mount("/internal/config", maskedStore.GetConfigHandler(strategy))
+			internal.Mount("/internal/config", maskedStore.GetConfigHandler(strategy))
+			internal.Mount("/internal/config/diff", maskedStore.GetDiffHandler(strategy))
+			internal.Mount("/internal/config/origin", maskedStore.GetOriginHandler())
 			s.logger.InfoWith("Registered config viewing endpoint",
 				domainlog.Fields{"path": "/internal/config"})
+			s.logger.InfoWith("Registered config diff endpoint",
+				domainlog.Fields{"path": "/internal/config/diff"})
+			s.logger.InfoWith("Registered config origin endpoint",
+				domainlog.Fields{"path": "/internal/config/origin"})
 		}
 	}
 
 	return nil
 }
+
+// internalRouter returns the router serving internal endpoints
+func (s *Service) internalRouter() chi.Router {
+	if s.adminRouter != nil {
+		return s.adminRouter
+	}
+	return s.router
+}
+
+// routerOptions converts the settings in Options.Router into router options.
+// Every user-facing RouterOptions field is listed here, in one table, so a
+// new field is wired by adding a single entry. Fields the service derives
+// from its own options, such as the logger or probe handlers, are set by
+// initRouter instead.
+func routerOptions(r domainhttp.RouterOptions) []domainhttp.Option {
+	settings := []struct {
+		set    bool
+		option func() domainhttp.Option
+	}{
+		{r.ProbePaths != nil, func() domainhttp.Option {
+			return domainhttp.WithProbePaths(r.ProbePaths.Liveness, r.ProbePaths.Readiness, r.ProbePaths.Startup)
+		}},
+		{r.MaxRequestBodySize > 0, func() domainhttp.Option {
+			return domainhttp.WithMaxRequestBodySize(r.MaxRequestBodySize)
+		}},
+		{r.MaxRequestDuration > 0, func() domainhttp.Option {
+			return domainhttp.WithMaxRequestDuration(r.MaxRequestDuration)
+		}},
+		{r.AccessLogFields != nil, func() domainhttp.Option {
+			return domainhttp.WithAccessLogFields(r.AccessLogFields)
+		}},
+		{r.RequireHTTPS != "", func() domainhttp.Option {
+			return domainhttp.WithRequireHTTPS(r.RequireHTTPS, r.TrustedProxies...)
+		}},
+		{len(r.LoggedContextKeys) > 0, func() domainhttp.Option {
+			return domainhttp.WithLoggedContextKeys(r.LoggedContextKeys)
+		}},
+		{len(r.AllowedHosts) > 0, func() domainhttp.Option {
+			return domainhttp.WithAllowedHosts(r.AllowedHosts)
+		}},
+		{r.UnmatchedPathLabel != "", func() domainhttp.Option {
+			return domainhttp.WithUnmatchedPathLabel(r.UnmatchedPathLabel)
+		}},
+		{r.UserAgentClassifier != nil, func() domainhttp.Option {
+			return domainhttp.WithUserAgentMetrics(r.UserAgentClassifier)
+		}},
+		{r.MetricsPath != "", func() domainhttp.Option {
+			return domainhttp.WithMetricsPath(r.MetricsPath)
+		}},
+		{r.DisableOpenMetrics, func() domainhttp.Option {
+			return domainhttp.WithOpenMetrics(false)
+		}},
+		{r.ReadinessInitialDelay > 0, func() domainhttp.Option {
+			return domainhttp.WithReadinessInitialDelay(r.ReadinessInitialDelay)
+		}},
+		{len(r.ResponseHeaders) > 0, func() domainhttp.Option {
+			return domainhttp.WithResponseHeaders(r.ResponseHeaders)
+		}},
+		{r.SecurityHeaders != nil, func() domainhttp.Option {
+			return domainhttp.WithSecurityHeaders(*r.SecurityHeaders)
+		}},
+		{r.BasicAuth != nil, func() domainhttp.Option {
+			return domainhttp.WithBasicAuth(r.BasicAuth.Realm, r.BasicAuth.Credentials, r.BasicAuth.Paths...)
+		}},
+		{r.JWTAuth != nil, func() domainhttp.Option {
+			return domainhttp.WithJWTAuth(*r.JWTAuth)
+		}},
+		{r.CSRF != nil, func() domainhttp.Option {
+			return domainhttp.WithCSRF(*r.CSRF)
+		}},
+		{len(r.ContextValues) > 0, func() domainhttp.Option {
+			return domainhttp.WithContextValues(r.ContextValues)
+		}},
+		{r.RequestIDHeader != "", func() domainhttp.Option {
+			return domainhttp.WithRequestIDHeader(r.RequestIDHeader)
+		}},
+		{r.SpanNameFormatter != nil, func() domainhttp.Option {
+			return domainhttp.WithSpanNameFormatter(r.SpanNameFormatter)
+		}},
+		{r.SpanAttributes != nil, func() domainhttp.Option {
+			return domainhttp.WithSpanAttributes(r.SpanAttributes)
+		}},
+		{r.MiddlewareOrdering != nil, func() domainhttp.Option {
+			return domainhttp.WithMiddlewareOrdering(r.MiddlewareOrdering)
+		}},
+	}
+
+	var opts []domainhttp.Option
+	for _, setting := range settings {
+		if setting.set {
+			opts = append(opts, setting.option())
+		}
+	}
+	return opts
+}
+
+// appendMissing appends path to paths unless it is already present.
+// A new slice is returned so caller-provided options are never modified.
+func appendMissing(paths []string, path string) []string {
+	for _, p := range paths {
+		if p == path {
+			return paths
+		}
+	}
+	result := make([]string, 0, len(paths)+1)
+	result = append(result, paths...)
+	return append(result, path)
+}
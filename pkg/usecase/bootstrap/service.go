@@ -5,13 +5,27 @@ package bootstrap
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"os/signal"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
 	domainconfig "github.com/damianoneill/go-bootstrap/pkg/domain/config"
 	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
 	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
+	domainmetrics "github.com/damianoneill/go-bootstrap/pkg/domain/metrics"
+	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
 	domaintracing "github.com/damianoneill/go-bootstrap/pkg/domain/tracing"
 )
 
@@ -26,6 +40,8 @@ type ServerConfig struct {
 	TLSEnabled      bool
 	TLSCertFile     string
 	TLSKeyFile      string
+	AdminPort       int
+	DrainDelay      time.Duration
 }
 
 // ServerHooks provides hooks for testing server lifecycle
@@ -36,17 +52,41 @@ type ServerHooks struct {
 
 // Service represents a bootstrapped application with core capabilities.
 type Service struct {
-	logger    domainlog.Logger
-	config    domainconfig.Store
-	router    domainhttp.Router
-	tracer    domaintracing.Provider
-	startTime time.Time
-	server    *http.Server
-	deps      Dependencies
-	hooks     *ServerHooks // Optional test hooks
-	opts      Options
+	logger      domainlog.Logger
+	config      domainconfig.Store
+	router      domainhttp.Router
+	adminRouter chi.Router // Internal endpoints, only set when an admin port is configured
+	tracer      domaintracing.Provider
+	startTime   time.Time
+	server      *http.Server
+	listener    net.Listener // Bound before serving, unless a test hook serves instead
+	addr        atomic.Value // net.Addr of listener, read concurrently by Addr
+	adminServer *http.Server
+	adminLn     net.Listener  // Bound before serving when an admin server is configured
+	certs       *certReloader // Set when TLS certificates reload on SIGHUP
+	deps        Dependencies
+	hooks       *ServerHooks // Optional test hooks
+	opts        Options
+	startHooks  []LifecycleHook
+	stopHooks   []LifecycleHook
+	notReady    atomic.Bool            // Zero value means ready
+	reason      atomic.Pointer[string] // Reported while not ready
+	checksMu    sync.RWMutex
+	checks      []namedCheck // Readiness checks for dependencies
+}
+
+// namedCheck is a dependency check contributing to readiness
+type namedCheck struct {
+	name  string
+	check func(context.Context) error
 }
 
+// readinessCheckTimeout bounds each readiness probe's dependency checks
+const readinessCheckTimeout = 5 * time.Second
+
+// LifecycleHook is invoked when the service starts or stops
+type LifecycleHook func(ctx context.Context) error
+
 // NewService creates a new bootstrap service with all domain capabilities
 func NewService(opts Options, deps Dependencies, hooks *ServerHooks) (*Service, error) {
 
@@ -69,6 +109,7 @@ func NewService(opts Options, deps Dependencies, hooks *ServerHooks) (*Service,
 	if err := svc.initLogger(opts); err != nil {
 		return nil, err
 	}
+	svc.checkMaxHeaderSize()
 
 	if err := svc.initTracing(opts); err != nil {
 		return nil, err
@@ -113,11 +154,17 @@ func (s *Service) LoadServerConfig() (ServerConfig, error) {
 		cfg.ShutdownTimeout = 15 * time.Second
 	}
 
+	cfg.DrainDelay, _ = s.config.GetDuration("server.http.drain_delay")
+
 	// Load size limits
 	cfg.MaxHeaderSize, ok = s.config.GetInt("server.http.max_header_size")
 	if !ok {
 		cfg.MaxHeaderSize = 1 << 20 // 1MB default
 	}
+	cfg.MaxHeaderSize = clampMaxHeaderSize(cfg.MaxHeaderSize)
+
+	// Load admin listener configuration
+	cfg.AdminPort, _ = s.config.GetInt("server.http.admin_port")
 
 	// Load TLS configuration
 	cfg.TLSEnabled, _ = s.config.GetBool("server.tls.enabled")
@@ -129,15 +176,59 @@ func (s *Service) LoadServerConfig() (ServerConfig, error) {
 	return cfg, nil
 }
 
+// Bounds applied to the configured maximum header size. Very large values
+// let clients make the server buffer excessive memory per request.
+const (
+	minMaxHeaderSize = 4 << 10  // 4KB
+	maxMaxHeaderSize = 16 << 20 // 16MB
+)
+
+// clampMaxHeaderSize keeps size within sane bounds
+func clampMaxHeaderSize(size int) int {
+	return min(max(size, minMaxHeaderSize), maxMaxHeaderSize)
+}
+
+// checkMaxHeaderSize warns when the configured maximum header size is out
+// of bounds. LoadServerConfig clamps it each time the config is loaded, so
+// the warning is given once, while the service is validated.
+func (s *Service) checkMaxHeaderSize() {
+	size, ok := s.config.GetInt("server.http.max_header_size")
+	if !ok {
+		return
+	}
+	if applied := clampMaxHeaderSize(size); applied != size {
+		s.logger.WarnWith("Max header size out of bounds, clamping", domainlog.Fields{
+			"configured": size,
+			"applied":    applied,
+			"min":        minMaxHeaderSize,
+			"max":        maxMaxHeaderSize,
+		})
+	}
+}
+
 // createServer creates a new HTTP server with the given configuration
 func (s *Service) createServer(cfg ServerConfig) (*http.Server, error) {
+	var handler http.Handler = s.router
+	if cfg.TLSEnabled && s.opts.Server.ClientCAFile != "" {
+		handler = withClientSubject(handler)
+	}
+	if s.opts.Server.EnableH2C && !cfg.TLSEnabled {
+		handler = h2c.NewHandler(handler, &http2.Server{
+			IdleTimeout: cfg.IdleTimeout,
+		})
+	}
+
 	server := &http.Server{
 		Addr:           fmt.Sprintf(":%d", cfg.Port),
-		Handler:        s.router,
+		Handler:        handler,
 		ReadTimeout:    cfg.ReadTimeout,
 		WriteTimeout:   cfg.WriteTimeout,
 		IdleTimeout:    cfg.IdleTimeout,
 		MaxHeaderBytes: cfg.MaxHeaderSize,
+		ConnState:      s.opts.Server.ConnState,
+	}
+	if s.opts.Server.DisableKeepAlives {
+		server.SetKeepAlivesEnabled(false)
 	}
 
 	if err := s.configureTLS(server, cfg); err != nil {
@@ -153,68 +244,306 @@ func (s *Service) createServer(cfg ServerConfig) (*http.Server, error) {
 	return server, nil
 }
 
-// Start initializes and starts the HTTP server
-// Start initializes and starts the HTTP server
+// OnStart registers a hook that runs after the server is configured but
+// before it accepts traffic. Hooks run in registration order and a failing
+// hook aborts startup. The nth OnStop hook is paired with the nth OnStart
+// hook: when startup fails, the stop hooks paired with the start hooks that
+// completed run in reverse order. Hooks must be registered before Start or
+// Run.
+func (s *Service) OnStart(fn LifecycleHook) {
+	s.startHooks = append(s.startHooks, fn)
+}
+
+// OnStop registers a hook that runs during Shutdown, after the HTTP server
+// has stopped and before the tracer is flushed, or when startup fails after
+// its paired OnStart hook completed. Hooks run in reverse registration
+// order.
+func (s *Service) OnStop(fn LifecycleHook) {
+	s.stopHooks = append(s.stopHooks, fn)
+}
+
+// Start initializes and starts the HTTP server, blocking until it stops
 func (s *Service) Start() error {
+	return s.StartContext(context.Background())
+}
+
+// StartContext initializes and starts the HTTP server, blocking until the
+// server stops or ctx is done. When ctx is done the service is shut down
+// gracefully using the configured timeout and StartContext returns nil once
+// the server has stopped. ctx is also passed to the start hooks. If the
+// server fails instead, the stop hooks are run before its error is returned.
+func (s *Service) StartContext(ctx context.Context) error {
+	cfg, err := s.prepareServer(ctx)
+	if err != nil {
+		return err
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.serve(cfg)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if err != nil {
+			// The start hooks all ran, so undo them as Shutdown would
+			return s.unwindStart(ctx, cfg, len(s.startHooks), err)
+		}
+		return nil
+	case <-ctx.Done():
+		s.logger.InfoWith("Context done, shutting down", domainlog.Fields{
+			"reason": context.Cause(ctx).Error(),
+		})
+	}
+
+	// Detach from the cancelled context so Shutdown gets its full timeout.
+	// Shutdown closes the listeners, so serve returns even when it fails.
+	err = s.Shutdown(context.WithoutCancel(ctx))
+	return errors.Join(err, <-serveErr)
+}
+
+// Run starts the service and blocks until the server stops, SIGINT or
+// SIGTERM is received, or ctx is done. Signals and cancellation of ctx are
+// treated alike: the service is shut down gracefully using the configured
+// timeout and Run returns nil. Errors are returned wrapped, so callers can
+// tell a requested stop apart from a failure.
+func (s *Service) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := s.StartContext(ctx); err != nil {
+		return fmt.Errorf("running server: %w", err)
+	}
+	return nil
+}
+
+// prepareServer loads the server configuration, creates the HTTP server,
+// runs the registered start hooks and binds the listeners
+func (s *Service) prepareServer(ctx context.Context) (ServerConfig, error) {
 	cfg, err := s.LoadServerConfig()
 	if err != nil {
-		return fmt.Errorf("loading server config: %w", err)
+		return cfg, fmt.Errorf("loading server config: %w", err)
 	}
 
 	server, err := s.createServer(cfg)
 	if err != nil {
-		return fmt.Errorf("creating server: %w", err)
+		return cfg, fmt.Errorf("creating server: %w", err)
 	}
 	s.server = server
 
-	s.logger.InfoWith("Starting server", domainlog.Fields{
-		"address":     s.server.Addr,
-		"tls_enabled": cfg.TLSEnabled,
-		"tls_cert":    cfg.TLSCertFile,
-		"tls_key":     cfg.TLSKeyFile,
-	})
+	if s.adminRouter != nil {
+		s.adminServer = s.createAdminServer(cfg)
+	}
 
-	// Check if we should use TLS
-	if cfg.TLSEnabled && cfg.TLSCertFile != "" && cfg.TLSKeyFile != "" {
-		s.logger.InfoWith("Starting TLS server", domainlog.Fields{
-			"cert_file": cfg.TLSCertFile,
-			"key_file":  cfg.TLSKeyFile,
-		})
+	for started, hook := range s.startHooks {
+		if err := hook(ctx); err != nil {
+			return cfg, s.unwindStart(ctx, cfg, started, fmt.Errorf("start hook: %w", err))
+		}
+	}
 
-		// Use hooks if provided, otherwise use standard ListenAndServeTLS
-		if s.hooks != nil && s.hooks.ListenAndServe != nil {
-			if err := s.hooks.ListenAndServe(); err != http.ErrServerClosed {
-				return fmt.Errorf("server error: %w", err)
-			}
-		} else {
-			if err := s.server.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile); err != http.ErrServerClosed {
-				return fmt.Errorf("server error: %w", err)
-			}
+	if s.adminServer != nil {
+		if err := s.listenAdmin(); err != nil {
+			return cfg, s.unwindStart(ctx, cfg, len(s.startHooks), err)
 		}
-	} else {
-		// Use test hook if provided, otherwise use standard ListenAndServe
-		if s.hooks != nil && s.hooks.ListenAndServe != nil {
-			if err := s.hooks.ListenAndServe(); err != http.ErrServerClosed {
-				return fmt.Errorf("server error: %w", err)
-			}
-		} else {
-			if err := s.server.ListenAndServe(); err != http.ErrServerClosed {
-				return fmt.Errorf("server error: %w", err)
-			}
+	}
+
+	// Test hooks replace the listener entirely
+	if s.hooks != nil && s.hooks.ListenAndServe != nil {
+		return cfg, nil
+	}
+
+	if err := s.listen(); err != nil {
+		if s.adminLn != nil {
+			s.adminLn.Close()
 		}
+		return cfg, s.unwindStart(ctx, cfg, len(s.startHooks), err)
+	}
+
+	return cfg, nil
+}
+
+// unwindStart runs the stop hooks paired with the start hooks that
+// completed, in reverse order, after startup fails. Once every start hook
+// has completed, all stop hooks run as they would on shutdown. It returns
+// cause joined with any stop hook errors.
+func (s *Service) unwindStart(ctx context.Context, cfg ServerConfig, started int, cause error) error {
+	hooks := s.stopHooks
+	if started < len(s.startHooks) && started < len(hooks) {
+		hooks = hooks[:started]
+	}
+	if len(hooks) == 0 {
+		return cause
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cfg.ShutdownTimeout)
+	defer cancel()
+	return errors.Join(append([]error{cause}, s.runStopHooks(ctx, hooks)...)...)
+}
+
+// runStopHooks runs hooks in reverse order, logging and returning their
+// errors
+func (s *Service) runStopHooks(ctx context.Context, hooks []LifecycleHook) []error {
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i](ctx); err != nil {
+			s.logger.ErrorWith("Stop hook error", domainlog.Fields{
+				"error": err.Error(),
+			})
+			errs = append(errs, fmt.Errorf("stop hook: %w", err))
+		}
+	}
+	return errs
+}
+
+// listen binds the server's address and runs the PostStart hook with the
+// bound address. Binding here rather than in ListenAndServe makes the
+// real port known before serving begins.
+func (s *Service) listen() error {
+	ln, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", s.server.Addr, err)
+	}
+
+	if s.opts.Server.PostStart != nil {
+		if err := s.opts.Server.PostStart(ln.Addr()); err != nil {
+			ln.Close()
+			return fmt.Errorf("post-start hook: %w", err)
+		}
+	}
+
+	s.listener = ln
+	s.addr.Store(ln.Addr())
+	return nil
+}
+
+// listenAdmin binds the admin listener, so a port conflict fails startup
+// like one on the main port rather than once serving has begun
+func (s *Service) listenAdmin() error {
+	ln, err := net.Listen("tcp", s.adminServer.Addr)
+	if err != nil {
+		return fmt.Errorf("listening on admin address %s: %w", s.adminServer.Addr, err)
+	}
+	s.adminLn = ln
+	return nil
+}
+
+// Addr returns the address the server is listening on, or nil before the
+// listener is bound by Start or Run
+func (s *Service) Addr() net.Addr {
+	addr, _ := s.addr.Load().(net.Addr)
+	return addr
+}
+
+// createAdminServer creates the HTTP server for internal endpoints
+func (s *Service) createAdminServer(cfg ServerConfig) *http.Server {
+	return &http.Server{
+		Addr:           fmt.Sprintf(":%d", cfg.AdminPort),
+		Handler:        s.adminRouter,
+		ReadTimeout:    cfg.ReadTimeout,
+		WriteTimeout:   cfg.WriteTimeout,
+		IdleTimeout:    cfg.IdleTimeout,
+		MaxHeaderBytes: cfg.MaxHeaderSize,
+	}
+}
+
+// serve runs the prepared servers until they are shut down.
+// When an admin server is configured both listeners run concurrently;
+// if either fails the other is closed and all errors are returned.
+func (s *Service) serve(cfg ServerConfig) error {
+	if s.adminServer == nil {
+		return s.serveHTTP(cfg)
+	}
+
+	errCh := make(chan error, 2)
+	go func() {
+		errCh <- s.serveAdmin()
+	}()
+	go func() {
+		errCh <- s.serveHTTP(cfg)
+	}()
+
+	var errs []error
+	for i := 0; i < 2; i++ {
+		if err := <-errCh; err != nil {
+			errs = append(errs, err)
+			s.server.Close()
+			s.adminServer.Close()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// serveAdmin runs the admin server until it is shut down
+func (s *Service) serveAdmin() error {
+	s.logger.InfoWith("Starting admin server", domainlog.Fields{
+		"address": s.adminServer.Addr,
+	})
+
+	if err := s.adminServer.Serve(s.adminLn); err != http.ErrServerClosed {
+		return fmt.Errorf("admin server error: %w", err)
+	}
+	return nil
+}
+
+// serveHTTP runs the main HTTP server until it is shut down
+func (s *Service) serveHTTP(cfg ServerConfig) error {
+	// Report the bound address, which resolves port 0 to the chosen port
+	address := s.server.Addr
+	if addr := s.Addr(); addr != nil {
+		address = addr.String()
+	}
+
+	if s.certs != nil {
+		stop := s.certs.watch()
+		defer stop()
+	}
+
+	fields := domainlog.Fields{
+		"address": address,
+		"tls":     cfg.TLSEnabled,
+	}
+	if cfg.TLSEnabled && cfg.TLSCertFile != "" {
+		fields["cert_file"] = cfg.TLSCertFile
+		fields["key_file"] = cfg.TLSKeyFile
+	}
+	s.logger.InfoWith("Starting server", fields)
+
+	// Use test hook if provided, otherwise serve on the bound listener
+	var err error
+	switch {
+	case s.hooks != nil && s.hooks.ListenAndServe != nil:
+		err = s.hooks.ListenAndServe()
+	case cfg.TLSEnabled:
+		// The certificate was loaded into TLSConfig when the server was created
+		err = s.server.ServeTLS(s.listener, "", "")
+	default:
+		err = s.server.Serve(s.listener)
+	}
+	if err != http.ErrServerClosed {
+		return fmt.Errorf("server error: %w", err)
 	}
 
 	return nil
 }
 
-// Shutdown gracefully stops the service
+// Shutdown gracefully stops the service. Every step runs even when an
+// earlier one fails, so pending spans are flushed regardless, and all
+// errors are returned joined.
 func (s *Service) Shutdown(ctx context.Context) error {
 	s.logger.Info("Starting graceful shutdown")
 
-	// Get shutdown timeout from config
+	var errs []error
+
+	// Get shutdown timeout from config, falling back to the options
 	cfg, err := s.LoadServerConfig()
 	if err != nil {
-		return fmt.Errorf("loading shutdown config: %w", err)
+		errs = append(errs, fmt.Errorf("loading shutdown config: %w", err))
+		cfg.ShutdownTimeout = s.opts.Server.ShutdownTimeout
+		cfg.DrainDelay = s.opts.Server.DrainDelay
+	}
+
+	if cfg.DrainDelay > 0 {
+		s.drain(ctx, cfg.DrainDelay)
 	}
 
 	// Create timeout context using configured shutdown timeout
@@ -231,20 +560,211 @@ func (s *Service) Shutdown(ctx context.Context) error {
 		s.logger.ErrorWith("Shutdown error", domainlog.Fields{
 			"error": err.Error(),
 		})
-		return fmt.Errorf("server shutdown: %w", err)
+		errs = append(errs, fmt.Errorf("server shutdown: %w", err))
+	}
+
+	if s.adminServer != nil {
+		if err := s.adminServer.Shutdown(ctx); err != nil {
+			s.logger.ErrorWith("Admin server shutdown error", domainlog.Fields{
+				"error": err.Error(),
+			})
+			errs = append(errs, fmt.Errorf("admin server shutdown: %w", err))
+		}
 	}
 
+	errs = append(errs, s.runStopHooks(ctx, s.stopHooks)...)
+
 	if s.tracer != nil {
-		if err := s.tracer.Shutdown(ctx); err != nil {
+		// The tracer has its own timeout, so spans are flushed even when
+		// the steps above used up the shutdown timeout
+		if err := s.shutdownTracer(context.WithoutCancel(ctx)); err != nil {
 			s.logger.ErrorWith("Tracer shutdown error", domainlog.Fields{
 				"error": err.Error(),
 			})
-			return fmt.Errorf("tracer shutdown: %w", err)
+			errs = append(errs, fmt.Errorf("tracer shutdown: %w", err))
 		}
 	}
 
-	s.logger.Info("Server stopped")
-	return nil
+	if len(errs) == 0 {
+		s.logger.Info("Server stopped")
+	}
+
+	// Flush exported log entries last so the entries above are included.
+	// Like the tracer, this has its own timeout.
+	if err := s.shutdownLogger(context.WithoutCancel(ctx)); err != nil {
+		errs = append(errs, fmt.Errorf("logger shutdown: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// drain fails readiness and waits for delay so load balancers stop
+// routing new requests before the server stops accepting connections.
+// A cancelled ctx cuts the wait short; the server is still shut down.
+func (s *Service) drain(ctx context.Context, delay time.Duration) {
+	// Keep a reason set by the application, which is more specific
+	if reason := s.reason.Load(); reason == nil || *reason == "" {
+		s.SetReason("shutting down")
+	}
+	s.SetReady(false)
+	s.logger.InfoWith("Readiness failed, draining connections", domainlog.Fields{
+		"drain_delay": delay.String(),
+	})
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		s.logger.Info("Drain complete, stopping server")
+	case <-ctx.Done():
+		s.logger.WarnWith("Drain interrupted, stopping server", domainlog.Fields{
+			"error": ctx.Err().Error(),
+		})
+	}
+}
+
+// shutdownTracer flushes and stops the tracer, waiting at most
+// TracingShutdownTimeout. A timeout is logged rather than returned because
+// the servers have already stopped and only pending spans are lost.
+func (s *Service) shutdownTracer(ctx context.Context) error {
+	err := shutdownWithin(ctx, s.opts.TracingShutdownTimeout, s.tracer.Shutdown)
+	if errors.Is(err, context.DeadlineExceeded) {
+		s.logger.WarnWith("Tracer shutdown timed out, pending spans may not have been exported",
+			domainlog.Fields{"timeout": s.opts.TracingShutdownTimeout.String()})
+		return nil
+	}
+	return err
+}
+
+// shutdownLogger flushes log entries pending export when the logger exports
+// them, waiting at most TracingShutdownTimeout as for spans. Local outputs
+// stay open, so a timeout is still logged.
+func (s *Service) shutdownLogger(ctx context.Context) error {
+	exporting, ok := s.logger.(domainlog.Exporting)
+	if !ok {
+		return nil
+	}
+
+	err := shutdownWithin(ctx, s.opts.TracingShutdownTimeout, exporting.Shutdown)
+	if errors.Is(err, context.DeadlineExceeded) {
+		s.logger.WarnWith("Logger shutdown timed out, pending log entries may not have been exported",
+			domainlog.Fields{"timeout": s.opts.TracingShutdownTimeout.String()})
+		return nil
+	}
+	return err
+}
+
+// shutdownWithin calls shutdown with a context that expires after timeout.
+// It runs in a goroutine so an implementation that ignores ctx cannot
+// block exit.
+func shutdownWithin(ctx context.Context, timeout time.Duration, shutdown func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- shutdown(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetReady sets whether the default readiness probe reports the service
+// as ready. While not ready the probe returns 503 with the reason set by
+// SetReason, so traffic is drained without restarting the service.
+func (s *Service) SetReady(ready bool) {
+	s.notReady.Store(!ready)
+}
+
+// SetReason sets the reason reported by the readiness probe while the
+// service is not ready.
+func (s *Service) SetReason(reason string) {
+	s.reason.Store(&reason)
+}
+
+// RegisterReadinessCheck adds a named dependency check to the default
+// readiness probe. The probe is "ok" only if every check passes, and its
+// details report each dependency's status. Checks run concurrently with the
+// probe request's context, bounded by a timeout.
+func (s *Service) RegisterReadinessCheck(name string, check func(context.Context) error) {
+	s.checksMu.Lock()
+	defer s.checksMu.Unlock()
+	s.checks = append(s.checks, namedCheck{name: name, check: check})
+}
+
+// runReadinessChecks runs the registered checks concurrently and returns
+// each dependency's status, and whether all of them passed
+func (s *Service) runReadinessChecks(ctx context.Context) (map[string]interface{}, bool) {
+	s.checksMu.RLock()
+	checks := append([]namedCheck(nil), s.checks...)
+	s.checksMu.RUnlock()
+
+	if len(checks) == 0 {
+		return nil, true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	results := make(map[string]interface{}, len(checks))
+	healthy := true
+	for i, c := range checks {
+		if errs[i] != nil {
+			results[c.name] = errs[i].Error()
+			healthy = false
+			continue
+		}
+		results[c.name] = "ok"
+	}
+	return results, healthy
+}
+
+// readiness reports whether the service can serve traffic
+func (s *Service) readiness(ctx context.Context) domainhttp.ProbeResponse {
+	details := map[string]interface{}{
+		"startup_time": s.startTime.Format(time.RFC3339),
+	}
+	if s.notReady.Load() {
+		if reason := s.reason.Load(); reason != nil && *reason != "" {
+			details["reason"] = *reason
+		}
+		return domainhttp.ProbeResponse{
+			Status:  "failed",
+			Details: details,
+		}
+	}
+
+	results, healthy := s.runReadinessChecks(ctx)
+	if results != nil {
+		details["checks"] = results
+	}
+	if !healthy {
+		return domainhttp.ProbeResponse{
+			Status:  "failed",
+			Details: details,
+		}
+	}
+	return domainhttp.ProbeResponse{
+		Status:  "ok",
+		Details: details,
+	}
 }
 
 // Router returns the service's router
@@ -262,50 +782,123 @@ func (s *Service) Logger() domainlog.Logger {
 	return s.logger
 }
 
-// validateOptions ensures all required options are set and defaults are applied
+// Tracer returns the service's tracing provider.
+// Returns nil when tracing is not configured.
+func (s *Service) Tracer() domaintracing.Provider {
+	return s.tracer
+}
+
+// Metrics returns the metrics collector created by the router.
+// Returns nil when metrics are not configured.
+func (s *Service) Metrics() domainmetrics.Collector {
+	if provider, ok := s.router.(domainhttp.MetricsProvider); ok {
+		return provider.Metrics()
+	}
+	return nil
+}
+
+// TracingEnabled reports whether tracing is active.
+// Returns false when no tracer is configured.
+func (s *Service) TracingEnabled() bool {
+	return s.tracer != nil && s.tracer.IsEnabled()
+}
+
+// validateOptions applies option defaults and then ensures the result is
+// consistent
 func validateOptions(opts *Options) error {
+	return options.ApplyAndValidate(opts, checkOptions, withDefaults())
+}
+
+// withDefaults sets defaults for unset options
+func withDefaults() options.Option[Options] {
+	return options.OptionFunc[Options](func(opts *Options) error {
+		// Set defaults for service identity
+		if opts.Version == "" {
+			opts.Version = "dev"
+		}
+		if opts.EnvPrefix == "" {
+			opts.EnvPrefix = opts.ServiceName
+		}
+
+		// Set defaults for logging
+		if opts.LogLevel == "" {
+			opts.LogLevel = domainlog.InfoLevel
+		}
+
+		// Set defaults for server
+		if opts.Server.ShutdownTimeout == 0 {
+			opts.Server.ShutdownTimeout = 15 * time.Second
+		}
+		if opts.Server.ReadTimeout == 0 {
+			opts.Server.ReadTimeout = 15 * time.Second
+		}
+		if opts.Server.WriteTimeout == 0 {
+			opts.Server.WriteTimeout = 15 * time.Second
+		}
+		if opts.Server.IdleTimeout == 0 {
+			opts.Server.IdleTimeout = 60 * time.Second
+		}
+		if opts.Server.MaxHeaderSize == 0 {
+			opts.Server.MaxHeaderSize = 1 << 20 // 1MB default
+		}
+		if opts.Server.Port == 0 {
+			opts.Server.Port = 8080
+		}
+
+		// Set defaults for tracing
+		if opts.TracingSampleRate == nil {
+			opts.TracingSampleRate = SampleRate(defaultSampleRate(opts.Environment))
+		}
+		if opts.TracingShutdownTimeout == 0 {
+			opts.TracingShutdownTimeout = 5 * time.Second
+		}
+		return nil
+	})
+}
+
+// checkOptions enforces invariants across options once defaults are set
+func checkOptions(opts *Options) error {
 	if opts.ServiceName == "" {
-		return fmt.Errorf("service name is required")
+		return options.Invalid("ServiceName", "service name is required")
 	}
 
-	// Set defaults for service identity
-	if opts.Version == "" {
-		opts.Version = "dev"
-	}
-	if opts.EnvPrefix == "" {
-		opts.EnvPrefix = opts.ServiceName
+	if opts.EnableStandardMetrics && opts.DisableRuntimeMetrics {
+		return options.Invalid("DisableRuntimeMetrics", "standard metrics include runtime metrics, which are disabled")
 	}
 
-	// Set defaults for logging
-	if opts.LogLevel == "" {
-		opts.LogLevel = domainlog.InfoLevel
+	// Reject invalid buckets before any dependency is created
+	if len(opts.MetricsBuckets) > 0 {
+		if err := domainmetrics.WithBuckets(opts.MetricsBuckets).ApplyOption(&domainmetrics.Options{}); err != nil {
+			return options.Invalid("MetricsBuckets", "metrics buckets: %v", err)
+		}
 	}
+	return nil
+}
 
-	// Set defaults for server
-	if opts.Server.ShutdownTimeout == 0 {
-		opts.Server.ShutdownTimeout = 15 * time.Second
-	}
-	if opts.Server.ReadTimeout == 0 {
-		opts.Server.ReadTimeout = 15 * time.Second
-	}
-	if opts.Server.WriteTimeout == 0 {
-		opts.Server.WriteTimeout = 15 * time.Second
-	}
-	if opts.Server.IdleTimeout == 0 {
-		opts.Server.IdleTimeout = 60 * time.Second
-	}
-	if opts.Server.MaxHeaderSize == 0 {
-		opts.Server.MaxHeaderSize = 1 << 20 // 1MB default
-	}
-	if opts.Server.Port == 0 {
-		opts.Server.Port = 8080
+// defaultSampleRate returns the tracing sample rate for an environment,
+// sampling every trace outside production
+func defaultSampleRate(environment string) float64 {
+	switch strings.ToLower(environment) {
+	case EnvironmentProduction, "production":
+		return 0.01
+	default:
+		return 1.0
 	}
+}
 
-	// Set defaults for tracing
-	if opts.TracingSampleRate == 0 {
-		opts.TracingSampleRate = 1.0
+// registerStandardMetrics records build information and when the config
+// was loaded, at service creation, through the router's collector. Runtime
+// metrics are enabled on the collector when it is created.
+func (s *Service) registerStandardMetrics() error {
+	collector := s.Metrics()
+	if collector == nil {
+		return fmt.Errorf("standard metrics require a metrics factory")
 	}
 
+	// The collector already labels every metric with the service version
+	collector.Gauge("build_info", "go_version").Set(1, runtime.Version())
+	collector.Gauge("config_last_load_timestamp_seconds").
+		Set(float64(s.startTime.Unix()))
 	return nil
 }
 
@@ -313,22 +906,22 @@ func validateOptions(opts *Options) error {
 func (s *Service) createProbeHandlers(opts Options) *domainhttp.ProbeHandlers {
 	return &domainhttp.ProbeHandlers{
 		LivenessCheck: func() domainhttp.ProbeResponse {
+			details := map[string]interface{}{
+				"version": opts.Version,
+				"uptime":  time.Since(s.startTime).String(),
+			}
+			if opts.DefaultProbeDetails {
+				addRuntimeDetails(details)
+			}
 			return domainhttp.ProbeResponse{
-				Status: "ok",
-				Details: map[string]interface{}{
-					"version": opts.Version,
-					"uptime":  time.Since(s.startTime).String(),
-				},
+				Status:  "ok",
+				Details: details,
 			}
 		},
 		ReadinessCheck: func() domainhttp.ProbeResponse {
-			return domainhttp.ProbeResponse{
-				Status: "ok",
-				Details: map[string]interface{}{
-					"startup_time": s.startTime.Format(time.RFC3339),
-				},
-			}
+			return s.readiness(context.Background())
 		},
+		ReadinessContextCheck: s.readiness,
 		StartupCheck: func() domainhttp.ProbeResponse {
 			return domainhttp.ProbeResponse{
 				Status: "ok",
@@ -337,31 +930,83 @@ func (s *Service) createProbeHandlers(opts Options) *domainhttp.ProbeHandlers {
 	}
 }
 
+// addRuntimeDetails adds goroutine and memory statistics to probe details
+func addRuntimeDetails(details map[string]interface{}) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	details["goroutines"] = runtime.NumGoroutine()
+	details["memory"] = map[string]interface{}{
+		"alloc_bytes": mem.Alloc,
+		"sys_bytes":   mem.Sys,
+		"num_gc":      mem.NumGC,
+	}
+}
+
 func (s *Service) configureTLS(server *http.Server, cfg ServerConfig) error {
 	if !cfg.TLSEnabled {
 		return nil
 	}
 
-	// Load TLS certificate if certificate files are provided
-	if cfg.TLSCertFile != "" && cfg.TLSKeyFile != "" {
-		cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
-		if err != nil {
-			return fmt.Errorf("loading TLS cert/key: %w", err)
-		}
-
-		if server.TLSConfig == nil {
-			server.TLSConfig = &tls.Config{}
-		}
-		server.TLSConfig.Certificates = []tls.Certificate{cert}
+	cert, err := s.loadCertificate(cfg)
+	if err != nil {
+		return err
 	}
 
-	// Ensure minimum TLS version
 	if server.TLSConfig == nil {
 		server.TLSConfig = &tls.Config{}
 	}
+	if s.opts.Server.ReloadCertificates && len(s.opts.Server.TLSCertPEM) == 0 {
+		// GetCertificate is only consulted when no static certificates are set
+		s.certs = newCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile, cert, s.logger)
+		server.TLSConfig.Certificates = nil
+		server.TLSConfig.GetCertificate = s.certs.getCertificate
+	} else {
+		server.TLSConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if err := s.configureClientAuth(server.TLSConfig); err != nil {
+		return err
+	}
+
+	// Ensure minimum TLS version
 	if server.TLSConfig.MinVersion == 0 {
 		server.TLSConfig.MinVersion = tls.VersionTLS12
 	}
 
 	return nil
 }
+
+// loadCertificate loads the certificate and key from PEM bytes, or from
+// files when no PEM is given
+func (s *Service) loadCertificate(cfg ServerConfig) (tls.Certificate, error) {
+	certPEM, keyPEM := s.opts.Server.TLSCertPEM, s.opts.Server.TLSKeyPEM
+	hasFiles := cfg.TLSCertFile != "" || cfg.TLSKeyFile != ""
+	hasPEM := len(certPEM) > 0 || len(keyPEM) > 0
+
+	if hasFiles && hasPEM {
+		return tls.Certificate{}, fmt.Errorf("TLS cert and key given as both files and PEM, set only one")
+	}
+
+	if hasPEM {
+		if len(certPEM) == 0 || len(keyPEM) == 0 {
+			return tls.Certificate{}, fmt.Errorf("TLS enabled but cert PEM and key PEM are not both configured")
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("parsing TLS cert/key PEM: %w", err)
+		}
+		return cert, nil
+	}
+
+	// Refuse to fall back to plaintext when TLS was requested
+	if cfg.TLSCertFile == "" || cfg.TLSKeyFile == "" {
+		return tls.Certificate{}, fmt.Errorf("TLS enabled but cert_file and key_file are not both configured")
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("loading TLS cert/key: %w", err)
+	}
+	return cert, nil
+}
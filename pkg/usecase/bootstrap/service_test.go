@@ -4,21 +4,47 @@ package bootstrap_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/net/http2"
 
+	adapterhttp "github.com/damianoneill/go-bootstrap/pkg/adapter/http"
+	adaptermetrics "github.com/damianoneill/go-bootstrap/pkg/adapter/metrics"
 	configmocks "github.com/damianoneill/go-bootstrap/pkg/domain/config/mocks"
 	domainhttp "github.com/damianoneill/go-bootstrap/pkg/domain/http"
 	httpmocks "github.com/damianoneill/go-bootstrap/pkg/domain/http/mocks"
 	domainlog "github.com/damianoneill/go-bootstrap/pkg/domain/logging"
 	logmocks "github.com/damianoneill/go-bootstrap/pkg/domain/logging/mocks"
+	domainmetrics "github.com/damianoneill/go-bootstrap/pkg/domain/metrics"
 	metricsmocks "github.com/damianoneill/go-bootstrap/pkg/domain/metrics/mocks"
+	"github.com/damianoneill/go-bootstrap/pkg/domain/options"
 	"github.com/damianoneill/go-bootstrap/pkg/domain/tracing"
 	tracingmocks "github.com/damianoneill/go-bootstrap/pkg/domain/tracing/mocks"
 	"github.com/damianoneill/go-bootstrap/pkg/usecase/bootstrap"
@@ -73,6 +99,8 @@ func (d *testDeps) setupBasicMockExpectations(allowPort bool) {
 	d.configStore.EXPECT().GetDuration("server.http.shutdown_timeout").Return(15*time.Second, true).AnyTimes()
 	d.configStore.EXPECT().GetInt("server.http.max_header_size").Return(1<<20, true).AnyTimes()
 	d.configStore.EXPECT().GetBool("server.tls.enabled").Return(false, true).AnyTimes()
+	d.configStore.EXPECT().GetInt("server.http.admin_port").Return(0, true).AnyTimes()
+	d.configStore.EXPECT().GetDuration("server.http.drain_delay").Return(time.Duration(0), true).AnyTimes()
 
 	// Add expectations for config viewing if enabled
 	d.configStore.EXPECT().
@@ -83,6 +111,14 @@ func (d *testDeps) setupBasicMockExpectations(allowPort bool) {
 		GetMaskedConfig(gomock.Any()).
 		Return(make(map[string]interface{}), nil).
 		AnyTimes()
+	d.configStore.EXPECT().
+		GetDiffHandler(gomock.Any()).
+		Return(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).
+		AnyTimes()
+	d.configStore.EXPECT().
+		GetOriginHandler().
+		Return(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).
+		AnyTimes()
 }
 
 func (d *testDeps) setupLoggerExpectations() {
@@ -100,10 +136,11 @@ func (d *testDeps) setupLoggerExpectations() {
 
 func TestNewService(t *testing.T) {
 	tests := []struct {
-		name    string
-		opts    bootstrap.Options
-		setup   func(*testDeps)
-		wantErr bool
+		name      string
+		opts      bootstrap.Options
+		setup     func(*testDeps)
+		wantErr   bool
+		wantField string // Field of the ValidationError, for invalid options
 	}{
 		{
 			name: "successful initialization with minimal options",
@@ -122,8 +159,32 @@ func TestNewService(t *testing.T) {
 			opts: bootstrap.Options{
 				Version: "1.0.0",
 			},
-			setup:   func(d *testDeps) {},
-			wantErr: true,
+			setup:     func(d *testDeps) {},
+			wantErr:   true,
+			wantField: "ServiceName",
+		},
+		{
+			name: "error when metrics buckets are unordered",
+			opts: bootstrap.Options{
+				ServiceName:    "test-service",
+				Version:        "1.0.0",
+				MetricsBuckets: []float64{0.1, 0.05},
+			},
+			setup:     func(d *testDeps) {},
+			wantErr:   true,
+			wantField: "MetricsBuckets",
+		},
+		{
+			name: "error when standard metrics and runtime metrics conflict",
+			opts: bootstrap.Options{
+				ServiceName:           "test-service",
+				Version:               "1.0.0",
+				EnableStandardMetrics: true,
+				DisableRuntimeMetrics: true,
+			},
+			setup:     func(d *testDeps) {},
+			wantErr:   true,
+			wantField: "DisableRuntimeMetrics",
 		},
 		{
 			name: "error creating config store",
@@ -195,6 +256,28 @@ func TestNewService(t *testing.T) {
 				d.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(d.router, nil)
 			},
 		},
+		{
+			name: "initialization with log buffer",
+			opts: bootstrap.Options{
+				ServiceName:   "test-service",
+				Version:       "1.0.0",
+				LogBufferSize: 500,
+			},
+			setup: func(d *testDeps) {
+				d.setupBasicMockExpectations(true)
+				d.loggerFactory.EXPECT().NewLogger(gomock.Any()).
+					DoAndReturn(func(opts ...domainlog.Option) (domainlog.LeveledLogger, error) {
+						testOpts := &domainlog.LoggerOptions{}
+						for _, opt := range opts {
+							err := opt.ApplyOption(testOpts)
+							require.NoError(t, err)
+						}
+						assert.Equal(t, 500, testOpts.BufferSize)
+						return d.logger, nil
+					}).Times(1)
+				d.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(d.router, nil)
+			},
+		},
 		{
 			name: "initialization with custom observability exclusions",
 			opts: bootstrap.Options{
@@ -220,18 +303,109 @@ func TestNewService(t *testing.T) {
 					})
 			},
 		},
+		{
+			name: "initialization with pprof enabled",
+			opts: bootstrap.Options{
+				ServiceName:        "test-service",
+				Version:            "1.0.0",
+				ExcludeFromLogging: []string{"/custom/*"},
+				EnablePprof:        true,
+			},
+			setup: func(d *testDeps) {
+				d.setupBasicMockExpectations(true)
+				d.setupLoggerExpectations()
+				d.logger.EXPECT().InfoWith("Registered pprof endpoints", gomock.Any())
+
+				d.routerFactory.EXPECT().NewRouter(gomock.Any()).
+					DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+						testOpts := &domainhttp.RouterOptions{}
+						for _, opt := range opts {
+							err := opt.ApplyOption(testOpts)
+							require.NoError(t, err)
+						}
+						assert.True(t, testOpts.EnablePprof)
+						assert.Equal(t, []string{"/custom/*", "/internal/debug/pprof/*"}, testOpts.ExcludeFromLogging)
+						assert.Contains(t, testOpts.ExcludeFromTracing, "/internal/debug/pprof/*")
+						return d.router, nil
+					})
+			},
+		},
+		{
+			name: "initialization with custom probe paths",
+			opts: bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+				Router: domainhttp.RouterOptions{
+					ProbePaths: &domainhttp.ProbePaths{Liveness: "/healthz", Readiness: "/readyz"},
+				},
+			},
+			setup: func(d *testDeps) {
+				d.setupBasicMockExpectations(true)
+				d.setupLoggerExpectations()
+
+				d.routerFactory.EXPECT().NewRouter(gomock.Any()).
+					DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+						testOpts := &domainhttp.RouterOptions{}
+						for _, opt := range opts {
+							err := opt.ApplyOption(testOpts)
+							require.NoError(t, err)
+						}
+						assert.Equal(t, &domainhttp.ProbePaths{Liveness: "/healthz", Readiness: "/readyz"}, testOpts.ProbePaths)
+						assert.Equal(t, []string{"/internal/*", "/metrics", "/healthz", "/readyz"}, testOpts.ExcludeFromLogging)
+						assert.Equal(t, []string{"/internal/*", "/metrics", "/healthz", "/readyz"}, testOpts.ExcludeFromTracing)
+						return d.router, nil
+					})
+			},
+		},
+		{
+			name: "initialization with route viewer enabled",
+			opts: bootstrap.Options{
+				ServiceName:        "test-service",
+				Version:            "1.0.0",
+				ExcludeFromLogging: []string{"/custom/*"},
+				EnableRouteViewer:  true,
+			},
+			setup: func(d *testDeps) {
+				d.setupBasicMockExpectations(true)
+				d.logger.EXPECT().InfoWith("Registered route viewer endpoint",
+					domainlog.Fields{"path": "/internal/routes"})
+				d.setupLoggerExpectations()
+
+				d.routerFactory.EXPECT().NewRouter(gomock.Any()).
+					DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+						testOpts := &domainhttp.RouterOptions{}
+						for _, opt := range opts {
+							err := opt.ApplyOption(testOpts)
+							require.NoError(t, err)
+						}
+						assert.True(t, testOpts.EnableRouteViewer)
+						assert.Equal(t, []string{"/custom/*", "/internal/routes"}, testOpts.ExcludeFromLogging)
+						assert.Contains(t, testOpts.ExcludeFromTracing, "/internal/routes")
+						return d.router, nil
+					})
+			},
+		},
 		{
 			name: "initialization with full tracing configuration",
 			opts: bootstrap.Options{
 				ServiceName:        "test-service",
 				Version:            "1.0.0",
 				TracingEndpoint:    "localhost:4317",
-				TracingSampleRate:  0.5,
+				TracingSampleRate:  bootstrap.SampleRate(0.5),
 				TracingPropagators: []string{"tracecontext", "baggage"},
+				ResourceAttributes: map[string]string{"deployment.environment": "staging"},
 			},
 			setup: func(d *testDeps) {
 				d.setupBasicMockExpectations(true)
-				d.setupLoggerExpectations()
+				d.loggerFactory.EXPECT().NewLogger(gomock.Any()).
+					DoAndReturn(func(opts ...domainlog.Option) (domainlog.LeveledLogger, error) {
+						logOpts := &domainlog.LoggerOptions{}
+						for _, opt := range opts {
+							require.NoError(t, opt.ApplyOption(logOpts))
+						}
+						assert.Equal(t, "staging", logOpts.Fields["deployment.environment"])
+						return d.logger, nil
+					})
 				d.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(d.router, nil)
 
 				d.tracerFactory.EXPECT().NewProvider(gomock.Any()).
@@ -245,6 +419,7 @@ func TestNewService(t *testing.T) {
 						assert.Equal(t, "1.0.0", testOpts.ServiceVersion)
 						assert.Equal(t, "localhost:4317", testOpts.CollectorEndpoint)
 						assert.Equal(t, 0.5, testOpts.SamplingRate)
+						assert.Equal(t, map[string]string{"deployment.environment": "staging"}, testOpts.ResourceAttributes)
 						assert.True(t, testOpts.Insecure)
 						return d.tracer, nil
 					})
@@ -270,6 +445,11 @@ func TestNewService(t *testing.T) {
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, svc)
+				if tt.wantField != "" {
+					var verr *options.ValidationError
+					require.ErrorAs(t, err, &verr)
+					assert.Equal(t, tt.wantField, verr.Field)
+				}
 				return
 			}
 
@@ -284,141 +464,2562 @@ func TestNewService(t *testing.T) {
 	}
 }
 
-func TestService_Lifecycle(t *testing.T) {
+// TestService_RouterOptions verifies router settings given in
+// Options.Router are passed on to the router factory
+func TestService_RouterOptions(t *testing.T) {
 	tests := []struct {
-		name    string
-		opts    bootstrap.Options
-		setup   func(*testDeps)
-		wantErr bool
-		runTest func(*testing.T, *bootstrap.Service, chan error)
+		name   string
+		router domainhttp.RouterOptions
+		check  func(t *testing.T, got *domainhttp.RouterOptions)
 	}{
 		{
-			name: "successful start and shutdown",
-			opts: bootstrap.Options{
-				ServiceName: "test-service",
-				Version:     "1.0.0",
+			name:   "max request body size",
+			router: domainhttp.RouterOptions{MaxRequestBodySize: 1 << 20},
+			check: func(t *testing.T, got *domainhttp.RouterOptions) {
+				assert.Equal(t, int64(1<<20), got.MaxRequestBodySize)
 			},
-			setup: func(d *testDeps) {
-				d.setupBasicMockExpectations(true)
-				d.setupLoggerExpectations()
-				d.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(d.router, nil)
-
-				d.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).Times(1)
-				d.logger.EXPECT().Info("Starting graceful shutdown").Times(1)
-				d.logger.EXPECT().Info("Server stopped").Times(1)
+		},
+		{
+			name:   "max request duration",
+			router: domainhttp.RouterOptions{MaxRequestDuration: 30 * time.Second},
+			check: func(t *testing.T, got *domainhttp.RouterOptions) {
+				assert.Equal(t, 30*time.Second, got.MaxRequestDuration)
 			},
-			runTest: func(t *testing.T, svc *bootstrap.Service, startErrCh chan error) {
-				time.Sleep(100 * time.Millisecond)
-				err := svc.Shutdown(context.Background())
-				assert.NoError(t, err)
-				select {
-				case err := <-startErrCh:
-					assert.NoError(t, err)
-				default:
-				}
+		},
+		{
+			name: "access log fields",
+			router: domainhttp.RouterOptions{
+				AccessLogFields: func(r *http.Request, _ domainhttp.ResponseInfo) domainlog.Fields {
+					return domainlog.Fields{"path": r.URL.Path}
+				},
+			},
+			check: func(t *testing.T, got *domainhttp.RouterOptions) {
+				require.NotNil(t, got.AccessLogFields)
+				fields := got.AccessLogFields(httptest.NewRequest(http.MethodGet, "/orders", nil), domainhttp.ResponseInfo{})
+				assert.Equal(t, domainlog.Fields{"path": "/orders"}, fields)
 			},
 		},
 		{
-			name: "error getting port configuration",
-			opts: bootstrap.Options{
-				ServiceName: "test-service",
-				Version:     "1.0.0",
+			name: "require HTTPS with trusted proxies",
+			router: domainhttp.RouterOptions{
+				RequireHTTPS:   domainhttp.HTTPSRedirect,
+				TrustedProxies: []string{"10.0.0.0/8"},
 			},
-			setup: func(d *testDeps) {
-				d.setupBasicMockExpectations(false)
-				d.setupLoggerExpectations()
-				d.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(d.router, nil)
-
-				// Use gomock.Eq for exact string matching
-				d.configStore.EXPECT().
-					GetInt(gomock.Eq("server.http.port")).
-					Return(0, false)
+			check: func(t *testing.T, got *domainhttp.RouterOptions) {
+				assert.Equal(t, domainhttp.HTTPSRedirect, got.RequireHTTPS)
+				assert.Equal(t, []string{"10.0.0.0/8"}, got.TrustedProxies)
 			},
-			wantErr: true,
-			runTest: func(t *testing.T, svc *bootstrap.Service, startErrCh chan error) {
-				// Wait for error
-				select {
-				case err := <-startErrCh:
-					assert.Error(t, err)
-					assert.Contains(t, err.Error(), "server port not configured")
-				case <-time.After(time.Second):
-					t.Fatal("timeout waiting for error")
-				}
+		},
+		{
+			name:   "logged context keys",
+			router: domainhttp.RouterOptions{LoggedContextKeys: map[string]interface{}{"tenant": "tenant-key"}},
+			check: func(t *testing.T, got *domainhttp.RouterOptions) {
+				assert.Equal(t, map[string]interface{}{"tenant": "tenant-key"}, got.LoggedContextKeys)
 			},
 		},
 		{
-			name: "shutdown respects configured timeout",
-			opts: bootstrap.Options{
+			name:   "allowed hosts",
+			router: domainhttp.RouterOptions{AllowedHosts: []string{"api.example.com", "*.example.org"}},
+			check: func(t *testing.T, got *domainhttp.RouterOptions) {
+				assert.Equal(t, []string{"api.example.com", "*.example.org"}, got.AllowedHosts)
+			},
+		},
+		{
+			name:   "unmatched path label",
+			router: domainhttp.RouterOptions{UnmatchedPathLabel: "unknown"},
+			check: func(t *testing.T, got *domainhttp.RouterOptions) {
+				assert.Equal(t, "unknown", got.UnmatchedPathLabel)
+			},
+		},
+		{
+			name: "user agent classifier",
+			router: domainhttp.RouterOptions{
+				UserAgentClassifier: func(string) string { return "mobile" },
+			},
+			check: func(t *testing.T, got *domainhttp.RouterOptions) {
+				require.NotNil(t, got.UserAgentClassifier)
+				assert.Equal(t, "mobile", got.UserAgentClassifier("okhttp/4.12"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+				DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+					testOpts := &domainhttp.RouterOptions{}
+					for _, opt := range opts {
+						require.NoError(t, opt.ApplyOption(testOpts))
+					}
+					tt.check(t, testOpts)
+					return deps.router, nil
+				})
+
+			_, err := bootstrap.NewService(bootstrap.Options{
 				ServiceName: "test-service",
 				Version:     "1.0.0",
-				Server: bootstrap.ServerOptions{
-					ShutdownTimeout: 5 * time.Second,
-				},
-			},
-			setup: func(d *testDeps) {
-				d.setupBasicMockExpectations(true)
-				d.setupLoggerExpectations()
-				d.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(d.router, nil)
+				Router:      tt.router,
+			}, bootstrap.Dependencies{
+				ConfigFactory: deps.configFactory,
+				LoggerFactory: deps.loggerFactory,
+				RouterFactory: deps.routerFactory,
+			}, nil)
+			require.NoError(t, err)
+		})
+	}
+}
 
-				// Logger expectations
-				d.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).Times(1)
-				d.logger.EXPECT().Info("Starting graceful shutdown").Times(1)
-				d.logger.EXPECT().Info("Server stopped").Times(1)
-			},
-			runTest: func(t *testing.T, svc *bootstrap.Service, startErrCh chan error) {
-				// Wait briefly to let server "start"
-				time.Sleep(100 * time.Millisecond)
+// TestService_RouterOptionsForwardsEveryField sets every user-facing field
+// of Options.Router and checks each one reaches the router, so a field
+// added to RouterOptions without bootstrap wiring fails here
+func TestService_RouterOptionsForwardsEveryField(t *testing.T) {
+	// Fields the service sets from its own options rather than Options.Router
+	derived := map[string]bool{
+		"ServiceName":        true,
+		"ServiceVersion":     true,
+		"Logger":             true,
+		"TracingProvider":    true,
+		"MetricsFactory":     true,
+		"MetricsOptions":     true,
+		"ProbeHandlers":      true,
+		"ExcludeFromLogging": true,
+		"ExcludeFromTracing": true,
+		"EnablePprof":        true,
+		"EnableRouteViewer":  true,
+		"InternalRouter":     true,
+	}
 
-				// Create parent context with longer timeout
-				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-				defer cancel()
+	type contextKey struct{}
+	router := domainhttp.RouterOptions{
+		ProbePaths:         &domainhttp.ProbePaths{Liveness: "/live", Readiness: "/ready", Startup: "/started"},
+		MiddlewareOrdering: &domainhttp.MiddlewareOrdering{Order: []domainhttp.MiddlewareCategory{domainhttp.CoreMiddleware, domainhttp.SecurityMiddleware, domainhttp.ApplicationMiddleware, domainhttp.ObservabilityMiddleware}},
+		MaxRequestBodySize: 1 << 20,
+		MaxRequestDuration: time.Minute,
+		AccessLogFields: func(*http.Request, domainhttp.ResponseInfo) domainlog.Fields {
+			return nil
+		},
+		LoggedContextKeys:     map[string]interface{}{"tenant": contextKey{}},
+		ContextValues:         map[interface{}]interface{}{contextKey{}: "eu-west-1"},
+		RequireHTTPS:          domainhttp.HTTPSRedirect,
+		TrustedProxies:        []string{"10.0.0.0/8"},
+		AllowedHosts:          []string{"example.com"},
+		ResponseHeaders:       map[string]string{"Server": "test-service"},
+		SecurityHeaders:       &domainhttp.SecurityHeaderOptions{FrameOptions: "DENY"},
+		BasicAuth:             &domainhttp.BasicAuthOptions{Realm: "admin", Credentials: map[string]string{"admin": "secret"}},
+		JWTAuth:               &domainhttp.JWTOptions{Key: []byte("0123456789abcdef0123456789abcdef")},
+		CSRF:                  &domainhttp.CSRFOptions{Secret: []byte("0123456789abcdef0123456789abcdef")},
+		RequestIDHeader:       "X-Request-ID",
+		MetricsPath:           "/prometheus",
+		DisableOpenMetrics:    true,
+		UnmatchedPathLabel:    "other",
+		SpanNameFormatter:     func(*http.Request) string { return "span" },
+		SpanAttributes:        func(*http.Request) []attribute.KeyValue { return nil },
+		UserAgentClassifier:   func(string) string { return "other" },
+		ReadinessInitialDelay: time.Second,
+	}
 
-				start := time.Now()
-				err := svc.Shutdown(ctx)
-				duration := time.Since(start)
+	fields := reflect.TypeOf(router)
+	for i := 0; i < fields.NumField(); i++ {
+		name := fields.Field(i).Name
+		if !derived[name] {
+			require.False(t, reflect.ValueOf(router).Field(i).IsZero(),
+				"RouterOptions.%s is not set by this test", name)
+		}
+	}
 
-				assert.NoError(t, err)
-				// Verify shutdown completed within configured timeout
-				assert.True(t, duration < 5*time.Second)
-			},
+	deps := newTestDeps(t)
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+		DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+			got := &domainhttp.RouterOptions{}
+			for _, opt := range opts {
+				require.NoError(t, opt.ApplyOption(got))
+			}
+			for i := 0; i < fields.NumField(); i++ {
+				name := fields.Field(i).Name
+				if !derived[name] {
+					assert.False(t, reflect.ValueOf(got).Elem().Field(i).IsZero(),
+						"RouterOptions.%s was not passed to the router", name)
+				}
+			}
+			return deps.router, nil
+		})
+
+	_, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName: "test-service",
+		Version:     "1.0.0",
+		Router:      router,
+	}, bootstrap.Dependencies{
+		ConfigFactory: deps.configFactory,
+		LoggerFactory: deps.loggerFactory,
+		RouterFactory: deps.routerFactory,
+	}, nil)
+	require.NoError(t, err)
+}
+
+func TestService_TracingEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		enabled  bool
+		want     bool
+	}{
+		{
+			name: "no tracer configured",
+			want: false,
+		},
+		{
+			name:     "tracer enabled",
+			endpoint: "localhost:4317",
+			enabled:  true,
+			want:     true,
+		},
+		{
+			name:     "tracer disabled",
+			endpoint: "localhost:4317",
+			enabled:  false,
+			want:     false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			deps := newTestDeps(t)
-			if tt.setup != nil {
-				tt.setup(deps)
-			}
-
-			hooks := &bootstrap.ServerHooks{
-				ListenAndServe: func() error {
-					// simulate immediate server stop
-					return http.ErrServerClosed
-				},
-				Shutdown: func(context.Context) error {
-					return nil
-				},
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+			if tt.endpoint != "" {
+				deps.tracerFactory.EXPECT().NewProvider(gomock.Any()).Return(deps.tracer, nil)
+				deps.tracer.EXPECT().IsEnabled().Return(tt.enabled)
 			}
 
-			svc, err := bootstrap.NewService(tt.opts, bootstrap.Dependencies{
+			svc, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName:     "test-service",
+				Version:         "1.0.0",
+				TracingEndpoint: tt.endpoint,
+			}, bootstrap.Dependencies{
 				ConfigFactory:  deps.configFactory,
 				LoggerFactory:  deps.loggerFactory,
 				RouterFactory:  deps.routerFactory,
 				TracerFactory:  deps.tracerFactory,
 				MetricsFactory: deps.metricsFactory,
-			}, hooks)
-
+			}, nil)
 			require.NoError(t, err)
-			require.NotNil(t, svc)
-
-			// Test startup
-			startErrCh := make(chan error, 1)
-			go func() {
-				startErrCh <- svc.Start()
-			}()
 
-			tt.runTest(t, svc, startErrCh)
+			assert.Equal(t, tt.want, svc.TracingEnabled())
+		})
+	}
+}
+
+// metricsRouter is a router mock that also exposes a metrics collector
+type metricsRouter struct {
+	*httpmocks.MockRouter
+	collector domainmetrics.Collector
+}
+
+func (r *metricsRouter) Metrics() domainmetrics.Collector {
+	return r.collector
+}
+
+func TestService_TracerAndMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		tracing     bool
+		metrics     bool
+		wantTracer  bool
+		wantMetrics bool
+	}{
+		{
+			name: "nothing configured",
+		},
+		{
+			name:        "tracer and metrics configured",
+			tracing:     true,
+			metrics:     true,
+			wantTracer:  true,
+			wantMetrics: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+
+			opts := bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+			}
+			if tt.tracing {
+				opts.TracingEndpoint = "localhost:4317"
+				deps.tracerFactory.EXPECT().NewProvider(gomock.Any()).Return(deps.tracer, nil)
+			}
+
+			collector := metricsmocks.NewMockCollector(deps.ctrl)
+			if tt.metrics {
+				deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+					Return(&metricsRouter{MockRouter: deps.router, collector: collector}, nil)
+			} else {
+				deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+			}
+
+			svc, err := bootstrap.NewService(opts, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, nil)
+			require.NoError(t, err)
+
+			if tt.wantTracer {
+				assert.Equal(t, deps.tracer, svc.Tracer())
+			} else {
+				assert.Nil(t, svc.Tracer())
+			}
+			if tt.wantMetrics {
+				assert.Equal(t, collector, svc.Metrics())
+			} else {
+				assert.Nil(t, svc.Metrics())
+			}
+		})
+	}
+}
+
+func TestService_EnableStandardMetrics(t *testing.T) {
+	t.Run("registers build, runtime and config metrics", func(t *testing.T) {
+		deps := newTestDeps(t)
+		deps.setupBasicMockExpectations(true)
+		deps.setupLoggerExpectations()
+
+		collector := metricsmocks.NewMockCollector(deps.ctrl)
+		buildInfo := metricsmocks.NewMockGaugeHandle(deps.ctrl)
+		configLoad := metricsmocks.NewMockGaugeHandle(deps.ctrl)
+		collector.EXPECT().Gauge("build_info", "go_version").Return(buildInfo)
+		collector.EXPECT().Gauge("config_last_load_timestamp_seconds").Return(configLoad)
+		buildInfo.EXPECT().Set(float64(1), runtime.Version())
+		configLoad.EXPECT().Set(gomock.Any()).Do(func(value float64, _ ...string) {
+			assert.InDelta(t, float64(time.Now().Unix()), value, 5)
+		})
+
+		var metricsOpts domainmetrics.Options
+		deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+			DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+				testOpts := &domainhttp.RouterOptions{}
+				for _, opt := range opts {
+					require.NoError(t, opt.ApplyOption(testOpts))
+				}
+				for _, opt := range testOpts.MetricsOptions {
+					require.NoError(t, opt.ApplyOption(&metricsOpts))
+				}
+				return &metricsRouter{MockRouter: deps.router, collector: collector}, nil
+			})
+
+		_, err := bootstrap.NewService(bootstrap.Options{
+			ServiceName:           "test-service",
+			Version:               "1.0.0",
+			EnableStandardMetrics: true,
+		}, bootstrap.Dependencies{
+			ConfigFactory:  deps.configFactory,
+			LoggerFactory:  deps.loggerFactory,
+			RouterFactory:  deps.routerFactory,
+			TracerFactory:  deps.tracerFactory,
+			MetricsFactory: deps.metricsFactory,
+		}, nil)
+		require.NoError(t, err)
+		assert.True(t, metricsOpts.RuntimeMetrics)
+	})
+
+	t.Run("requires a metrics factory", func(t *testing.T) {
+		deps := newTestDeps(t)
+		deps.setupBasicMockExpectations(true)
+		deps.setupLoggerExpectations()
+		deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+
+		_, err := bootstrap.NewService(bootstrap.Options{
+			ServiceName:           "test-service",
+			Version:               "1.0.0",
+			EnableStandardMetrics: true,
+		}, bootstrap.Dependencies{
+			ConfigFactory: deps.configFactory,
+			LoggerFactory: deps.loggerFactory,
+			RouterFactory: deps.routerFactory,
+			TracerFactory: deps.tracerFactory,
+		}, nil)
+		assert.EqualError(t, err, "standard metrics require a metrics factory")
+	})
+
+	t.Run("registers with the prometheus collector", func(t *testing.T) {
+		deps := newTestDeps(t)
+		deps.setupBasicMockExpectations(true)
+		deps.setupLoggerExpectations()
+
+		registry := prometheus.NewRegistry()
+		_, err := bootstrap.NewService(bootstrap.Options{
+			ServiceName:           "test-service",
+			Version:               "1.0.0",
+			EnableStandardMetrics: true,
+		}, bootstrap.Dependencies{
+			ConfigFactory:  deps.configFactory,
+			LoggerFactory:  deps.loggerFactory,
+			RouterFactory:  adapterhttp.NewFactory(),
+			MetricsFactory: adaptermetrics.NewMetricsFactoryWithRegistry(registry),
+		}, nil)
+		require.NoError(t, err)
+
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		var labels map[string]string
+		for _, family := range families {
+			if family.GetName() == "build_info" {
+				labels = map[string]string{}
+				for _, label := range family.GetMetric()[0].GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+			}
+		}
+		assert.Equal(t, "1.0.0", labels["version"])
+		assert.Equal(t, runtime.Version(), labels["go_version"])
+	})
+}
+
+func TestService_InfoEndpoint(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+	deps.logger.EXPECT().InfoWith("Registered service info endpoint", domainlog.Fields{"path": "/internal/info"})
+	deps.logger.EXPECT().GetLevel().Return(domainlog.DebugLevel)
+	deps.tracerFactory.EXPECT().NewProvider(gomock.Any()).Return(deps.tracer, nil)
+	deps.tracer.EXPECT().IsEnabled().Return(true)
+	deps.configStore.EXPECT().IsSet("features").Return(true)
+	deps.configStore.EXPECT().UnmarshalKey("features", gomock.Any()).
+		DoAndReturn(func(_ string, target interface{}) error {
+			*target.(*map[string]interface{}) = map[string]interface{}{"new_checkout": true}
+			return nil
+		})
+
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+		DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+			testOpts := &domainhttp.RouterOptions{}
+			for _, opt := range opts {
+				require.NoError(t, opt.ApplyOption(testOpts))
+			}
+			assert.Contains(t, testOpts.ExcludeFromLogging, "/internal/info")
+			assert.Contains(t, testOpts.ExcludeFromTracing, "/internal/info")
+			return deps.router, nil
+		})
+
+	var info http.Handler
+	deps.router.EXPECT().Mount("/internal/info", gomock.Any()).
+		Do(func(_ string, h http.Handler) {
+			info = h
+		})
+
+	_, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName:        "test-service",
+		Version:            "1.0.0",
+		Environment:        bootstrap.EnvironmentProduction,
+		EnableInfo:         true,
+		ExcludeFromLogging: []string{"/custom/*"},
+		ExcludeFromTracing: []string{"/custom/*"},
+		TracingEndpoint:    "localhost:4317",
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, nil)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+
+	rec := httptest.NewRecorder()
+	info.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/internal/info", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &payload))
+
+	assert.Equal(t, map[string]interface{}{
+		"name":        "test-service",
+		"version":     "1.0.0",
+		"environment": "prod",
+	}, payload["service"])
+	assert.Equal(t, runtime.Version(), payload["build"].(map[string]interface{})["go_version"])
+	assert.NotEmpty(t, payload["start_time"])
+	assert.NotEmpty(t, payload["uptime"])
+	assert.Equal(t, "debug", payload["log_level"])
+	assert.Equal(t, true, payload["tracing_enabled"])
+	assert.Equal(t, map[string]interface{}{"new_checkout": true}, payload["features"])
+}
+
+func TestService_Readiness(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+
+	var probes *domainhttp.ProbeHandlers
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+		DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+			testOpts := &domainhttp.RouterOptions{}
+			for _, opt := range opts {
+				require.NoError(t, opt.ApplyOption(testOpts))
+			}
+			probes = testOpts.ProbeHandlers
+			return deps.router, nil
+		})
+
+	svc, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName: "test-service",
+		Version:     "1.0.0",
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, nil)
+	require.NoError(t, err)
+	require.NotNil(t, probes)
+
+	// Ready by default
+	resp := probes.ReadinessCheck()
+	assert.Equal(t, "ok", resp.Status)
+	assert.NotContains(t, resp.Details, "reason")
+
+	svc.SetReason("database unavailable")
+	svc.SetReady(false)
+	resp = probes.ReadinessCheck()
+	assert.Equal(t, "failed", resp.Status)
+	assert.Equal(t, "database unavailable", resp.Details["reason"])
+
+	svc.SetReady(true)
+	resp = probes.ReadinessCheck()
+	assert.Equal(t, "ok", resp.Status)
+	assert.NotContains(t, resp.Details, "reason")
+}
+
+func TestService_ReadinessChecks(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     map[string]error
+		wantStatus string
+		wantChecks map[string]interface{}
+	}{
+		{
+			name:       "no checks registered",
+			wantStatus: "ok",
+		},
+		{
+			name: "all checks pass",
+			checks: map[string]error{
+				"database": nil,
+				"cache":    nil,
+			},
+			wantStatus: "ok",
+			wantChecks: map[string]interface{}{
+				"database": "ok",
+				"cache":    "ok",
+			},
+		},
+		{
+			name: "one check fails",
+			checks: map[string]error{
+				"database": nil,
+				"cache":    errors.New("connection refused"),
+			},
+			wantStatus: "failed",
+			wantChecks: map[string]interface{}{
+				"database": "ok",
+				"cache":    "connection refused",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+
+			var probes *domainhttp.ProbeHandlers
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+				DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+					testOpts := &domainhttp.RouterOptions{}
+					for _, opt := range opts {
+						require.NoError(t, opt.ApplyOption(testOpts))
+					}
+					probes = testOpts.ProbeHandlers
+					return deps.router, nil
+				})
+
+			svc, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+			}, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, nil)
+			require.NoError(t, err)
+
+			for name, checkErr := range tt.checks {
+				svc.RegisterReadinessCheck(name, func(context.Context) error {
+					return checkErr
+				})
+			}
+
+			resp := probes.ReadinessContextCheck(context.Background())
+			assert.Equal(t, tt.wantStatus, resp.Status)
+			if tt.wantChecks == nil {
+				assert.NotContains(t, resp.Details, "checks")
+				return
+			}
+			assert.Equal(t, tt.wantChecks, resp.Details["checks"])
+		})
+	}
+}
+
+func TestService_ReadinessChecksUseRequestContext(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+
+	var probes *domainhttp.ProbeHandlers
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+		DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+			testOpts := &domainhttp.RouterOptions{}
+			for _, opt := range opts {
+				require.NoError(t, opt.ApplyOption(testOpts))
+			}
+			probes = testOpts.ProbeHandlers
+			return deps.router, nil
+		})
+
+	svc, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName: "test-service",
+		Version:     "1.0.0",
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, nil)
+	require.NoError(t, err)
+
+	svc.RegisterReadinessCheck("broker", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	resp := probes.ReadinessContextCheck(ctx)
+	assert.Equal(t, "failed", resp.Status)
+	assert.Equal(t, map[string]interface{}{
+		"broker": context.DeadlineExceeded.Error(),
+	}, resp.Details["checks"])
+}
+
+func TestService_MetricsBuckets(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+
+	var metricsOpts domainmetrics.Options
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+		DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+			testOpts := &domainhttp.RouterOptions{}
+			for _, opt := range opts {
+				require.NoError(t, opt.ApplyOption(testOpts))
+			}
+			assert.Equal(t, deps.metricsFactory, testOpts.MetricsFactory)
+			for _, opt := range testOpts.MetricsOptions {
+				require.NoError(t, opt.ApplyOption(&metricsOpts))
+			}
+			return deps.router, nil
+		})
+
+	_, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName:    "test-service",
+		Version:        "1.0.0",
+		MetricsBuckets: []float64{0.001, 0.005, 0.01, 0.05},
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []float64{0.001, 0.005, 0.01, 0.05}, metricsOpts.Buckets)
+	assert.True(t, metricsOpts.RuntimeMetrics, "runtime metrics are on by default")
+}
+
+func TestService_DisableRuntimeMetrics(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+
+	var metricsOpts domainmetrics.Options
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+		DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+			testOpts := &domainhttp.RouterOptions{}
+			for _, opt := range opts {
+				require.NoError(t, opt.ApplyOption(testOpts))
+			}
+			for _, opt := range testOpts.MetricsOptions {
+				require.NoError(t, opt.ApplyOption(&metricsOpts))
+			}
+			return deps.router, nil
+		})
+
+	_, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName:           "test-service",
+		Version:               "1.0.0",
+		DisableRuntimeMetrics: true,
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, nil)
+	require.NoError(t, err)
+
+	assert.False(t, metricsOpts.RuntimeMetrics)
+}
+
+func TestService_EnvironmentSampleRate(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		rate        *float64
+		want        float64
+	}{
+		{
+			name: "no environment samples everything",
+			want: 1.0,
+		},
+		{
+			name:        "development samples everything",
+			environment: bootstrap.EnvironmentDevelopment,
+			want:        1.0,
+		},
+		{
+			name:        "production samples one percent",
+			environment: bootstrap.EnvironmentProduction,
+			want:        0.01,
+		},
+		{
+			name:        "production long form is recognised",
+			environment: "Production",
+			want:        0.01,
+		},
+		{
+			name:        "explicit rate overrides production default",
+			environment: bootstrap.EnvironmentProduction,
+			rate:        bootstrap.SampleRate(0.25),
+			want:        0.25,
+		},
+		{
+			name:        "explicit rate overrides development default",
+			environment: bootstrap.EnvironmentDevelopment,
+			rate:        bootstrap.SampleRate(0.5),
+			want:        0.5,
+		},
+		{
+			name:        "explicit zero samples nothing in production",
+			environment: bootstrap.EnvironmentProduction,
+			rate:        bootstrap.SampleRate(0),
+			want:        0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+
+			var tracingOpts tracing.Options
+			deps.tracerFactory.EXPECT().NewProvider(gomock.Any()).
+				DoAndReturn(func(opts ...tracing.Option) (tracing.Provider, error) {
+					for _, opt := range opts {
+						require.NoError(t, opt.ApplyOption(&tracingOpts))
+					}
+					return deps.tracer, nil
+				})
+
+			_, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName:       "test-service",
+				Version:           "1.0.0",
+				Environment:       tt.environment,
+				TracingEndpoint:   "localhost:4317",
+				TracingSampleRate: tt.rate,
+			}, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, tracingOpts.SamplingRate)
+		})
+	}
+}
+
+func TestService_DefaultProbeDetails(t *testing.T) {
+	tests := []struct {
+		name           string
+		enabled        bool
+		wantGoroutines bool
+	}{
+		{name: "minimal details by default", enabled: false, wantGoroutines: false},
+		{name: "enriched details when enabled", enabled: true, wantGoroutines: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+
+			var probes *domainhttp.ProbeHandlers
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+				DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+					testOpts := &domainhttp.RouterOptions{}
+					for _, opt := range opts {
+						require.NoError(t, opt.ApplyOption(testOpts))
+					}
+					probes = testOpts.ProbeHandlers
+					return deps.router, nil
+				})
+
+			_, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName:         "test-service",
+				Version:             "1.0.0",
+				DefaultProbeDetails: tt.enabled,
+			}, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, nil)
+			require.NoError(t, err)
+
+			liveness := probes.LivenessCheck()
+			assert.Equal(t, "1.0.0", liveness.Details["version"])
+			assert.Contains(t, liveness.Details, "uptime")
+
+			if tt.wantGoroutines {
+				assert.Greater(t, liveness.Details["goroutines"], 0)
+				memory, ok := liveness.Details["memory"].(map[string]interface{})
+				require.True(t, ok)
+				assert.Contains(t, memory, "alloc_bytes")
+				assert.Contains(t, memory, "sys_bytes")
+			} else {
+				assert.NotContains(t, liveness.Details, "goroutines")
+				assert.NotContains(t, liveness.Details, "memory")
+			}
+
+			readiness := probes.ReadinessCheck()
+			assert.Contains(t, readiness.Details, "startup_time")
+		})
+	}
+}
+
+func TestService_Lifecycle(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    bootstrap.Options
+		setup   func(*testDeps)
+		wantErr bool
+		runTest func(*testing.T, *bootstrap.Service, chan error)
+	}{
+		{
+			name: "successful start and shutdown",
+			opts: bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+			},
+			setup: func(d *testDeps) {
+				d.setupBasicMockExpectations(true)
+				d.setupLoggerExpectations()
+				d.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(d.router, nil)
+
+				d.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).Times(1)
+				d.logger.EXPECT().Info("Starting graceful shutdown").Times(1)
+				d.logger.EXPECT().Info("Server stopped").Times(1)
+			},
+			runTest: func(t *testing.T, svc *bootstrap.Service, startErrCh chan error) {
+				time.Sleep(100 * time.Millisecond)
+				err := svc.Shutdown(context.Background())
+				assert.NoError(t, err)
+				select {
+				case err := <-startErrCh:
+					assert.NoError(t, err)
+				default:
+				}
+			},
+		},
+		{
+			name: "error getting port configuration",
+			opts: bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+			},
+			setup: func(d *testDeps) {
+				d.setupBasicMockExpectations(false)
+				d.setupLoggerExpectations()
+				d.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(d.router, nil)
+
+				// Use gomock.Eq for exact string matching
+				d.configStore.EXPECT().
+					GetInt(gomock.Eq("server.http.port")).
+					Return(0, false)
+			},
+			wantErr: true,
+			runTest: func(t *testing.T, svc *bootstrap.Service, startErrCh chan error) {
+				// Wait for error
+				select {
+				case err := <-startErrCh:
+					assert.Error(t, err)
+					assert.Contains(t, err.Error(), "server port not configured")
+				case <-time.After(time.Second):
+					t.Fatal("timeout waiting for error")
+				}
+			},
+		},
+		{
+			name: "shutdown respects configured timeout",
+			opts: bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+				Server: bootstrap.ServerOptions{
+					ShutdownTimeout: 5 * time.Second,
+				},
+			},
+			setup: func(d *testDeps) {
+				d.setupBasicMockExpectations(true)
+				d.setupLoggerExpectations()
+				d.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(d.router, nil)
+
+				// Logger expectations
+				d.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).Times(1)
+				d.logger.EXPECT().Info("Starting graceful shutdown").Times(1)
+				d.logger.EXPECT().Info("Server stopped").Times(1)
+			},
+			runTest: func(t *testing.T, svc *bootstrap.Service, startErrCh chan error) {
+				// Wait briefly to let server "start"
+				time.Sleep(100 * time.Millisecond)
+
+				// Create parent context with longer timeout
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+
+				start := time.Now()
+				err := svc.Shutdown(ctx)
+				duration := time.Since(start)
+
+				assert.NoError(t, err)
+				// Verify shutdown completed within configured timeout
+				assert.True(t, duration < 5*time.Second)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			if tt.setup != nil {
+				tt.setup(deps)
+			}
+
+			hooks := &bootstrap.ServerHooks{
+				ListenAndServe: func() error {
+					// simulate immediate server stop
+					return http.ErrServerClosed
+				},
+				Shutdown: func(context.Context) error {
+					return nil
+				},
+			}
+
+			svc, err := bootstrap.NewService(tt.opts, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, hooks)
+
+			require.NoError(t, err)
+			require.NotNil(t, svc)
+
+			// Test startup
+			startErrCh := make(chan error, 1)
+			go func() {
+				startErrCh <- svc.Start()
+			}()
+
+			tt.runTest(t, svc, startErrCh)
+		})
+	}
+}
+
+func TestService_LifecycleHooks(t *testing.T) {
+	tests := []struct {
+		name      string
+		failing   int // Position of the failing start hook, if any
+		wantOrder []string
+	}{
+		{
+			name: "hooks run in order around the server",
+			wantOrder: []string{
+				"start-1", "start-2", "serve",
+				"server-shutdown", "stop-2", "stop-1",
+			},
+		},
+		{
+			name:      "failing start hook aborts startup",
+			failing:   1,
+			wantOrder: []string{"start-1"},
+		},
+		{
+			name:      "partial start is unwound",
+			failing:   2,
+			wantOrder: []string{"start-1", "start-2", "stop-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+			deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+			deps.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+			var order []string
+			hooks := &bootstrap.ServerHooks{
+				ListenAndServe: func() error {
+					order = append(order, "serve")
+					return http.ErrServerClosed
+				},
+				Shutdown: func(context.Context) error {
+					order = append(order, "server-shutdown")
+					return nil
+				},
+			}
+
+			svc, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+			}, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, hooks)
+			require.NoError(t, err)
+
+			errStart := errors.New("database unavailable")
+			for i := 1; i <= 2; i++ {
+				svc.OnStart(func(context.Context) error {
+					order = append(order, fmt.Sprintf("start-%d", i))
+					if i == tt.failing {
+						return errStart
+					}
+					return nil
+				})
+				svc.OnStop(func(context.Context) error {
+					order = append(order, fmt.Sprintf("stop-%d", i))
+					return nil
+				})
+			}
+
+			err = svc.Start()
+			if tt.failing != 0 {
+				assert.ErrorIs(t, err, errStart)
+				assert.Equal(t, tt.wantOrder, order)
+				return
+			}
+			require.NoError(t, err)
+
+			require.NoError(t, svc.Shutdown(context.Background()))
+			assert.Equal(t, tt.wantOrder, order)
+		})
+	}
+}
+
+func TestService_LifecycleHooksListenFailure(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.setupBasicMockExpectations(false)
+	deps.configStore.EXPECT().GetInt("server.http.port").Return(0, true).AnyTimes()
+	deps.setupLoggerExpectations()
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+	deps.logger.EXPECT().ErrorWith("Stop hook error", domainlog.Fields{"error": "pool already closed"})
+
+	svc, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName: "test-service",
+		Version:     "1.0.0",
+		Server: bootstrap.ServerOptions{
+			PostStart: func(net.Addr) error {
+				return errors.New("registry unavailable")
+			},
+		},
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, nil)
+	require.NoError(t, err)
+
+	var order []string
+	svc.OnStart(func(context.Context) error {
+		order = append(order, "start-1")
+		return nil
+	})
+	svc.OnStop(func(context.Context) error {
+		order = append(order, "stop-1")
+		return errors.New("pool already closed")
+	})
+	svc.OnStop(func(context.Context) error {
+		order = append(order, "stop-2")
+		return nil
+	})
+
+	// Every start hook completed, so all stop hooks run
+	err = svc.Start()
+	assert.ErrorContains(t, err, "post-start hook: registry unavailable")
+	assert.ErrorContains(t, err, "stop hook: pool already closed")
+	assert.Equal(t, []string{"start-1", "stop-2", "stop-1"}, order)
+}
+
+func TestService_DrainDelay(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.configStore.EXPECT().GetDuration("server.http.drain_delay").Return(100*time.Millisecond, true).AnyTimes()
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+
+	// Readiness fails and the drain delay elapses before the server stops
+	gomock.InOrder(
+		deps.logger.EXPECT().InfoWith("Readiness failed, draining connections",
+			domainlog.Fields{"drain_delay": "100ms"}),
+		deps.logger.EXPECT().Info("Drain complete, stopping server"),
+	)
+	deps.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+	deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+
+	var probes *domainhttp.ProbeHandlers
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+		DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+			testOpts := &domainhttp.RouterOptions{}
+			for _, opt := range opts {
+				require.NoError(t, opt.ApplyOption(testOpts))
+			}
+			probes = testOpts.ProbeHandlers
+			return deps.router, nil
+		})
+
+	var (
+		shutdownAt  time.Time
+		readyAtStop string
+	)
+	hooks := &bootstrap.ServerHooks{
+		ListenAndServe: func() error { return http.ErrServerClosed },
+		Shutdown: func(context.Context) error {
+			shutdownAt = time.Now()
+			readyAtStop = probes.ReadinessCheck().Status
+			return nil
+		},
+	}
+
+	svc, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName: "test-service",
+		Version:     "1.0.0",
+		Server:      bootstrap.ServerOptions{DrainDelay: 100 * time.Millisecond},
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, hooks)
+	require.NoError(t, err)
+	require.NoError(t, svc.Start())
+	require.Equal(t, "ok", probes.ReadinessCheck().Status)
+
+	start := time.Now()
+	require.NoError(t, svc.Shutdown(context.Background()))
+
+	assert.Equal(t, "failed", readyAtStop)
+	assert.GreaterOrEqual(t, shutdownAt.Sub(start), 100*time.Millisecond)
+	assert.Equal(t, "shutting down", probes.ReadinessCheck().Details["reason"])
+}
+
+func TestService_DrainKeepsReadinessReason(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.configStore.EXPECT().GetDuration("server.http.drain_delay").Return(time.Millisecond, true).AnyTimes()
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+	deps.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+	deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+
+	var probes *domainhttp.ProbeHandlers
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+		DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+			testOpts := &domainhttp.RouterOptions{}
+			for _, opt := range opts {
+				require.NoError(t, opt.ApplyOption(testOpts))
+			}
+			probes = testOpts.ProbeHandlers
+			return deps.router, nil
+		})
+
+	hooks := &bootstrap.ServerHooks{
+		ListenAndServe: func() error { return http.ErrServerClosed },
+		Shutdown:       func(context.Context) error { return nil },
+	}
+
+	svc, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName: "test-service",
+		Version:     "1.0.0",
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, hooks)
+	require.NoError(t, err)
+	require.NoError(t, svc.Start())
+
+	// A reason set by the application is not replaced by the drain
+	svc.SetReason("database failover")
+	require.NoError(t, svc.Shutdown(context.Background()))
+	assert.Equal(t, "database failover", probes.ReadinessCheck().Details["reason"])
+}
+
+func TestService_TracerShutdownTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		shutdown func(ctx context.Context) error
+	}{
+		{
+			name: "exporter honours context",
+			shutdown: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		},
+		{
+			name: "exporter ignores context",
+			shutdown: func(context.Context) error {
+				time.Sleep(2 * time.Second)
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+			deps.tracerFactory.EXPECT().NewProvider(gomock.Any()).Return(deps.tracer, nil)
+			deps.tracer.EXPECT().Shutdown(gomock.Any()).DoAndReturn(tt.shutdown)
+			deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+			deps.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+			deps.logger.EXPECT().
+				WarnWith("Tracer shutdown timed out, pending spans may not have been exported", gomock.Any())
+
+			hooks := &bootstrap.ServerHooks{
+				ListenAndServe: func() error { return http.ErrServerClosed },
+				Shutdown:       func(context.Context) error { return nil },
+			}
+
+			svc, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName:            "test-service",
+				Version:                "1.0.0",
+				TracingEndpoint:        "localhost:4317",
+				TracingShutdownTimeout: 50 * time.Millisecond,
+			}, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, hooks)
+			require.NoError(t, err)
+			require.NoError(t, svc.Start())
+
+			start := time.Now()
+			require.NoError(t, svc.Shutdown(context.Background()))
+			assert.Less(t, time.Since(start), time.Second)
+		})
+	}
+}
+
+func TestService_ShutdownRunsEveryStep(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+	deps.tracerFactory.EXPECT().NewProvider(gomock.Any()).Return(deps.tracer, nil)
+	deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+	deps.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+	deps.logger.EXPECT().ErrorWith("Shutdown error", gomock.Any())
+	deps.logger.EXPECT().ErrorWith("Stop hook error", gomock.Any())
+
+	// Spans are flushed even though earlier steps failed
+	deps.tracer.EXPECT().Shutdown(gomock.Any()).Return(nil)
+
+	errServer := errors.New("connections did not close")
+	errHook := errors.New("pool already closed")
+	hooks := &bootstrap.ServerHooks{
+		ListenAndServe: func() error { return http.ErrServerClosed },
+		Shutdown:       func(context.Context) error { return errServer },
+	}
+
+	svc, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName:     "test-service",
+		Version:         "1.0.0",
+		TracingEndpoint: "localhost:4317",
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, hooks)
+	require.NoError(t, err)
+
+	stopped := false
+	svc.OnStop(func(context.Context) error { return errHook })
+	svc.OnStop(func(context.Context) error {
+		stopped = true
+		return nil
+	})
+	require.NoError(t, svc.Start())
+
+	err = svc.Shutdown(context.Background())
+	assert.ErrorIs(t, err, errServer)
+	assert.ErrorIs(t, err, errHook)
+	assert.True(t, stopped)
+}
+
+// exportingLogger is a logger that exports entries and must be shut down
+type exportingLogger struct {
+	*logmocks.MockLeveledLogger
+	shutdown func(ctx context.Context) error
+}
+
+func (l *exportingLogger) Shutdown(ctx context.Context) error {
+	return l.shutdown(ctx)
+}
+
+func TestService_ShutdownFlushesExportedLogs(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "flushed after the last entry"},
+		{name: "error returned", err: errors.New("collector unreachable"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			deps.setupBasicMockExpectations(true)
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+			deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+
+			stopped := false
+			deps.logger.EXPECT().Info(gomock.Any()).Do(func(msg string) {
+				if msg == "Server stopped" {
+					stopped = true
+				}
+			}).AnyTimes()
+
+			flushed := false
+			logger := &exportingLogger{
+				MockLeveledLogger: deps.logger,
+				shutdown: func(ctx context.Context) error {
+					_, hasDeadline := ctx.Deadline()
+					assert.True(t, hasDeadline)
+					assert.True(t, stopped, "logger shut down before the final entry")
+					flushed = true
+					return tt.err
+				},
+			}
+			deps.loggerFactory.EXPECT().NewLogger(gomock.Any()).Return(logger, nil)
+
+			svc, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+			}, bootstrap.Dependencies{
+				ConfigFactory: deps.configFactory,
+				LoggerFactory: deps.loggerFactory,
+				RouterFactory: deps.routerFactory,
+			}, &bootstrap.ServerHooks{
+				ListenAndServe: func() error { return http.ErrServerClosed },
+				Shutdown:       func(context.Context) error { return nil },
+			})
+			require.NoError(t, err)
+
+			err = svc.Shutdown(context.Background())
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.True(t, flushed)
+		})
+	}
+}
+
+func TestService_Run(t *testing.T) {
+	tests := []struct {
+		name      string
+		serverErr error
+		cancel    bool
+		signal    bool
+		wantErr   bool
+	}{
+		{
+			name:   "context cancellation shuts down cleanly",
+			cancel: true,
+		},
+		{
+			name:   "termination signal shuts down cleanly",
+			signal: true,
+		},
+		{
+			name:      "server error is returned",
+			serverErr: errors.New("bind: address already in use"),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+			deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+			deps.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+			stopped := make(chan struct{})
+			shutdownCalled := false
+			hooks := &bootstrap.ServerHooks{
+				ListenAndServe: func() error {
+					if tt.serverErr != nil {
+						return tt.serverErr
+					}
+					if tt.signal {
+						proc, err := os.FindProcess(os.Getpid())
+						if err != nil {
+							return err
+						}
+						if err := proc.Signal(syscall.SIGTERM); err != nil {
+							return err
+						}
+					}
+					<-stopped
+					return http.ErrServerClosed
+				},
+				Shutdown: func(context.Context) error {
+					shutdownCalled = true
+					close(stopped)
+					return nil
+				},
+			}
+
+			svc, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+			}, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, hooks)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			runErrCh := make(chan error, 1)
+			go func() {
+				runErrCh <- svc.Run(ctx)
+			}()
+
+			if tt.cancel {
+				time.Sleep(50 * time.Millisecond)
+				cancel()
+			}
+
+			select {
+			case err := <-runErrCh:
+				if tt.wantErr {
+					assert.ErrorIs(t, err, tt.serverErr)
+					assert.False(t, shutdownCalled)
+					return
+				}
+				assert.NoError(t, err)
+				assert.True(t, shutdownCalled)
+			case <-time.After(time.Second):
+				t.Fatal("timeout waiting for Run to return")
+			}
+		})
+	}
+}
+
+func TestService_StartContext(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverErr     error // Returned by the server straight away
+		lateServerErr error // Returned by the server once shut down
+		shutdownErr   error
+		cancel        bool
+		wantErr       bool
+	}{
+		{
+			name:   "context cancellation shuts down cleanly",
+			cancel: true,
+		},
+		{
+			name:      "server error is returned",
+			serverErr: errors.New("bind: address already in use"),
+			wantErr:   true,
+		},
+		{
+			name:          "shutdown error is joined with the server error",
+			lateServerErr: errors.New("connection reset"),
+			shutdownErr:   errors.New("connections did not close"),
+			cancel:        true,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+			deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+			deps.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+			deps.logger.EXPECT().ErrorWith(gomock.Any(), gomock.Any()).AnyTimes()
+
+			stopped := make(chan struct{})
+			shutdownCalled := false
+			hooks := &bootstrap.ServerHooks{
+				ListenAndServe: func() error {
+					if tt.serverErr != nil {
+						return tt.serverErr
+					}
+					<-stopped
+					if tt.lateServerErr != nil {
+						return tt.lateServerErr
+					}
+					return http.ErrServerClosed
+				},
+				Shutdown: func(ctx context.Context) error {
+					shutdownCalled = true
+					assert.NoError(t, ctx.Err(), "shutdown is detached from the cancelled context")
+					close(stopped)
+					return tt.shutdownErr
+				},
+			}
+
+			svc, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+			}, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, hooks)
+			require.NoError(t, err)
+
+			// The stop hook runs however the server stops
+			hookStopped := false
+			svc.OnStart(func(context.Context) error { return nil })
+			svc.OnStop(func(context.Context) error {
+				hookStopped = true
+				return nil
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			startErrCh := make(chan error, 1)
+			go func() {
+				startErrCh <- svc.StartContext(ctx)
+			}()
+
+			if tt.cancel {
+				time.Sleep(50 * time.Millisecond)
+				cancel()
+			}
+
+			select {
+			case err := <-startErrCh:
+				assert.True(t, hookStopped)
+				assert.Equal(t, tt.cancel, shutdownCalled)
+				if !tt.wantErr {
+					assert.NoError(t, err)
+					return
+				}
+				for _, want := range []error{tt.serverErr, tt.lateServerErr, tt.shutdownErr} {
+					if want != nil {
+						assert.ErrorIs(t, err, want)
+					}
+				}
+			case <-time.After(time.Second):
+				t.Fatal("timeout waiting for StartContext to return")
+			}
+		})
+	}
+}
+
+func TestService_MaxHeaderSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured int
+		want       int
+		wantWarn   bool
+	}{
+		{
+			name:       "within bounds",
+			configured: 64 << 10,
+			want:       64 << 10,
+		},
+		{
+			name:       "oversized value is clamped",
+			configured: 1 << 30,
+			want:       16 << 20,
+			wantWarn:   true,
+		},
+		{
+			name:       "undersized value is clamped",
+			configured: 100,
+			want:       4 << 10,
+			wantWarn:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			// Registered before the basic expectations so it takes precedence
+			deps.configStore.EXPECT().GetInt("server.http.max_header_size").Return(tt.configured, true).AnyTimes()
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+			deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+			deps.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+			// Warned once, although the config is loaded at start and shutdown
+			if tt.wantWarn {
+				deps.logger.EXPECT().WarnWith("Max header size out of bounds, clamping", domainlog.Fields{
+					"configured": tt.configured,
+					"applied":    tt.want,
+					"min":        4 << 10,
+					"max":        16 << 20,
+				}).Times(1)
+			}
+
+			var applied int
+			hooks := &bootstrap.ServerHooks{
+				ListenAndServe: func() error {
+					return http.ErrServerClosed
+				},
+				Shutdown: func(context.Context) error {
+					return nil
+				},
+			}
+
+			svc, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+				Server: bootstrap.ServerOptions{
+					PreStart: func(srv *http.Server) error {
+						applied = srv.MaxHeaderBytes
+						return nil
+					},
+				},
+			}, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, hooks)
+			require.NoError(t, err)
+
+			require.NoError(t, svc.Start())
+			assert.Equal(t, tt.want, applied)
+			require.NoError(t, svc.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestService_EnableH2C(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+	deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+
+	var protoMajor int
+	deps.router.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).
+		Do(func(w http.ResponseWriter, r *http.Request) {
+			protoMajor = r.ProtoMajor
+			w.WriteHeader(http.StatusOK)
+		})
+
+	var server *http.Server
+	svc, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName: "test-service",
+		Version:     "1.0.0",
+		Server: bootstrap.ServerOptions{
+			EnableH2C: true,
+			PreStart: func(srv *http.Server) error {
+				server = srv
+				return nil
+			},
+		},
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, &bootstrap.ServerHooks{
+		ListenAndServe: func() error { return http.ErrServerClosed },
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.Start())
+	require.NotNil(t, server)
+
+	ts := httptest.NewServer(server.Handler)
+	defer ts.Close()
+
+	// Prior knowledge: speak HTTP/2 over plain TCP without an upgrade
+	client := &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, addr)
+			},
+		},
+	}
+
+	resp, err := client.Get(ts.URL + "/hello")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, resp.ProtoMajor)
+	assert.Equal(t, 2, protoMajor)
+}
+
+func TestService_Addr(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.setupBasicMockExpectations(false)
+	deps.configStore.EXPECT().GetInt("server.http.port").Return(0, true).AnyTimes()
+	deps.setupLoggerExpectations()
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+	deps.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	logged := make(chan interface{}, 1)
+	deps.logger.EXPECT().InfoWith("Starting server", gomock.Any()).
+		Do(func(_ string, fields domainlog.Fields) {
+			logged <- fields["address"]
+		})
+
+	svc, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName: "test-service",
+		Version:     "1.0.0",
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, nil)
+	require.NoError(t, err)
+
+	startErr := make(chan error, 1)
+	go func() {
+		startErr <- svc.Start()
+	}()
+
+	// The log reports the bound address rather than ":0"
+	address := <-logged
+	addr := svc.Addr()
+	require.NotNil(t, addr)
+	assert.NotZero(t, addr.(*net.TCPAddr).Port)
+	assert.Equal(t, addr.String(), address)
+
+	require.NoError(t, svc.Shutdown(context.Background()))
+	require.NoError(t, <-startErr)
+}
+
+func TestService_PostStart(t *testing.T) {
+	t.Run("runs with the bound address before serving", func(t *testing.T) {
+		deps := newTestDeps(t)
+		deps.setupBasicMockExpectations(false)
+		deps.configStore.EXPECT().GetInt("server.http.port").Return(0, true).AnyTimes()
+		deps.setupLoggerExpectations()
+		deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+		deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+		deps.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+		deps.router.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).
+			Do(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+		var postStartAddr net.Addr
+		svc, err := bootstrap.NewService(bootstrap.Options{
+			ServiceName: "test-service",
+			Version:     "1.0.0",
+			Server: bootstrap.ServerOptions{
+				PostStart: func(addr net.Addr) error {
+					postStartAddr = addr
+					return nil
+				},
+			},
+		}, bootstrap.Dependencies{
+			ConfigFactory:  deps.configFactory,
+			LoggerFactory:  deps.loggerFactory,
+			RouterFactory:  deps.routerFactory,
+			TracerFactory:  deps.tracerFactory,
+			MetricsFactory: deps.metricsFactory,
+		}, nil)
+		require.NoError(t, err)
+		assert.Nil(t, svc.Addr(), "no address before start")
+
+		startErr := make(chan error, 1)
+		go func() {
+			startErr <- svc.Start()
+		}()
+
+		require.Eventually(t, func() bool { return svc.Addr() != nil }, time.Second, 10*time.Millisecond)
+		require.NotNil(t, postStartAddr)
+		assert.Equal(t, postStartAddr, svc.Addr())
+		assert.NotZero(t, postStartAddr.(*net.TCPAddr).Port, "ephemeral port is resolved")
+
+		port := postStartAddr.(*net.TCPAddr).Port
+		resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/", port))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		require.NoError(t, svc.Shutdown(context.Background()))
+		require.NoError(t, <-startErr)
+	})
+
+	t.Run("error aborts startup and releases the port", func(t *testing.T) {
+		deps := newTestDeps(t)
+		deps.setupBasicMockExpectations(false)
+		deps.configStore.EXPECT().GetInt("server.http.port").Return(0, true).AnyTimes()
+		deps.setupLoggerExpectations()
+		deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+
+		var bound net.Addr
+		svc, err := bootstrap.NewService(bootstrap.Options{
+			ServiceName: "test-service",
+			Version:     "1.0.0",
+			Server: bootstrap.ServerOptions{
+				PostStart: func(addr net.Addr) error {
+					bound = addr
+					return errors.New("registry unavailable")
+				},
+			},
+		}, bootstrap.Dependencies{
+			ConfigFactory:  deps.configFactory,
+			LoggerFactory:  deps.loggerFactory,
+			RouterFactory:  deps.routerFactory,
+			TracerFactory:  deps.tracerFactory,
+			MetricsFactory: deps.metricsFactory,
+		}, nil)
+		require.NoError(t, err)
+
+		err = svc.Start()
+		assert.ErrorContains(t, err, "post-start hook: registry unavailable")
+		assert.Nil(t, svc.Addr())
+
+		ln, err := net.Listen("tcp", bound.String())
+		require.NoError(t, err, "listener is closed on failure")
+		ln.Close()
+	})
+}
+
+func TestService_TLSRequiresCertificate(t *testing.T) {
+	tests := []struct {
+		name     string
+		certFile string
+		keyFile  string
+		wantErr  string
+	}{
+		{
+			name:    "missing cert and key",
+			wantErr: "TLS enabled but cert_file and key_file are not both configured",
+		},
+		{
+			name:     "missing key",
+			certFile: "server.crt",
+			wantErr:  "TLS enabled but cert_file and key_file are not both configured",
+		},
+		{
+			name:     "unreadable cert and key",
+			certFile: "does-not-exist.crt",
+			keyFile:  "does-not-exist.key",
+			wantErr:  "loading TLS cert/key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			// Registered before the basic expectations so they take precedence
+			deps.configStore.EXPECT().GetBool("server.tls.enabled").Return(true, true).AnyTimes()
+			deps.configStore.EXPECT().GetString("server.tls.cert_file").Return(tt.certFile, tt.certFile != "").AnyTimes()
+			deps.configStore.EXPECT().GetString("server.tls.key_file").Return(tt.keyFile, tt.keyFile != "").AnyTimes()
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+
+			serveCalled := false
+			hooks := &bootstrap.ServerHooks{
+				ListenAndServe: func() error {
+					serveCalled = true
+					return http.ErrServerClosed
+				},
+			}
+
+			svc, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+			}, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, hooks)
+			require.NoError(t, err)
+
+			err = svc.Start()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.False(t, serveCalled, "server must not start in plaintext")
+		})
+	}
+}
+
+func TestService_AdminPort(t *testing.T) {
+	// Reserve a free port for the admin listener
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	adminPort := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+
+	deps := newTestDeps(t)
+	// Registered first so it takes precedence over the basic expectations
+	deps.configStore.EXPECT().GetInt("server.http.admin_port").Return(adminPort, true).AnyTimes()
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+	deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+	deps.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).
+		DoAndReturn(func(opts ...domainhttp.Option) (domainhttp.Router, error) {
+			testOpts := &domainhttp.RouterOptions{}
+			for _, opt := range opts {
+				require.NoError(t, opt.ApplyOption(testOpts))
+			}
+			require.NotNil(t, testOpts.InternalRouter)
+
+			// Simulate the router mounting its probes on the internal router
+			testOpts.InternalRouter.Get("/internal/health", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			return deps.router, nil
+		})
+
+	stopped := make(chan struct{})
+	hooks := &bootstrap.ServerHooks{
+		ListenAndServe: func() error {
+			<-stopped
+			return http.ErrServerClosed
+		},
+		Shutdown: func(context.Context) error {
+			close(stopped)
+			return nil
+		},
+	}
+
+	svc, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName: "test-service",
+		Version:     "1.0.0",
+		Server: bootstrap.ServerOptions{
+			AdminPort: adminPort,
+		},
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, hooks)
+	require.NoError(t, err)
+
+	startErrCh := make(chan error, 1)
+	go func() {
+		startErrCh <- svc.Start()
+	}()
+
+	// Wait for the admin listener to serve the internal routes
+	url := fmt.Sprintf("http://127.0.0.1:%d/internal/health", adminPort)
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(url)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, svc.Shutdown(context.Background()))
+
+	select {
+	case err := <-startErrCh:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for Start to return")
+	}
+
+	// The admin listener is closed after shutdown
+	_, err = http.Get(url)
+	assert.Error(t, err)
+}
+
+func TestService_AdminPortConflict(t *testing.T) {
+	// Hold the admin port so binding it fails
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	adminPort := l.Addr().(*net.TCPAddr).Port
+
+	deps := newTestDeps(t)
+	deps.configStore.EXPECT().GetInt("server.http.admin_port").Return(adminPort, true).AnyTimes()
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+
+	serveCalled := false
+	svc, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName: "test-service",
+		Version:     "1.0.0",
+		Server: bootstrap.ServerOptions{
+			AdminPort: adminPort,
+		},
+	}, bootstrap.Dependencies{
+		ConfigFactory: deps.configFactory,
+		LoggerFactory: deps.loggerFactory,
+		RouterFactory: deps.routerFactory,
+	}, &bootstrap.ServerHooks{
+		ListenAndServe: func() error {
+			serveCalled = true
+			return http.ErrServerClosed
+		},
+	})
+	require.NoError(t, err)
+
+	var order []string
+	svc.OnStart(func(context.Context) error {
+		order = append(order, "start")
+		return nil
+	})
+	svc.OnStop(func(context.Context) error {
+		order = append(order, "stop")
+		return nil
+	})
+
+	// The conflict fails startup and unwinds the start hooks
+	err = svc.Start()
+	assert.ErrorContains(t, err, "listening on admin address")
+	assert.Equal(t, []string{"start", "stop"}, order)
+	assert.False(t, serveCalled)
+}
+
+func TestService_KeepAlivesAndConnState(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.setupBasicMockExpectations(true)
+	deps.setupLoggerExpectations()
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+	deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+	deps.router.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).
+		Do(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+	var mu sync.Mutex
+	var states []http.ConnState
+	var server *http.Server
+	svc, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName: "test-service",
+		Version:     "1.0.0",
+		Server: bootstrap.ServerOptions{
+			DisableKeepAlives: true,
+			ConnState: func(_ net.Conn, state http.ConnState) {
+				mu.Lock()
+				defer mu.Unlock()
+				states = append(states, state)
+			},
+			PreStart: func(srv *http.Server) error {
+				server = srv
+				return nil
+			},
+		},
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, &bootstrap.ServerHooks{
+		ListenAndServe: func() error { return http.ErrServerClosed },
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.Start())
+	require.NotNil(t, server)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(ln) }()
+	defer server.Close()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/hello")
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, resp.Close, "keep-alives disabled, so the server closes the connection")
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(states) > 0 && states[len(states)-1] == http.StateClosed
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []http.ConnState{http.StateNew, http.StateActive, http.StateClosed}, states)
+}
+
+// selfSignedPEM returns a PEM encoded self-signed certificate and key for localhost
+func selfSignedPEM(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	return generateCertPEM(t, "localhost", x509.ExtKeyUsageServerAuth)
+}
+
+func generateCertPEM(t *testing.T, commonName string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestService_TLSFromPEM(t *testing.T) {
+	certPEM, keyPEM := selfSignedPEM(t)
+
+	tests := []struct {
+		name     string
+		certFile string
+		certPEM  []byte
+		keyPEM   []byte
+		wantErr  string
+	}{
+		{
+			name:    "cert and key PEM",
+			certPEM: certPEM,
+			keyPEM:  keyPEM,
+		},
+		{
+			name:    "missing key PEM",
+			certPEM: certPEM,
+			wantErr: "TLS enabled but cert PEM and key PEM are not both configured",
+		},
+		{
+			name:    "invalid PEM",
+			certPEM: []byte("not a certificate"),
+			keyPEM:  keyPEM,
+			wantErr: "parsing TLS cert/key PEM",
+		},
+		{
+			name:     "both files and PEM",
+			certFile: "server.crt",
+			certPEM:  certPEM,
+			keyPEM:   keyPEM,
+			wantErr:  "TLS cert and key given as both files and PEM, set only one",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			// Registered before the basic expectations so they take precedence
+			deps.configStore.EXPECT().GetBool("server.tls.enabled").Return(true, true).AnyTimes()
+			deps.configStore.EXPECT().GetString("server.tls.cert_file").Return(tt.certFile, tt.certFile != "").AnyTimes()
+			deps.configStore.EXPECT().GetString("server.tls.key_file").Return("", false).AnyTimes()
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+			deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+			deps.router.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).
+				Do(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}).AnyTimes()
+
+			var server *http.Server
+			svc, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+				Server: bootstrap.ServerOptions{
+					TLSCertPEM: tt.certPEM,
+					TLSKeyPEM:  tt.keyPEM,
+					PreStart: func(srv *http.Server) error {
+						server = srv
+						return nil
+					},
+				},
+			}, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, &bootstrap.ServerHooks{
+				ListenAndServe: func() error { return http.ErrServerClosed },
+			})
+			require.NoError(t, err)
+
+			err = svc.Start()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			// Serve with the configured certificate and complete a handshake
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			go func() { _ = server.ServeTLS(ln, "", "") }()
+			defer server.Close()
+
+			roots := x509.NewCertPool()
+			require.True(t, roots.AppendCertsFromPEM(certPEM))
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+			resp, err := client.Get("https://" + ln.Addr().String() + "/hello")
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func TestService_ReloadCertificates(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	writePair := func(certPEM, keyPEM []byte) {
+		require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+		require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+	}
+	oldCert, oldKey := selfSignedPEM(t)
+	writePair(oldCert, oldKey)
+
+	deps := newTestDeps(t)
+	// Registered before the basic expectations so they take precedence
+	deps.configStore.EXPECT().GetBool("server.tls.enabled").Return(true, true).AnyTimes()
+	deps.configStore.EXPECT().GetString("server.tls.cert_file").Return(certFile, true).AnyTimes()
+	deps.configStore.EXPECT().GetString("server.tls.key_file").Return(keyFile, true).AnyTimes()
+	deps.setupBasicMockExpectations(false)
+	deps.configStore.EXPECT().GetInt("server.http.port").Return(0, true).AnyTimes()
+	deps.setupLoggerExpectations()
+	deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+	deps.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	started := make(chan struct{})
+	reloaded := make(chan struct{}, 1)
+	failed := make(chan struct{}, 1)
+	// A single startup line reports TLS
+	deps.logger.EXPECT().InfoWith("Starting server", gomock.Any()).
+		Do(func(_ string, fields domainlog.Fields) {
+			assert.Equal(t, true, fields["tls"])
+			assert.Equal(t, certFile, fields["cert_file"])
+			close(started)
+		})
+	deps.logger.EXPECT().InfoWith("Starting TLS server", gomock.Any()).Times(0)
+	deps.logger.EXPECT().InfoWith("Reloaded TLS certificate", gomock.Any()).
+		Do(func(string, domainlog.Fields) { reloaded <- struct{}{} })
+	deps.logger.EXPECT().ErrorWith("Failed to reload TLS certificate, keeping current", gomock.Any()).
+		Do(func(string, domainlog.Fields) { failed <- struct{}{} })
+	deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+
+	svc, err := bootstrap.NewService(bootstrap.Options{
+		ServiceName: "test-service",
+		Version:     "1.0.0",
+		Server: bootstrap.ServerOptions{
+			ReloadCertificates: true,
+		},
+	}, bootstrap.Dependencies{
+		ConfigFactory:  deps.configFactory,
+		LoggerFactory:  deps.loggerFactory,
+		RouterFactory:  deps.routerFactory,
+		TracerFactory:  deps.tracerFactory,
+		MetricsFactory: deps.metricsFactory,
+	}, nil)
+	require.NoError(t, err)
+
+	startErr := make(chan error, 1)
+	go func() {
+		startErr <- svc.Start()
+	}()
+	<-started
+
+	// servedCert returns the certificate presented in a fresh handshake
+	servedCert := func() []byte {
+		conn, err := tls.Dial("tcp", svc.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+		require.NoError(t, err)
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].Raw
+	}
+	certDER := func(certPEM []byte) []byte {
+		block, _ := pem.Decode(certPEM)
+		return block.Bytes
+	}
+	sighup := func() {
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	}
+
+	assert.Equal(t, certDER(oldCert), servedCert())
+
+	// Swap the files and signal, new handshakes present the new certificate
+	newCert, newKey := selfSignedPEM(t)
+	writePair(newCert, newKey)
+	sighup()
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("certificate was not reloaded")
+	}
+	assert.Equal(t, certDER(newCert), servedCert())
+
+	// A broken pair is rejected and the last good certificate kept
+	writePair([]byte("not a certificate"), newKey)
+	sighup()
+	select {
+	case <-failed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("failed reload was not logged")
+	}
+	assert.Equal(t, certDER(newCert), servedCert())
+
+	require.NoError(t, svc.Shutdown(context.Background()))
+	require.NoError(t, <-startErr)
+}
+
+func TestService_ClientCertificates(t *testing.T) {
+	serverCert, serverKey := selfSignedPEM(t)
+	clientCert, clientKey := generateCertPEM(t, "orders-service", x509.ExtKeyUsageClientAuth)
+	untrustedCert, untrustedKey := generateCertPEM(t, "intruder", x509.ExtKeyUsageClientAuth)
+
+	// The self-signed client certificate acts as its own CA
+	caFile := filepath.Join(t.TempDir(), "clients.pem")
+	require.NoError(t, os.WriteFile(caFile, clientCert, 0o600))
+	badCAFile := filepath.Join(t.TempDir(), "bad.pem")
+	require.NoError(t, os.WriteFile(badCAFile, []byte("not a certificate"), 0o600))
+
+	pair := func(certPEM, keyPEM []byte) []tls.Certificate {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		require.NoError(t, err)
+		return []tls.Certificate{cert}
+	}
+
+	tests := []struct {
+		name        string
+		caFile      string
+		require     bool
+		clientCerts []tls.Certificate
+		wantSubject string
+		wantErr     string
+		wantDialErr bool
+	}{
+		{
+			name:        "verified client certificate",
+			caFile:      caFile,
+			require:     true,
+			clientCerts: pair(clientCert, clientKey),
+			wantSubject: "orders-service",
+		},
+		{
+			name:        "missing client certificate",
+			caFile:      caFile,
+			require:     true,
+			wantDialErr: true,
+		},
+		{
+			name:        "untrusted client certificate",
+			caFile:      caFile,
+			require:     true,
+			clientCerts: pair(untrustedCert, untrustedKey),
+			wantDialErr: true,
+		},
+		{
+			name:   "optional client certificate omitted",
+			caFile: caFile,
+		},
+		{
+			name:    "required without CA file",
+			require: true,
+			wantErr: "client certificates required but no client CA file configured",
+		},
+		{
+			name:    "CA file without certificates",
+			caFile:  badCAFile,
+			wantErr: "no certificates found in client CA file",
+		},
+		{
+			name:    "missing CA file",
+			caFile:  filepath.Join(t.TempDir(), "missing.pem"),
+			wantErr: "reading client CA file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newTestDeps(t)
+			// Registered before the basic expectations so they take precedence
+			deps.configStore.EXPECT().GetBool("server.tls.enabled").Return(true, true).AnyTimes()
+			deps.configStore.EXPECT().GetString(gomock.Any()).Return("", false).AnyTimes()
+			deps.setupBasicMockExpectations(true)
+			deps.setupLoggerExpectations()
+			deps.logger.EXPECT().InfoWith(gomock.Any(), gomock.Any()).AnyTimes()
+			deps.routerFactory.EXPECT().NewRouter(gomock.Any()).Return(deps.router, nil)
+			deps.router.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).
+				Do(func(w http.ResponseWriter, r *http.Request) {
+					subject, ok := bootstrap.ClientSubject(r.Context())
+					if ok {
+						_, _ = w.Write([]byte(subject.CommonName))
+					}
+				}).AnyTimes()
+
+			var server *http.Server
+			svc, err := bootstrap.NewService(bootstrap.Options{
+				ServiceName: "test-service",
+				Version:     "1.0.0",
+				Server: bootstrap.ServerOptions{
+					TLSCertPEM:        serverCert,
+					TLSKeyPEM:         serverKey,
+					ClientCAFile:      tt.caFile,
+					RequireClientCert: tt.require,
+					PreStart: func(srv *http.Server) error {
+						server = srv
+						return nil
+					},
+				},
+			}, bootstrap.Dependencies{
+				ConfigFactory:  deps.configFactory,
+				LoggerFactory:  deps.loggerFactory,
+				RouterFactory:  deps.routerFactory,
+				TracerFactory:  deps.tracerFactory,
+				MetricsFactory: deps.metricsFactory,
+			}, &bootstrap.ServerHooks{
+				ListenAndServe: func() error { return http.ErrServerClosed },
+			})
+			require.NoError(t, err)
+
+			err = svc.Start()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			go func() { _ = server.ServeTLS(ln, "", "") }()
+			defer server.Close()
+
+			roots := x509.NewCertPool()
+			require.True(t, roots.AppendCertsFromPEM(serverCert))
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+				RootCAs:      roots,
+				Certificates: tt.clientCerts,
+			}}}
+			resp, err := client.Get("https://" + ln.Addr().String() + "/hello")
+			if tt.wantDialErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSubject, string(body))
 		})
 	}
 }
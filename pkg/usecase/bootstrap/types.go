@@ -4,6 +4,7 @@ package bootstrap
 
 import (
 	"crypto/tls"
+	"net"
 	"net/http"
 	"time"
 
@@ -30,22 +31,73 @@ type ServerOptions struct {
 	WriteTimeout    time.Duration
 	ShutdownTimeout time.Duration
 
+	// DrainDelay is how long shutdown waits after failing readiness before
+	// stopping the server, giving load balancers time to stop routing
+	// new requests. Zero stops the server immediately.
+	DrainDelay time.Duration
+
 	// New security options
-	TLSConfig     *tls.Config
-	TLSCertFile   string
-	TLSKeyFile    string
-	MaxHeaderSize int
-	IdleTimeout   time.Duration
+	TLSConfig   *tls.Config
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSCertPEM and TLSKeyPEM supply the certificate and key in memory,
+	// for example from a secret store, instead of TLSCertFile and
+	// TLSKeyFile. Setting them enables TLS.
+	TLSCertPEM []byte
+	TLSKeyPEM  []byte
+	// ReloadCertificates re-reads TLSCertFile and TLSKeyFile on SIGHUP
+	// while serving, so rotated certificates are used for new connections
+	// without a restart. Ignored when the certificate is given as PEM.
+	ReloadCertificates bool
+	// ClientCAFile is a PEM bundle of CAs trusted to sign client
+	// certificates. When set, client certificates presented during the
+	// handshake are verified and the subject is available to handlers via
+	// ClientSubject.
+	ClientCAFile string
+	// RequireClientCert rejects connections without a client certificate
+	// signed by ClientCAFile (mutual TLS).
+	RequireClientCert bool
+	MaxHeaderSize     int
+	IdleTimeout       time.Duration
+
+	// Admin listener
+	// When non-zero, probes, metrics and diagnostics endpoints are served
+	// on this port by a separate server instead of the main listener.
+	AdminPort int
+
+	// EnableH2C serves HTTP/2 without TLS (h2c), including prior-knowledge
+	// connections, alongside HTTP/1.1. Ignored when TLS is enabled, where
+	// HTTP/2 is negotiated via ALPN.
+	EnableH2C bool
+
+	// DisableKeepAlives closes each connection after its response, so
+	// load balancers rebalance on every request
+	DisableKeepAlives bool
+
+	// ConnState is called when a client connection to the main listener
+	// changes state, for example to count open connections
+	ConnState func(net.Conn, http.ConnState)
 
 	// Server customization
 	PreStart func(*http.Server) error
+	// PostStart runs once the listener is bound, with its actual address
+	// (useful with Port 0), before requests are served. An error aborts
+	// startup. Typically used to register with service discovery.
+	PostStart func(addr net.Addr) error
 }
 
+// Deployment environments with built-in defaults
+const (
+	EnvironmentDevelopment = "dev"
+	EnvironmentProduction  = "prod"
+)
+
 // Options configures the bootstrap service.
 type Options struct {
 	// Service Identity
 	ServiceName string
 	Version     string
+	Environment string // Deployment environment, e.g. EnvironmentDevelopment or EnvironmentProduction
 
 	// Configuration
 	ConfigFile         string
@@ -57,6 +109,8 @@ type Options struct {
 	LogLevel        logging.Level
 	LogFields       logging.Fields
 	EnableLogConfig bool // Whether to mount runtime log config endpoint
+	LogBufferSize   int  // Number of recent log entries retained in memory (0 disables)
+	EnableLogViewer bool // Whether to mount /internal/logs (requires LogBufferSize)
 
 	// HTTP Server
 	Server ServerOptions
@@ -65,12 +119,47 @@ type Options struct {
 	Router domainhttp.RouterOptions
 
 	// Router/Observability
-	ExcludeFromLogging []string
-	ExcludeFromTracing []string
-	ProbeHandlers      *domainhttp.ProbeHandlers
+	ExcludeFromLogging  []string
+	ExcludeFromTracing  []string
+	ProbeHandlers       *domainhttp.ProbeHandlers
+	DefaultProbeDetails bool // Whether default probes report goroutines and memory (ignored with ProbeHandlers)
+	EnablePprof         bool // Whether to mount pprof endpoints under /internal/debug/pprof
+	EnableInfo          bool // Whether to mount /internal/info with identity, build, uptime, log level, tracing and feature flags
+	EnableRouteViewer   bool // Whether to mount /internal/routes listing registered methods and patterns
+
+	// Metrics
+	MetricsBuckets        []float64 // Latency histogram buckets in ascending order (default Prometheus buckets if empty)
+	DisableRuntimeMetrics bool      // Whether to omit Go runtime and process metrics from /metrics
+	// EnableStandardMetrics adds build_info and config_last_load_timestamp_seconds
+	// to the runtime metrics. Requires a metrics factory.
+	EnableStandardMetrics bool
 
 	// Tracing
-	TracingEndpoint    string
-	TracingSampleRate  float64
+	// TracingEndpoint is the OTLP collector spans are exported to. Logs are
+	// not exported from it; to export them too, create the logger factory
+	// with logging.NewFactoryWithOptions and logging.WithOTLPExport.
+	TracingEndpoint string
+	// TracingSampleRate is the fraction of traces sampled. When nil it
+	// defaults from Environment: 0.01 in production, otherwise 1.0. An
+	// explicit 0 samples nothing. Set it with SampleRate.
+	TracingSampleRate *float64
+	// ResourceAttributes describe this deployment, for example
+	// deployment.environment or host.name. They are added to the tracing
+	// resource, so every span carries them, and to every log entry.
+	// LogFields take precedence for log entries.
+	ResourceAttributes map[string]string
+	// TracingRateLimit caps the number of traces started per second,
+	// taking precedence over TracingSampleRate when set.
+	TracingRateLimit   float64
 	TracingPropagators []string
+	// TracingShutdownTimeout bounds how long shutdown waits for pending
+	// spans, and log entries when the logger exports them, so an
+	// unreachable collector does not delay exit. Defaults to 5s.
+	TracingShutdownTimeout time.Duration
+}
+
+// SampleRate returns rate for Options.TracingSampleRate, whose nil value
+// selects the environment default
+func SampleRate(rate float64) *float64 {
+	return &rate
 }